@@ -0,0 +1,312 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/health" // enables client-side health checking
+	"google.golang.org/grpc/serviceconfig"
+	"google.golang.org/grpc/status"
+)
+
+// BalancingPolicy selects the endpoint of each call.
+type BalancingPolicy int
+
+const (
+	// RoundRobin sends calls to endpoints in turn.
+	RoundRobin BalancingPolicy = iota
+	// LeastRequest sends each call to the endpoint with fewer calls in flight
+	// out of two picked at random, steering load away from slow endpoints.
+	LeastRequest
+)
+
+// LoadBalancingConfig configures how calls are spread across the endpoints a
+// target resolves to, such as those given by WithEndpoints.
+type LoadBalancingConfig struct {
+	Policy BalancingPolicy
+	// HealthCheck only sends calls to endpoints whose grpc_health_v1 service
+	// reports the frontend service as SERVING.
+	HealthCheck bool
+	// EjectAfter ejects an endpoint after this many failed calls in a row, so
+	// it gets no calls for EjectionTime. Zero disables ejection.
+	EjectAfter   int
+	EjectionTime time.Duration
+	// MaxEjectedPercent stops ejecting once this percentage of the endpoints
+	// is ejected. If every endpoint is ejected anyway, calls ignore ejection.
+	MaxEjectedPercent int
+	// FailureCodes lists the status codes counted as failures.
+	FailureCodes []codes.Code
+}
+
+// DefaultLoadBalancingConfig returns the configuration used with
+// WithEndpoints, WithEndpointsFile and WithDNSSRV unless set otherwise:
+// round-robin across healthy endpoints, ejecting an endpoint for 30s after 5
+// consecutive failures, but at most half of them.
+func DefaultLoadBalancingConfig() LoadBalancingConfig {
+	return LoadBalancingConfig{
+		Policy:            RoundRobin,
+		HealthCheck:       true,
+		EjectAfter:        5,
+		EjectionTime:      30 * time.Second,
+		MaxEjectedPercent: 50,
+		FailureCodes: []codes.Code{
+			codes.Unavailable,
+			codes.DeadlineExceeded,
+			codes.Internal,
+			codes.Unknown,
+		},
+	}
+}
+
+// WithLoadBalancing spreads calls across all endpoints the target resolves
+// to, instead of gRPC's default of using the first one that connects.
+func WithLoadBalancing(config LoadBalancingConfig) Option {
+	return func(c *clientConfig) {
+		c.balancing = &config
+	}
+}
+
+// balancerName registers the endpoint balancer with gRPC.
+const balancerName = "gh_go_endpoints"
+
+func init() {
+	balancer.Register(endpointsBuilder{})
+}
+
+// balancerConfig is the service config form of LoadBalancingConfig.
+type balancerConfig struct {
+	serviceconfig.LoadBalancingConfig `json:"-"`
+
+	LeastRequest      bool         `json:"leastRequest,omitempty"`
+	EjectAfter        int          `json:"ejectAfter,omitempty"`
+	EjectionTime      string       `json:"ejectionTime,omitempty"`
+	MaxEjectedPercent int          `json:"maxEjectedPercent,omitempty"`
+	FailureCodes      []codes.Code `json:"failureCodes,omitempty"`
+
+	ejectionTime time.Duration
+}
+
+func newBalancerConfig(config LoadBalancingConfig) (*balancerConfig, error) {
+	if config.Policy != RoundRobin && config.Policy != LeastRequest {
+		return nil, fmt.Errorf("unknown balancing policy %d", config.Policy)
+	}
+	if config.EjectAfter < 0 {
+		return nil, fmt.Errorf("eject after must not be negative, got %d", config.EjectAfter)
+	}
+	if config.EjectAfter > 0 && config.EjectionTime <= 0 {
+		return nil, fmt.Errorf("ejection time must be positive, got %v", config.EjectionTime)
+	}
+	if config.MaxEjectedPercent < 0 || config.MaxEjectedPercent > 100 {
+		return nil, fmt.Errorf("max ejected percent must be between 0 and 100, got %d", config.MaxEjectedPercent)
+	}
+
+	return &balancerConfig{
+		LeastRequest:      config.Policy == LeastRequest,
+		EjectAfter:        config.EjectAfter,
+		EjectionTime:      durationJSON(config.EjectionTime),
+		MaxEjectedPercent: config.MaxEjectedPercent,
+		FailureCodes:      config.FailureCodes,
+	}, nil
+}
+
+// endpointsBuilder builds an endpointBalancer per connection.
+type endpointsBuilder struct{}
+
+func (endpointsBuilder) Name() string {
+	return balancerName
+}
+
+func (endpointsBuilder) ParseConfig(data json.RawMessage) (serviceconfig.LoadBalancingConfig, error) {
+	config := &balancerConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s config: %w", balancerName, err)
+	}
+	if config.EjectionTime != "" {
+		var err error
+		if config.ejectionTime, err = time.ParseDuration(config.EjectionTime); err != nil {
+			return nil, fmt.Errorf("failed to parse %s ejection time: %w", balancerName, err)
+		}
+	}
+	return config, nil
+}
+
+func (endpointsBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	meter := otel.Meter(instrumentationName)
+	ejections, err := meter.Int64Counter("client.endpoint.ejections",
+		otelmetric.WithDescription("Number of endpoints ejected for failing calls."))
+	if err != nil {
+		otel.Handle(err)
+		ejections = noop.Int64Counter{}
+	}
+
+	lb := &endpointBalancer{
+		config:    &balancerConfig{},
+		endpoints: make(map[string]*endpoint),
+		ejections: ejections,
+	}
+	// The base balancer manages connections and health checks, and asks lb
+	// for a picker whenever the set of ready endpoints changes
+	lb.Balancer = base.NewBalancerBuilder(balancerName, lb, base.Config{HealthCheck: true}).Build(cc, opts)
+	return lb
+}
+
+// endpointBalancer balances calls across ready endpoints, ejecting outliers.
+type endpointBalancer struct {
+	balancer.Balancer
+
+	ejections otelmetric.Int64Counter
+
+	mu        sync.Mutex
+	config    *balancerConfig
+	endpoints map[string]*endpoint // by address, kept across pickers
+}
+
+func (lb *endpointBalancer) UpdateClientConnState(state balancer.ClientConnState) error {
+	if config, ok := state.BalancerConfig.(*balancerConfig); ok {
+		lb.mu.Lock()
+		lb.config = config
+		lb.mu.Unlock()
+	}
+	return lb.Balancer.UpdateClientConnState(state)
+}
+
+// Build implements base.PickerBuilder.
+func (lb *endpointBalancer) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	p := &picker{lb: lb, config: lb.config}
+	ready := make(map[string]*endpoint, len(info.ReadySCs))
+	for sc, scInfo := range info.ReadySCs {
+		e, ok := lb.endpoints[scInfo.Address.Addr]
+		if !ok {
+			e = &endpoint{addr: scInfo.Address.Addr}
+		}
+		ready[e.addr] = e
+		p.endpoints = append(p.endpoints, readyEndpoint{endpoint: e, sc: sc})
+	}
+	lb.endpoints = ready
+
+	// Keep the order stable so round-robin visits every endpoint in turn
+	slices.SortFunc(p.endpoints, func(a, b readyEndpoint) int { return strings.Compare(a.addr, b.addr) })
+	return p
+}
+
+// record updates the endpoint's failure count with the outcome of a call and
+// ejects it once it failed too often in a row.
+func (lb *endpointBalancer) record(e *endpoint, config *balancerConfig, err error) {
+	if config.EjectAfter == 0 {
+		return
+	}
+
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	if err == nil || !slices.Contains(config.FailureCodes, status.Code(err)) {
+		e.failures = 0
+		return
+	}
+
+	e.failures++
+	now := time.Now()
+	if e.failures < config.EjectAfter || e.ejected(now) {
+		return
+	}
+
+	ejected := 0
+	for _, other := range lb.endpoints {
+		if other.ejected(now) {
+			ejected++
+		}
+	}
+	if ejected*100 >= config.MaxEjectedPercent*len(lb.endpoints) {
+		return
+	}
+
+	e.ejectedUntil.Store(now.Add(config.ejectionTime).UnixNano())
+	e.failures = 0
+	lb.ejections.Add(context.Background(), 1, otelmetric.WithAttributes(attribute.String("endpoint", e.addr)))
+}
+
+// endpoint is the load and outlier ejection state of an endpoint.
+type endpoint struct {
+	addr     string
+	inFlight atomic.Int64
+
+	// Guarded by endpointBalancer.mu
+	failures int
+	// ejectedUntil is read without the lock when picking
+	ejectedUntil atomic.Int64
+}
+
+func (e *endpoint) ejected(now time.Time) bool {
+	return now.UnixNano() < e.ejectedUntil.Load()
+}
+
+// picker picks the endpoint of each call among the ready ones.
+type picker struct {
+	lb        *endpointBalancer
+	config    *balancerConfig
+	endpoints []readyEndpoint
+	next      atomic.Uint64
+}
+
+type readyEndpoint struct {
+	*endpoint
+	sc balancer.SubConn
+}
+
+func (p *picker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	now := time.Now()
+	candidates := make([]readyEndpoint, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		if !e.ejected(now) {
+			candidates = append(candidates, e)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = p.endpoints
+	}
+
+	var e readyEndpoint
+	if p.config.LeastRequest && len(candidates) > 1 {
+		i := rand.IntN(len(candidates))
+		j := rand.IntN(len(candidates) - 1)
+		if j >= i {
+			j++
+		}
+		e = candidates[i]
+		if candidates[j].inFlight.Load() < e.inFlight.Load() {
+			e = candidates[j]
+		}
+	} else {
+		e = candidates[(p.next.Add(1)-1)%uint64(len(candidates))]
+	}
+
+	e.inFlight.Add(1)
+	return balancer.PickResult{
+		SubConn: e.sc,
+		Done: func(info balancer.DoneInfo) {
+			e.inFlight.Add(-1)
+			p.lb.record(e.endpoint, p.config, info.Err)
+		},
+	}, nil
+}
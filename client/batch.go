@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+// BatchConfig configures request batching.
+type BatchConfig struct {
+	// Linger is how long the first call of a batch waits for others to join.
+	Linger time.Duration
+	// MaxBatch sends a batch as soon as it holds this many calls.
+	MaxBatch int
+}
+
+// WithBatching coalesces concurrent Put and Get calls into BatchPut and
+// BatchGet calls, trading up to Linger of latency for fewer round trips.
+// Each caller still gets its own result. Batched puts are applied atomically,
+// so a failed BatchPut fails every Put in it.
+func WithBatching(config BatchConfig) Option {
+	return func(c *clientConfig) {
+		c.batch = &config
+	}
+}
+
+// pendingCall is a call waiting for its batch to complete.
+type pendingCall[Req, Res any] struct {
+	ctx  context.Context
+	req  Req
+	res  Res
+	err  error
+	done chan struct{}
+}
+
+// batcher groups calls made within the linger window into batches of at most
+// maxBatch calls, sent with send. send returns one result per request, in
+// order, or an error for the whole batch.
+type batcher[Req, Res any] struct {
+	config BatchConfig
+	send   func(ctx context.Context, reqs []Req) ([]Res, []error, error)
+
+	mu      sync.Mutex
+	pending []*pendingCall[Req, Res]
+	timer   *time.Timer
+	flushes sync.WaitGroup
+}
+
+func newBatcher[Req, Res any](config BatchConfig, send func(context.Context, []Req) ([]Res, []error, error)) *batcher[Req, Res] {
+	return &batcher[Req, Res]{config: config, send: send}
+}
+
+// do adds req to the current batch and waits for its result.
+func (b *batcher[Req, Res]) do(ctx context.Context, req Req) (Res, error) {
+	call := &pendingCall[Req, Res]{ctx: ctx, req: req, done: make(chan struct{})}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, call)
+	switch {
+	case len(b.pending) >= b.config.MaxBatch:
+		b.flushLocked()
+	case len(b.pending) == 1:
+		b.timer = time.AfterFunc(b.config.Linger, b.flush)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.res, call.err
+	case <-ctx.Done():
+		var zero Res
+		return zero, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (b *batcher[Req, Res]) flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.flushLocked()
+}
+
+// flushLocked sends the pending calls as a batch in the background.
+func (b *batcher[Req, Res]) flushLocked() {
+	if len(b.pending) == 0 {
+		return
+	}
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	calls := b.pending
+	b.pending = nil
+	b.flushes.Go(func() {
+		b.sendBatch(calls)
+	})
+}
+
+func (b *batcher[Req, Res]) sendBatch(calls []*pendingCall[Req, Res]) {
+	ctx, cancel := batchContext(calls)
+	defer cancel()
+
+	reqs := make([]Req, len(calls))
+	for i, call := range calls {
+		reqs[i] = call.req
+	}
+
+	results, errs, err := b.send(ctx, reqs)
+	if err == nil && (len(results) != len(calls) || len(errs) != len(calls)) {
+		err = status.Errorf(codes.Internal, "batch of %d calls returned %d results", len(calls), len(results))
+	}
+
+	for i, call := range calls {
+		if err != nil {
+			call.err = err
+		} else {
+			call.res, call.err = results[i], errs[i]
+		}
+		close(call.done)
+	}
+}
+
+// close sends any pending calls and waits for all batches to complete.
+func (b *batcher[Req, Res]) close() {
+	b.flush()
+	b.flushes.Wait()
+}
+
+// batchContext derives the context of a batch from its calls: it carries the
+// values of the first call and, if every call has a deadline, the latest one.
+// Callers that give up earlier stop waiting without failing the others.
+func batchContext[Req, Res any](calls []*pendingCall[Req, Res]) (context.Context, context.CancelFunc) {
+	ctx := context.WithoutCancel(calls[0].ctx)
+
+	var latest time.Time
+	for _, call := range calls {
+		deadline, ok := call.ctx.Deadline()
+		if !ok {
+			return context.WithCancel(ctx)
+		}
+		if deadline.After(latest) {
+			latest = deadline
+		}
+	}
+	return context.WithDeadline(ctx, latest)
+}
+
+// newPutBatcher batches puts into BatchPut calls.
+func newPutBatcher(config BatchConfig, client frontendpb.FrontendServiceClient) *batcher[*frontendpb.PutRequest, struct{}] {
+	return newBatcher(config, func(ctx context.Context, reqs []*frontendpb.PutRequest) ([]struct{}, []error, error) {
+		_, err := client.BatchPut(ctx, frontendpb.BatchPutRequest_builder{Entries: reqs}.Build())
+		if err != nil {
+			return nil, nil, err
+		}
+		return make([]struct{}, len(reqs)), make([]error, len(reqs)), nil
+	})
+}
+
+// newGetBatcher batches gets into BatchGet calls, reporting missing keys as
+// NotFound like Get does.
+func newGetBatcher(config BatchConfig, client frontendpb.FrontendServiceClient) *batcher[int64, string] {
+	return newBatcher(config, func(ctx context.Context, keys []int64) ([]string, []error, error) {
+		resp, err := client.BatchGet(ctx, frontendpb.BatchGetRequest_builder{Keys: keys}.Build())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		values := make([]string, len(resp.GetResults()))
+		errs := make([]error, len(resp.GetResults()))
+		for i, result := range resp.GetResults() {
+			values[i] = result.GetValue()
+			if !result.GetFound() {
+				errs[i] = status.Errorf(codes.NotFound, "key %d not found", result.GetKey())
+			}
+		}
+		return values, errs, nil
+	})
+}
@@ -0,0 +1,341 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// instrumentationName identifies the client's OpenTelemetry instruments.
+const instrumentationName = "github.com/dynoinc/gh-go/client"
+
+// ErrCircuitOpen is returned, wrapped, by calls rejected because the circuit
+// breaker for their target is open. The error also carries an Unavailable
+// gRPC status.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all calls through while counting failures.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects all calls until the cool-down has passed.
+	BreakerOpen
+	// BreakerHalfOpen lets a limited number of probe calls through to decide
+	// whether to close or re-open the circuit.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig configures the circuit breaker.
+type BreakerConfig struct {
+	// ConsecutiveFailures opens the circuit after this many failed calls in a
+	// row. Zero disables the check.
+	ConsecutiveFailures int
+	// FailureRatio opens the circuit once the fraction of failed calls within
+	// the current Interval reaches it, provided at least MinRequests calls were
+	// made. Zero disables the check.
+	FailureRatio float64
+	MinRequests  int
+	// Interval is how often the closed circuit resets its counts. Zero keeps
+	// counting until the circuit opens.
+	Interval time.Duration
+	// Cooldown is how long the circuit stays open before probing.
+	Cooldown time.Duration
+	// HalfOpenCalls is the number of probe calls let through when half-open;
+	// the circuit closes once that many succeed.
+	HalfOpenCalls int
+	// FailureCodes lists the status codes counted as failures. Other errors,
+	// such as NotFound, show the target is healthy.
+	FailureCodes []codes.Code
+	// OnStateChange, if set, is called on every transition, in order. It runs
+	// after the breaker's lock is released, so it may call the client.
+	OnStateChange func(target string, from, to BreakerState)
+}
+
+// DefaultBreakerConfig returns a breaker that opens after 5 consecutive
+// failures, or half the calls failing out of at least 20 in 10s, and probes
+// again with a single call after 5s.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		ConsecutiveFailures: 5,
+		FailureRatio:        0.5,
+		MinRequests:         20,
+		Interval:            10 * time.Second,
+		Cooldown:            5 * time.Second,
+		HalfOpenCalls:       1,
+		FailureCodes: []codes.Code{
+			codes.Unavailable,
+			codes.DeadlineExceeded,
+			codes.ResourceExhausted,
+			codes.Internal,
+			codes.Unknown,
+		},
+	}
+}
+
+// WithCircuitBreaker fails calls fast with ErrCircuitOpen while the target
+// keeps failing, instead of letting callers pile up behind it.
+func WithCircuitBreaker(config BreakerConfig) Option {
+	return func(c *clientConfig) {
+		c.breaker = &config
+	}
+}
+
+// circuitOpenError is returned for rejected calls.
+type circuitOpenError struct {
+	target string
+}
+
+func (e *circuitOpenError) Error() string {
+	return "client: " + ErrCircuitOpen.Error() + " for " + e.target
+}
+
+func (e *circuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
+
+func (e *circuitOpenError) GRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, e.Error())
+}
+
+// breakers holds one breaker per target and the instruments they report to.
+type breakers struct {
+	config BreakerConfig
+
+	transitions otelmetric.Int64Counter
+	rejected    otelmetric.Int64Counter
+	state       otelmetric.Int64Gauge
+
+	mu       sync.Mutex
+	byTarget map[string]*breaker
+}
+
+func newBreakers(config BreakerConfig) (*breakers, error) {
+	meter := otel.Meter(instrumentationName)
+
+	transitions, err := meter.Int64Counter("client.circuit_breaker.transitions",
+		otelmetric.WithDescription("Number of circuit breaker state changes."))
+	if err != nil {
+		return nil, err
+	}
+
+	rejected, err := meter.Int64Counter("client.circuit_breaker.rejected",
+		otelmetric.WithDescription("Number of calls rejected by an open circuit breaker."))
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := meter.Int64Gauge("client.circuit_breaker.state",
+		otelmetric.WithDescription("Circuit breaker state: 0 closed, 1 open, 2 half-open."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &breakers{
+		config:      config,
+		transitions: transitions,
+		rejected:    rejected,
+		state:       state,
+		byTarget:    make(map[string]*breaker),
+	}, nil
+}
+
+func (b *breakers) get(target string) *breaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br, ok := b.byTarget[target]
+	if !ok {
+		br = &breaker{parent: b, target: target, windowStart: time.Now()}
+		b.byTarget[target] = br
+	}
+	return br
+}
+
+// interceptor rejects calls while the breaker of the connection's target is
+// open and records the outcome of the others.
+func (b *breakers) interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		br := b.get(cc.Target())
+
+		generation, err := br.allow(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		br.record(ctx, generation, err == nil || !slices.Contains(b.config.FailureCodes, status.Code(err)))
+		return err
+	}
+}
+
+// breaker is the circuit breaker of a single target.
+type breaker struct {
+	parent *breakers
+	target string
+
+	mu                  sync.Mutex
+	state               BreakerState
+	generation          uint64 // bumped on every transition to drop stale results
+	openedAt            time.Time
+	windowStart         time.Time
+	calls, failures     int
+	consecutiveFailures int
+	probes, successes   int
+
+	pending   []transition // transitions yet to be reported to OnStateChange
+	notifying bool
+}
+
+type transition struct {
+	from, to BreakerState
+}
+
+// allow admits a call, returning the generation its result belongs to.
+func (b *breaker) allow(ctx context.Context) (uint64, error) {
+	defer b.notify()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	config := b.parent.config
+	now := time.Now()
+
+	switch b.state {
+	case BreakerClosed:
+		if config.Interval > 0 && now.Sub(b.windowStart) >= config.Interval {
+			b.resetCounts(now)
+		}
+	case BreakerOpen:
+		if now.Sub(b.openedAt) < config.Cooldown {
+			b.parent.rejected.Add(ctx, 1, otelmetric.WithAttributes(attribute.String("target", b.target)))
+			return 0, &circuitOpenError{target: b.target}
+		}
+		b.transition(ctx, BreakerHalfOpen, now)
+		fallthrough
+	case BreakerHalfOpen:
+		if b.probes >= max(config.HalfOpenCalls, 1) {
+			b.parent.rejected.Add(ctx, 1, otelmetric.WithAttributes(attribute.String("target", b.target)))
+			return 0, &circuitOpenError{target: b.target}
+		}
+		b.probes++
+	}
+
+	return b.generation, nil
+}
+
+// record accounts for the outcome of a call admitted in generation.
+func (b *breaker) record(ctx context.Context, generation uint64, success bool) {
+	defer b.notify()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	config := b.parent.config
+	now := time.Now()
+
+	switch b.state {
+	case BreakerClosed:
+		b.calls++
+		if success {
+			b.consecutiveFailures = 0
+			return
+		}
+		b.failures++
+		b.consecutiveFailures++
+
+		tooManyInARow := config.ConsecutiveFailures > 0 && b.consecutiveFailures >= config.ConsecutiveFailures
+		tooManyOverall := config.FailureRatio > 0 && b.calls >= config.MinRequests &&
+			float64(b.failures)/float64(b.calls) >= config.FailureRatio
+		if tooManyInARow || tooManyOverall {
+			b.transition(ctx, BreakerOpen, now)
+		}
+	case BreakerHalfOpen:
+		if !success {
+			b.transition(ctx, BreakerOpen, now)
+			return
+		}
+		b.successes++
+		if b.successes >= max(config.HalfOpenCalls, 1) {
+			b.transition(ctx, BreakerClosed, now)
+		}
+	}
+}
+
+func (b *breaker) transition(ctx context.Context, to BreakerState, now time.Time) {
+	from := b.state
+	b.state = to
+	b.generation++
+	b.resetCounts(now)
+	if to == BreakerOpen {
+		b.openedAt = now
+	}
+
+	targetAttr := attribute.String("target", b.target)
+	b.parent.transitions.Add(ctx, 1, otelmetric.WithAttributes(
+		targetAttr,
+		attribute.String("from", from.String()),
+		attribute.String("to", to.String()),
+	))
+	b.parent.state.Record(ctx, int64(to), otelmetric.WithAttributes(targetAttr))
+
+	if b.parent.config.OnStateChange != nil {
+		b.pending = append(b.pending, transition{from: from, to: to})
+	}
+}
+
+// notify reports pending transitions to OnStateChange without holding the
+// lock. A single caller reports at a time, so transitions are reported in
+// order, including those caused by the callback itself.
+func (b *breaker) notify() {
+	if b.parent.config.OnStateChange == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.notifying {
+		return
+	}
+	b.notifying = true
+	defer func() { b.notifying = false }()
+
+	for len(b.pending) > 0 {
+		t := b.pending[0]
+		b.pending = b.pending[1:]
+		b.mu.Unlock()
+		b.parent.config.OnStateChange(b.target, t.from, t.to)
+		b.mu.Lock()
+	}
+}
+
+func (b *breaker) resetCounts(now time.Time) {
+	b.windowStart = now
+	b.calls, b.failures, b.consecutiveFailures = 0, 0, 0
+	b.probes, b.successes = 0, 0
+}
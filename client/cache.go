@@ -0,0 +1,310 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+const (
+	// watchMinBackoff and watchMaxBackoff bound the delay between attempts to
+	// re-establish the invalidation stream.
+	watchMinBackoff = 100 * time.Millisecond
+	watchMaxBackoff = 10 * time.Second
+)
+
+// CacheConfig configures the client-side read cache.
+type CacheConfig struct {
+	// Size is the maximum number of cached keys; the least recently used key
+	// is evicted beyond it.
+	Size int
+	// TTL bounds how long a value is served from the cache.
+	TTL time.Duration
+	// NegativeTTL is how long a NotFound result is cached. Zero disables
+	// negative caching.
+	NegativeTTL time.Duration
+}
+
+// WithCache serves Get from an in-process LRU cache. Entries are invalidated
+// as soon as the server streams a change of their key; while that stream is
+// down the cache is emptied and bypassed. Against servers that don't support
+// Watch, entries are only bounded by the TTL.
+//
+// Concurrent misses of the same key are coalesced into a single call, made
+// with the context of the first caller.
+func WithCache(config CacheConfig) Option {
+	return func(c *clientConfig) {
+		c.cache = &config
+	}
+}
+
+type watchState int
+
+const (
+	watchConnecting  watchState = iota // cache bypassed until the stream is up
+	watchConnected                     // changes are streamed, cache is usable
+	watchUnsupported                   // server has no Watch, rely on the TTL
+)
+
+type cacheEntry struct {
+	key      int64
+	value    string
+	notFound bool
+	expires  time.Time
+}
+
+// readCache is an LRU cache of Get results.
+type readCache struct {
+	config CacheConfig
+	client frontendpb.FrontendServiceClient
+	group  singleflight.Group
+
+	hits          otelmetric.Int64Counter
+	misses        otelmetric.Int64Counter
+	invalidations otelmetric.Int64Counter
+
+	mu      sync.Mutex
+	entries map[int64]*list.Element
+	lru     *list.List // of *cacheEntry, most recently used first
+	fills   map[int64]*pendingFill
+	epoch   uint64 // bumped when changes may have been missed to drop all racing fills
+	state   watchState
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newReadCache(config CacheConfig, client frontendpb.FrontendServiceClient) (*readCache, error) {
+	meter := otel.Meter(instrumentationName)
+
+	hits, err := meter.Int64Counter("client.cache.hits",
+		otelmetric.WithDescription("Number of Get calls served from the cache."))
+	if err != nil {
+		return nil, err
+	}
+
+	misses, err := meter.Int64Counter("client.cache.misses",
+		otelmetric.WithDescription("Number of Get calls not served from the cache."))
+	if err != nil {
+		return nil, err
+	}
+
+	invalidations, err := meter.Int64Counter("client.cache.invalidations",
+		otelmetric.WithDescription("Number of cache entries invalidated by changes."))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &readCache{
+		config:        config,
+		client:        client,
+		hits:          hits,
+		misses:        misses,
+		invalidations: invalidations,
+		entries:       make(map[int64]*list.Element),
+		fills:         make(map[int64]*pendingFill),
+		lru:           list.New(),
+		cancel:        cancel,
+		done:          make(chan struct{}),
+	}
+	go c.watch(ctx)
+
+	return c, nil
+}
+
+// close stops watching for changes.
+func (c *readCache) close() {
+	c.cancel()
+	<-c.done
+}
+
+// get serves key from the cache, or reads it with fetch on a miss.
+func (c *readCache) get(ctx context.Context, key int64, fetch func(context.Context, int64) (string, error)) (string, error) {
+	if entry, ok := c.lookup(key); ok {
+		c.hits.Add(ctx, 1, otelmetric.WithAttributes(attribute.Bool("negative", entry.notFound)))
+		if entry.notFound {
+			return "", status.Errorf(codes.NotFound, "key %d not found (cached)", key)
+		}
+		return entry.value, nil
+	}
+	c.misses.Add(ctx, 1)
+
+	value, err, _ := c.group.Do(strconv.FormatInt(key, 10), func() (any, error) {
+		pending := c.startFill(key)
+		defer c.endFill(key, pending)
+
+		value, err := fetch(ctx, key)
+		switch {
+		case err == nil:
+			c.fill(pending, &cacheEntry{key: key, value: value, expires: time.Now().Add(c.config.TTL)})
+			return value, nil
+		case status.Code(err) == codes.NotFound && c.config.NegativeTTL > 0:
+			c.fill(pending, &cacheEntry{key: key, notFound: true, expires: time.Now().Add(c.config.NegativeTTL)})
+		}
+		return "", err
+	})
+	return value.(string), err
+}
+
+func (c *readCache) lookup(key int64) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry, true
+}
+
+// pendingFill is a read of a key on a miss, whose result is cached unless
+// the key is invalidated while it's in flight.
+type pendingFill struct {
+	epoch uint64
+	stale bool
+}
+
+// startFill registers a read of key. The singleflight group makes sure there
+// is at most one per key.
+func (c *readCache) startFill(key int64) *pendingFill {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pending := &pendingFill{epoch: c.epoch}
+	c.fills[key] = pending
+	return pending
+}
+
+func (c *readCache) endFill(key int64, pending *pendingFill) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.fills[key] == pending {
+		delete(c.fills, key)
+	}
+}
+
+// fill caches an entry read by pending, unless its key has been invalidated
+// since, as the entry may then predate the change.
+func (c *readCache) fill(pending *pendingFill, entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if pending.stale || pending.epoch != c.epoch || c.state == watchConnecting || c.config.Size <= 0 {
+		return
+	}
+
+	if elem, ok := c.entries[entry.key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.config.Size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// invalidate drops the cached entry of a changed key.
+func (c *readCache) invalidate(ctx context.Context, key int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if pending, ok := c.fills[key]; ok {
+		pending.stale = true
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		c.invalidations.Add(ctx, 1)
+	}
+}
+
+func (c *readCache) setState(state watchState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Changes may have been missed while not connected
+	if state == watchConnecting {
+		c.epoch++
+		clear(c.entries)
+		c.lru.Init()
+	}
+	c.state = state
+}
+
+// watch keeps an invalidation stream open until ctx is cancelled.
+func (c *readCache) watch(ctx context.Context) {
+	defer close(c.done)
+
+	backoff := watchMinBackoff
+	for {
+		connected, err := c.watchOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			c.setState(watchUnsupported)
+			return
+		}
+
+		c.setState(watchConnecting)
+		if connected {
+			backoff = watchMinBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, watchMaxBackoff)
+	}
+}
+
+// watchOnce invalidates keys as their changes are streamed, until the stream
+// fails. It reports whether the stream was established.
+func (c *readCache) watchOnce(ctx context.Context) (bool, error) {
+	stream, err := c.client.Watch(ctx, &frontendpb.WatchRequest{})
+	if err != nil {
+		return false, err
+	}
+
+	// The server sends headers once subscribed
+	if _, err := stream.Header(); err != nil {
+		return false, err
+	}
+	c.setState(watchConnected)
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return true, err
+		}
+		c.invalidate(ctx, resp.GetKey())
+	}
+}
@@ -5,12 +5,35 @@
 // transport security should provide their own credentials using
 // WithTransportCredentials. For explicit insecure mode, WithInsecure is still
 // available.
+//
+// Get calls are retried with exponential backoff when the service is
+// Unavailable; see WithRetryPolicy, WithPutRetries and WithHedging to tune how
+// failed or slow calls are handled, and WithCircuitBreaker to fail fast while
+// the service keeps failing.
+//
+// WithBatching coalesces concurrent calls into batch RPCs, and WithCache
+// enables a read cache kept coherent by the server's Watch stream.
+//
+// Import and Export move entries in bulk as JSON lines, CSV or delimited
+// protobuf.
+//
+// Typed stores Go values instead of strings, encoded with a JSON, protobuf,
+// gob or MessagePack codec.
+//
+// WithEndpoints, WithEndpointsFile and WithDNSSRV spread calls across several
+// replicas of the service; see WithLoadBalancing.
+//
+// Sharded spreads keys across several frontend instances as described by a
+// Topology.
 package client
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"net"
+	"slices"
+	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
@@ -24,14 +47,33 @@ import (
 type Client struct {
 	conn   *grpc.ClientConn
 	client frontendpb.FrontendServiceClient
+	cache  *readCache
+	puts   *batcher[*frontendpb.PutRequest, struct{}]
+	gets   *batcher[int64, string]
 }
 
 type Option func(*clientConfig)
 
 type clientConfig struct {
-	target string
-	dialer func(context.Context, string) (net.Conn, error)
-	creds  credentials.TransportCredentials
+	target      string
+	dialer      func(context.Context, string) (net.Conn, error)
+	creds       credentials.TransportCredentials
+	token       string
+	retry       *RetryPolicy
+	retryPuts   bool
+	callTimeout time.Duration
+	hedging     *HedgingPolicy
+	breaker     *BreakerConfig
+	cache       *CacheConfig
+	batch       *BatchConfig
+	balancing   *LoadBalancingConfig
+	unary       []grpc.UnaryClientInterceptor
+	stream      []grpc.StreamClientInterceptor
+	dialOpts    []grpc.DialOption
+
+	discover          discoverFunc
+	rediscover        bool
+	discoveryInterval time.Duration
 }
 
 // WithTarget sets the gRPC target
@@ -63,10 +105,35 @@ func WithInsecure() Option {
 	}
 }
 
+// WithBearerToken sends the token in the "authorization" metadata of every
+// call, as servers configured with an auth token require. Unless the
+// connection uses insecure credentials, the token is only sent over TLS.
+func WithBearerToken(token string) Option {
+	return func(c *clientConfig) {
+		c.token = token
+	}
+}
+
+// bearerToken implements credentials.PerRPCCredentials.
+type bearerToken struct {
+	token      string
+	requireTLS bool
+}
+
+func (t bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+func (t bearerToken) RequireTransportSecurity() bool {
+	return t.requireTLS
+}
+
 // New creates a new client with the given options
 func New(opts ...Option) (*Client, error) {
+	defaultRetry := DefaultRetryPolicy()
 	config := &clientConfig{
 		target: "localhost:5051", // default target
+		retry:  &defaultRetry,
 	}
 
 	// Apply all options
@@ -77,12 +144,33 @@ func New(opts ...Option) (*Client, error) {
 	if config.target == "" {
 		return nil, fmt.Errorf("target is required")
 	}
+	if config.batch != nil && config.batch.MaxBatch < 1 {
+		return nil, fmt.Errorf("max batch must be at least 1, got %d", config.batch.MaxBatch)
+	}
 
 	var dialOpts []grpc.DialOption
 
 	// Add OpenTelemetry stats handler
 	dialOpts = append(dialOpts, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
 
+	// Resolve discovered endpoints with a resolver of this connection only
+	if config.discover != nil {
+		interval := time.Duration(0)
+		if config.rediscover {
+			interval = cmp.Or(config.discoveryInterval, defaultDiscoveryInterval)
+		}
+		config.target = discoveryScheme + ":///endpoints"
+		dialOpts = append(dialOpts, grpc.WithResolvers(&discoveryBuilder{
+			discover: config.discover,
+			interval: interval,
+		}))
+
+		if config.balancing == nil {
+			defaultBalancing := DefaultLoadBalancingConfig()
+			config.balancing = &defaultBalancing
+		}
+	}
+
 	// Add custom dialer if provided
 	if config.dialer != nil {
 		dialOpts = append(dialOpts, grpc.WithContextDialer(config.dialer))
@@ -97,6 +185,48 @@ func New(opts ...Option) (*Client, error) {
 	}
 	dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
 
+	if config.token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearerToken{
+			token:      config.token,
+			requireTLS: creds.Info().SecurityProtocol != "insecure",
+		}))
+	}
+
+	// Configure retries and timeouts through the service config
+	serviceConfig, err := config.serviceConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid client options: %w", err)
+	}
+	if serviceConfig != "" {
+		dialOpts = append(dialOpts, grpc.WithDefaultServiceConfig(serviceConfig))
+	}
+
+	// Interceptors from options go first, then the circuit breaker so an open
+	// circuit also stops hedging
+	interceptors := slices.Clone(config.unary)
+	if config.breaker != nil {
+		breakers, err := newBreakers(*config.breaker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create circuit breaker: %w", err)
+		}
+		interceptors = append(interceptors, breakers.interceptor())
+	}
+
+	// Hedge reads on the client side, as gRPC-Go doesn't implement hedging
+	if config.hedging != nil {
+		interceptors = append(interceptors,
+			hedgingInterceptor(frontendpb.FrontendService_Get_FullMethodName, *config.hedging),
+			hedgingInterceptor(frontendpb.FrontendService_BatchGet_FullMethodName, *config.hedging),
+		)
+	}
+	if len(interceptors) > 0 {
+		dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(interceptors...))
+	}
+	if len(config.stream) > 0 {
+		dialOpts = append(dialOpts, grpc.WithChainStreamInterceptor(config.stream...))
+	}
+	dialOpts = append(dialOpts, config.dialOpts...)
+
 	// Create gRPC connection
 	conn, err := grpc.NewClient(config.target, dialOpts...)
 	if err != nil {
@@ -105,14 +235,36 @@ func New(opts ...Option) (*Client, error) {
 
 	client := frontendpb.NewFrontendServiceClient(conn)
 
-	return &Client{
+	var cache *readCache
+	if config.cache != nil {
+		if cache, err = newReadCache(*config.cache, client); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to create cache: %w", err)
+		}
+	}
+
+	c := &Client{
 		conn:   conn,
 		client: client,
-	}, nil
+		cache:  cache,
+	}
+	if config.batch != nil {
+		c.puts = newPutBatcher(*config.batch, client)
+		c.gets = newGetBatcher(*config.batch, client)
+	}
+
+	return c, nil
 }
 
-// Close closes the underlying gRPC connection
+// Close closes the underlying gRPC connection, after sending any batched calls
 func (c *Client) Close() error {
+	if c.cache != nil {
+		c.cache.close()
+	}
+	if c.puts != nil {
+		c.puts.close()
+		c.gets.close()
+	}
 	return c.conn.Close()
 }
 
@@ -123,12 +275,78 @@ func (c *Client) Put(ctx context.Context, key int64, value string) error {
 		Value: value,
 	}.Build()
 
-	_, err := c.client.Put(ctx, req)
+	var err error
+	if c.puts != nil {
+		_, err = c.puts.do(ctx, req)
+	} else {
+		_, err = c.client.Put(ctx, req)
+	}
+
+	// Even a failed Put may have been applied
+	if c.cache != nil {
+		c.cache.invalidate(ctx, key)
+	}
+	return err
+}
+
+// BatchPut atomically stores several entries in one call, applying them in
+// order so the last entry for a key wins
+func (c *Client) BatchPut(ctx context.Context, entries []KeyValue) error {
+	reqs := make([]*frontendpb.PutRequest, len(entries))
+	for i, entry := range entries {
+		reqs[i] = frontendpb.PutRequest_builder{
+			Key:   entry.Key,
+			Value: entry.Value,
+		}.Build()
+	}
+
+	_, err := c.client.BatchPut(ctx, frontendpb.BatchPutRequest_builder{Entries: reqs}.Build())
+
+	if c.cache != nil {
+		for _, entry := range entries {
+			c.cache.invalidate(ctx, entry.Key)
+		}
+	}
 	return err
 }
 
-// Get retrieves a value by key
-func (c *Client) Get(ctx context.Context, key int64) (string, error) {
+// Delete removes a key, reporting whether it existed
+func (c *Client) Delete(ctx context.Context, key int64) (bool, error) {
+	req := frontendpb.DeleteRequest_builder{
+		Key: key,
+	}.Build()
+
+	resp, err := c.client.Delete(ctx, req)
+
+	// Even a failed Delete may have been applied
+	if c.cache != nil {
+		c.cache.invalidate(ctx, key)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return resp.GetFound(), nil
+}
+
+// Get retrieves a value by key. Reads of past values bypass the cache and
+// batching.
+func (c *Client) Get(ctx context.Context, key int64, opts ...GetOption) (string, error) {
+	if len(opts) > 0 {
+		return c.getVersion(ctx, key, opts)
+	}
+	if c.cache != nil {
+		return c.cache.get(ctx, key, c.get)
+	}
+	return c.get(ctx, key)
+}
+
+// get reads a value, bypassing the cache
+func (c *Client) get(ctx context.Context, key int64) (string, error) {
+	if c.gets != nil {
+		return c.gets.do(ctx, key)
+	}
+
 	req := frontendpb.GetRequest_builder{
 		Key: key,
 	}.Build()
@@ -0,0 +1,484 @@
+// Package clienttest runs an in-process frontend server for tests of code
+// that uses the client package.
+//
+// The server stores data in memory like the real one, but lets tests inject
+// backend errors and latency and inspect the backend operations that calls
+// caused:
+//
+//	c, srv := clienttest.NewTestServer(t)
+//	srv.SetError(clienttest.OpGet, errors.New("disk on fire"))
+//	_, err := c.Get(ctx, 1) // fails with codes.Internal
+package clienttest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/frontend"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+var (
+	// ErrNotFound, injected with SetError, makes reads fail with NotFound.
+	ErrNotFound = sql.ErrNoRows
+
+	// Namespace methods fail with these errors for namespaces that don't
+	// exist, already exist, or aren't empty.
+	ErrNamespaceNotFound = sqlbackend.ErrNamespaceNotFound
+	ErrNamespaceExists   = sqlbackend.ErrNamespaceExists
+	ErrNamespaceNotEmpty = sqlbackend.ErrNamespaceNotEmpty
+)
+
+// Op identifies a backend operation.
+type Op string
+
+const (
+	OpPut      Op = "Put"
+	OpGet      Op = "Get"
+	OpDelete   Op = "Delete"
+	OpScan     Op = "Scan"
+	OpBatchPut Op = "BatchPut"
+	OpHistory  Op = "History"
+)
+
+// Call is a recorded backend operation. A BatchPut is recorded as one call per
+// entry, all sharing the batch's error, and a Scan as a call for its first key
+// with its page size as the value. Reads of several keys at once, as by
+// BatchGet, are recorded like a BatchPut, and History like a Scan. Reads at a
+// revision count as Get and Scan.
+type Call struct {
+	Op        Op
+	Namespace string // empty for the default namespace
+	Key       int64
+	Value     string // empty for reads
+	Err       error
+}
+
+// Quota limits a namespace. Zero fields are unlimited.
+type Quota struct {
+	MaxKeys  int64
+	MaxBytes int64
+}
+
+// Namespace describes a namespace of the server and its current usage.
+type Namespace struct {
+	Name      string
+	Quota     Quota
+	Keys      int64
+	Bytes     int64
+	CreatedAt time.Time
+}
+
+// Option configures the test server.
+type Option func(*config)
+
+type config struct {
+	clientOpts []client.Option
+	logger     *slog.Logger
+}
+
+// WithClientOptions sets options for the returned client, applied after the
+// ones connecting it to the server.
+func WithClientOptions(opts ...client.Option) Option {
+	return func(c *config) {
+		c.clientOpts = append(c.clientOpts, opts...)
+	}
+}
+
+// WithLogger sets the logger of the server. Call logs are discarded by
+// default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// Server is an in-process frontend server. It serves namespaces, reads at a
+// revision and History like the real one; namespaces are managed through its
+// namespace methods.
+type Server struct {
+	tb       testing.TB
+	lis      *bufconn.Listener
+	backend  sqlbackend.Backend
+	defaults []client.Option
+
+	mu      sync.Mutex
+	errs    map[Op]error
+	failN   map[Op]int
+	latency map[Op]time.Duration
+	calls   []Call
+}
+
+// NewTestServer starts a server and returns a client connected to it. Both
+// are shut down when the test ends.
+func NewTestServer(tb testing.TB, opts ...Option) (*client.Client, *Server) {
+	tb.Helper()
+
+	cfg := &config{logger: slog.New(slog.DiscardHandler)}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	backend, err := sqlbackend.New(tb.Context())
+	if err != nil {
+		tb.Fatalf("failed to create backend: %v", err)
+	}
+
+	s := &Server{
+		tb:      tb,
+		lis:     bufconn.Listen(1024 * 1024),
+		backend: backend,
+		errs:    make(map[Op]error),
+		failN:   make(map[Op]int),
+		latency: make(map[Op]time.Duration),
+	}
+	s.defaults = []client.Option{
+		client.WithTarget("passthrough:///bufnet"),
+		client.WithDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.lis.DialContext(ctx)
+		}),
+	}
+
+	srv, cleanup, err := frontend.NewServer(tb.Context(), &faultyBackend{Server: s, store: backend},
+		frontend.WithNoopTelemetry(),
+		frontend.WithLogger(cfg.logger),
+	)
+	if err != nil {
+		tb.Fatalf("failed to create server: %v", err)
+	}
+	go func() {
+		_ = srv.Serve(s.lis)
+	}()
+	tb.Cleanup(func() {
+		srv.Stop()
+		cleanup()
+		if err := backend.Close(context.Background()); err != nil {
+			tb.Errorf("failed to close backend: %v", err)
+		}
+	})
+
+	return s.NewClient(cfg.clientOpts...), s
+}
+
+// NewClient returns another client connected to the server, closed when the
+// test ends.
+func (s *Server) NewClient(opts ...client.Option) *client.Client {
+	s.tb.Helper()
+
+	c, err := client.New(append(s.defaults[:len(s.defaults):len(s.defaults)], opts...)...)
+	if err != nil {
+		s.tb.Fatalf("failed to create client: %v", err)
+	}
+	s.tb.Cleanup(func() { c.Close() })
+	return c
+}
+
+// CreateNamespace creates a namespace, which clients can use once created.
+func (s *Server) CreateNamespace(ctx context.Context, name string, quota Quota) (Namespace, error) {
+	ns, err := s.namespaces().CreateNamespace(ctx, name, sqlbackend.Quota(quota))
+	return namespaceOf(ns), err
+}
+
+// GetNamespace returns a namespace and its usage.
+func (s *Server) GetNamespace(ctx context.Context, name string) (Namespace, error) {
+	ns, err := s.namespaces().GetNamespace(ctx, name)
+	return namespaceOf(ns), err
+}
+
+// SetQuota replaces the quota of a namespace.
+func (s *Server) SetQuota(ctx context.Context, name string, quota Quota) (Namespace, error) {
+	ns, err := s.namespaces().SetQuota(ctx, name, sqlbackend.Quota(quota))
+	return namespaceOf(ns), err
+}
+
+// DeleteNamespace deletes an empty namespace.
+func (s *Server) DeleteNamespace(ctx context.Context, name string) error {
+	return s.namespaces().DeleteNamespace(ctx, name)
+}
+
+func (s *Server) namespaces() sqlbackend.Namespaces {
+	return s.backend.(sqlbackend.Namespaces)
+}
+
+func namespaceOf(ns sqlbackend.Namespace) Namespace {
+	return Namespace{Name: ns.Name, Quota: Quota(ns.Quota), Keys: ns.Keys, Bytes: ns.Bytes, CreatedAt: ns.CreatedAt}
+}
+
+// SetError makes every op fail with err until cleared with a nil error.
+// Errors other than ErrNotFound reach clients as codes.Internal.
+func (s *Server) SetError(op Op, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errs[op] = err
+	s.failN[op] = -1
+}
+
+// FailNext makes the next n ops fail with err.
+func (s *Server) FailNext(op Op, n int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errs[op] = err
+	s.failN[op] = n
+}
+
+// SetLatency delays every op by d.
+func (s *Server) SetLatency(op Op, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency[op] = d
+}
+
+// Calls returns the backend operations performed so far, in order.
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// Reset clears recorded calls and injected errors and latency.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.errs)
+	clear(s.failN)
+	clear(s.latency)
+	s.calls = nil
+}
+
+// fault returns the latency and error to inject into an op.
+func (s *Server) fault(op Op) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	switch n := s.failN[op]; {
+	case n < 0:
+		err = s.errs[op]
+	case n > 0:
+		err = s.errs[op]
+		s.failN[op] = n - 1
+	}
+	return s.latency[op], err
+}
+
+func (s *Server) record(calls ...Call) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, calls...)
+}
+
+// faultyBackend injects the server's faults in front of the real backend of a
+// namespace. It implements the optional interfaces of the real backend too, so
+// the server offers the same features.
+type faultyBackend struct {
+	*Server
+	store     sqlbackend.Backend
+	namespace string
+}
+
+func (b *faultyBackend) inject(ctx context.Context, op Op) error {
+	latency, err := b.fault(op)
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+func (b *faultyBackend) Put(ctx context.Context, key int64, value string) error {
+	err := b.inject(ctx, OpPut)
+	if err == nil {
+		err = b.store.Put(ctx, key, value)
+	}
+	b.record(Call{Op: OpPut, Namespace: b.namespace, Key: key, Value: value, Err: err})
+	return err
+}
+
+func (b *faultyBackend) Get(ctx context.Context, key int64) (string, error) {
+	err := b.inject(ctx, OpGet)
+	var value string
+	if err == nil {
+		value, err = b.store.Get(ctx, key)
+	}
+	b.record(Call{Op: OpGet, Namespace: b.namespace, Key: key, Err: err})
+	return value, err
+}
+
+func (b *faultyBackend) Delete(ctx context.Context, key int64) (bool, error) {
+	err := b.inject(ctx, OpDelete)
+	var found bool
+	if err == nil {
+		found, err = b.store.Delete(ctx, key)
+	}
+	b.record(Call{Op: OpDelete, Namespace: b.namespace, Key: key, Err: err})
+	return found, err
+}
+
+func (b *faultyBackend) Scan(ctx context.Context, first, last int64, limit int) ([]sqlbackend.KeyValue, error) {
+	err := b.inject(ctx, OpScan)
+	var entries []sqlbackend.KeyValue
+	if err == nil {
+		entries, err = b.store.Scan(ctx, first, last, limit)
+	}
+	b.record(Call{Op: OpScan, Namespace: b.namespace, Key: first, Value: strconv.Itoa(limit), Err: err})
+	return entries, err
+}
+
+func (b *faultyBackend) BatchPut(ctx context.Context, entries []sqlbackend.KeyValue) error {
+	err := b.inject(ctx, OpBatchPut)
+	if err == nil {
+		err = b.store.BatchPut(ctx, entries)
+	}
+
+	calls := make([]Call, 0, len(entries))
+	for _, entry := range entries {
+		calls = append(calls, Call{Op: OpBatchPut, Namespace: b.namespace, Key: entry.Key, Value: entry.Value, Err: err})
+	}
+	b.record(calls...)
+	return err
+}
+
+func (b *faultyBackend) Close(context.Context) error {
+	// The real backend is closed by the test cleanup, after the server stops
+	return nil
+}
+
+// Namespaces
+
+func (b *faultyBackend) Namespace(name string) sqlbackend.Backend {
+	return &faultyBackend{Server: b.Server, store: b.namespaces().Namespace(name), namespace: name}
+}
+
+func (b *faultyBackend) CreateNamespace(ctx context.Context, name string, quota sqlbackend.Quota) (sqlbackend.Namespace, error) {
+	return b.namespaces().CreateNamespace(ctx, name, quota)
+}
+
+func (b *faultyBackend) GetNamespace(ctx context.Context, name string) (sqlbackend.Namespace, error) {
+	return b.namespaces().GetNamespace(ctx, name)
+}
+
+func (b *faultyBackend) ListNamespaces(ctx context.Context) ([]sqlbackend.Namespace, error) {
+	return b.namespaces().ListNamespaces(ctx)
+}
+
+func (b *faultyBackend) SetQuota(ctx context.Context, name string, quota sqlbackend.Quota) (sqlbackend.Namespace, error) {
+	return b.namespaces().SetQuota(ctx, name, quota)
+}
+
+func (b *faultyBackend) DeleteNamespace(ctx context.Context, name string) error {
+	return b.namespaces().DeleteNamespace(ctx, name)
+}
+
+// Versioned
+
+func (b *faultyBackend) GetVersion(ctx context.Context, key, revision int64, at time.Time) (sqlbackend.Version, error) {
+	err := b.inject(ctx, OpGet)
+	var version sqlbackend.Version
+	if err == nil {
+		version, err = b.store.(sqlbackend.Versioned).GetVersion(ctx, key, revision, at)
+	}
+	b.record(Call{Op: OpGet, Namespace: b.namespace, Key: key, Err: err})
+	return version, err
+}
+
+func (b *faultyBackend) History(ctx context.Context, key, before int64, limit int) ([]sqlbackend.Version, bool, error) {
+	err := b.inject(ctx, OpHistory)
+	var versions []sqlbackend.Version
+	var compacted bool
+	if err == nil {
+		versions, compacted, err = b.store.(sqlbackend.Versioned).History(ctx, key, before, limit)
+	}
+	b.record(Call{Op: OpHistory, Namespace: b.namespace, Key: key, Value: strconv.Itoa(limit), Err: err})
+	return versions, compacted, err
+}
+
+func (b *faultyBackend) CompactHistory(ctx context.Context, retention sqlbackend.HistoryRetention) (int64, error) {
+	return b.store.(sqlbackend.Versioned).CompactHistory(ctx, retention)
+}
+
+// Snapshots
+
+func (b *faultyBackend) SnapshotGet(ctx context.Context, keys []int64, revision int64) (map[int64]string, int64, error) {
+	err := b.inject(ctx, OpGet)
+	values := map[int64]string{}
+	switch {
+	case errors.Is(err, ErrNotFound):
+		// None of the keys are found
+		_, revision, err = b.store.(sqlbackend.Snapshots).SnapshotGet(ctx, nil, revision)
+	case err == nil:
+		values, revision, err = b.store.(sqlbackend.Snapshots).SnapshotGet(ctx, keys, revision)
+	}
+
+	calls := make([]Call, 0, len(keys))
+	for _, key := range keys {
+		calls = append(calls, Call{Op: OpGet, Namespace: b.namespace, Key: key, Err: err})
+	}
+	b.record(calls...)
+	return values, revision, err
+}
+
+func (b *faultyBackend) SnapshotScan(ctx context.Context, first, last int64, limit int, revision int64) ([]sqlbackend.KeyValue, int64, error) {
+	err := b.inject(ctx, OpScan)
+	var entries []sqlbackend.KeyValue
+	if err == nil {
+		entries, revision, err = b.store.(sqlbackend.Snapshots).SnapshotScan(ctx, first, last, limit, revision)
+	}
+	b.record(Call{Op: OpScan, Namespace: b.namespace, Key: first, Value: strconv.Itoa(limit), Err: err})
+	return entries, revision, err
+}
+
+// ChangeLog and Backupper serve replication and backups without faults.
+
+func (b *faultyBackend) Changes(ctx context.Context, after int64, limit int) ([]sqlbackend.Change, error) {
+	return b.store.(sqlbackend.ChangeLog).Changes(ctx, after, limit)
+}
+
+func (b *faultyBackend) LastSeq(ctx context.Context) (int64, error) {
+	return b.store.(sqlbackend.ChangeLog).LastSeq(ctx)
+}
+
+func (b *faultyBackend) Apply(ctx context.Context, changes []sqlbackend.Change) error {
+	return b.store.(sqlbackend.ChangeLog).Apply(ctx, changes)
+}
+
+func (b *faultyBackend) TrimChanges(ctx context.Context, retention sqlbackend.ChangeLogRetention) (int64, error) {
+	return b.store.(sqlbackend.ChangeLog).TrimChanges(ctx, retention)
+}
+
+func (b *faultyBackend) Retain(lowWater func() int64) func() {
+	return b.store.(sqlbackend.ChangeLog).Retain(lowWater)
+}
+
+func (b *faultyBackend) Backup(ctx context.Context, path string) (sqlbackend.BackupInfo, error) {
+	return b.store.(sqlbackend.Backupper).Backup(ctx, path)
+}
+
+func (b *faultyBackend) Restore(ctx context.Context, path string) (sqlbackend.BackupInfo, error) {
+	return b.store.(sqlbackend.Backupper).Restore(ctx, path)
+}
+
+func (b *faultyBackend) OnRestore(fn func()) {
+	b.store.(sqlbackend.Backupper).OnRestore(fn)
+}
+
+// The server type-asserts these, so missing one silently disables a feature
+var (
+	_ sqlbackend.Namespaces = (*faultyBackend)(nil)
+	_ sqlbackend.Versioned  = (*faultyBackend)(nil)
+	_ sqlbackend.Snapshots  = (*faultyBackend)(nil)
+	_ sqlbackend.ChangeLog  = (*faultyBackend)(nil)
+	_ sqlbackend.Backupper  = (*faultyBackend)(nil)
+)
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+)
+
+// discoveryScheme is the resolver scheme of clients using endpoint discovery.
+const discoveryScheme = "gh-go"
+
+// defaultDiscoveryInterval is how often discovered endpoints are refreshed
+// unless set with WithDiscoveryInterval.
+const defaultDiscoveryInterval = 30 * time.Second
+
+// minResolveInterval limits how often gRPC may ask for endpoints to be
+// refreshed early, e.g. after losing a connection.
+const minResolveInterval = time.Second
+
+// discoverFunc returns the current endpoint addresses.
+type discoverFunc func(ctx context.Context) ([]string, error)
+
+// WithEndpoints spreads calls across the given "host:port" addresses, in place
+// of WithTarget, using the default load balancing configuration unless
+// WithLoadBalancing is given.
+func WithEndpoints(addrs ...string) Option {
+	addrs = slices.Clone(addrs)
+	return func(c *clientConfig) {
+		c.discover = func(context.Context) ([]string, error) { return addrs, nil }
+		c.rediscover = false
+	}
+}
+
+// WithEndpointsFile is like WithEndpoints, but reads the addresses from a
+// file, one per line, ignoring blank lines and "#" comments. The file is read
+// again periodically, so endpoints can be added or removed while running.
+func WithEndpointsFile(path string) Option {
+	return func(c *clientConfig) {
+		c.discover = func(context.Context) ([]string, error) { return readEndpointsFile(path) }
+		c.rediscover = true
+	}
+}
+
+// WithDNSSRV is like WithEndpoints, but looks up the addresses in the DNS SRV
+// records of name, such as "_grpc._tcp.frontend.example.com", using r or
+// net.DefaultResolver if nil. Only the records of the lowest priority are
+// used and their weights are ignored. The records are looked up again
+// periodically.
+func WithDNSSRV(name string, r *net.Resolver) Option {
+	if r == nil {
+		r = net.DefaultResolver
+	}
+	return func(c *clientConfig) {
+		c.discover = func(ctx context.Context) ([]string, error) { return lookupSRV(ctx, r, name) }
+		c.rediscover = true
+	}
+}
+
+// WithDiscoveryInterval sets how often WithEndpointsFile and WithDNSSRV
+// refresh the endpoints, 30s by default.
+func WithDiscoveryInterval(interval time.Duration) Option {
+	return func(c *clientConfig) {
+		c.discoveryInterval = interval
+	}
+}
+
+func readEndpointsFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read endpoints file: %w", err)
+	}
+
+	var addrs []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			addrs = append(addrs, line)
+		}
+	}
+	return addrs, scanner.Err()
+}
+
+func lookupSRV(ctx context.Context, r *net.Resolver, name string) ([]string, error) {
+	// LookupSRV sorts records by priority
+	_, records, err := r.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up SRV records: %w", err)
+	}
+
+	var addrs []string
+	for _, record := range records {
+		if record.Priority != records[0].Priority {
+			break
+		}
+		host := strings.TrimSuffix(record.Target, ".")
+		addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(int(record.Port))))
+	}
+	return addrs, nil
+}
+
+// discoveryBuilder builds resolvers reporting the endpoints found by discover.
+type discoveryBuilder struct {
+	discover discoverFunc
+	interval time.Duration
+}
+
+func (b *discoveryBuilder) Scheme() string {
+	return discoveryScheme
+}
+
+func (b *discoveryBuilder) Build(_ resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &discoveryResolver{
+		builder: b,
+		cc:      cc,
+		cancel:  cancel,
+		refresh: make(chan struct{}, 1),
+	}
+	r.wg.Go(func() { r.run(ctx) })
+	return r, nil
+}
+
+// discoveryResolver refreshes endpoints periodically and when gRPC asks.
+type discoveryResolver struct {
+	builder *discoveryBuilder
+	cc      resolver.ClientConn
+	cancel  context.CancelFunc
+	refresh chan struct{}
+	wg      sync.WaitGroup
+}
+
+func (r *discoveryResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.refresh <- struct{}{}:
+	default:
+	}
+}
+
+func (r *discoveryResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *discoveryResolver) run(ctx context.Context) {
+	var tick <-chan time.Time
+	if r.builder.interval > 0 {
+		ticker := time.NewTicker(r.builder.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var last []string
+	for {
+		addrs, err := r.builder.discover(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			r.cc.ReportError(err)
+			last = nil
+		default:
+			addrs = slices.Compact(slices.Sorted(slices.Values(addrs)))
+			if !slices.Equal(addrs, last) {
+				state := resolver.State{}
+				for _, addr := range addrs {
+					state.Addresses = append(state.Addresses, resolver.Address{Addr: addr})
+				}
+				// Rejected states, such as one without addresses, are
+				// reported again on the next refresh
+				last = addrs
+				if r.cc.UpdateState(state) != nil {
+					last = nil
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		case <-r.refresh:
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(minResolveInterval):
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+// GetOption selects a past value for Get.
+type GetOption func(*frontendpb.GetRequest)
+
+// GetAtRevision reads the value the key had after the change with the given
+// revision was committed.
+func GetAtRevision(revision int64) GetOption {
+	return func(req *frontendpb.GetRequest) {
+		req.SetRevision(revision)
+	}
+}
+
+// GetAtTime reads the value the key had at t.
+func GetAtTime(t time.Time) GetOption {
+	return func(req *frontendpb.GetRequest) {
+		req.SetReadTime(t.UnixNano())
+	}
+}
+
+func (c *Client) getVersion(ctx context.Context, key int64, opts []GetOption) (string, error) {
+	req := frontendpb.GetRequest_builder{Key: key}.Build()
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := c.client.Get(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.GetValue(), nil
+}
+
+// Version is a value a key had.
+type Version struct {
+	// Revision numbers the change that wrote the version.
+	Revision    int64
+	Value       string
+	Deleted     bool
+	CommittedAt time.Time
+}
+
+// HistoryOption narrows a History call.
+type HistoryOption func(*frontendpb.HistoryRequest)
+
+// HistoryBefore lists versions older than revision, to page through the
+// history.
+func HistoryBefore(revision int64) HistoryOption {
+	return func(req *frontendpb.HistoryRequest) {
+		req.SetBeforeRevision(revision)
+	}
+}
+
+// HistoryLimit returns at most n versions.
+func HistoryLimit(n int) HistoryOption {
+	return func(req *frontendpb.HistoryRequest) {
+		req.SetLimit(int64(n))
+	}
+}
+
+// History lists the versions of a key the server keeps, newest first, and
+// reports whether older versions were compacted.
+func (c *Client) History(ctx context.Context, key int64, opts ...HistoryOption) ([]Version, bool, error) {
+	req := frontendpb.HistoryRequest_builder{Key: key}.Build()
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := c.client.History(ctx, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	versions := make([]Version, len(resp.GetVersions()))
+	for i, v := range resp.GetVersions() {
+		versions[i] = Version{
+			Revision:    v.GetRevision(),
+			Value:       v.GetValue(),
+			Deleted:     v.GetDeleted(),
+			CommittedAt: time.Unix(0, v.GetCommittedAt()),
+		}
+	}
+	return versions, resp.GetCompacted(), nil
+}
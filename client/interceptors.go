@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// WithUnaryInterceptors adds interceptors to unary calls. Interceptors added
+// by this and the other interceptor options run in the order the options are
+// given, before the client's own retries, hedging and circuit breaking, so
+// they see each call once.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(c *clientConfig) {
+		c.unary = append(c.unary, interceptors...)
+	}
+}
+
+// WithStreamInterceptors adds interceptors to streaming calls, such as Scan
+// and Watch, in the same order as WithUnaryInterceptors.
+func WithStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) Option {
+	return func(c *clientConfig) {
+		c.stream = append(c.stream, interceptors...)
+	}
+}
+
+// WithDialOptions passes additional options to grpc.NewClient. They are
+// applied after the client's own, so they take precedence where gRPC allows
+// only one setting.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(c *clientConfig) {
+		c.dialOpts = append(c.dialOpts, opts...)
+	}
+}
+
+// WithMetadata adds the key-value pairs to the metadata of every call, such
+// as a tenant ID header.
+func WithMetadata(kv ...string) Option {
+	md := metadata.Pairs(kv...)
+	return WithMetadataFunc(func(context.Context) metadata.MD { return md })
+}
+
+// WithMetadataFunc adds the metadata returned by fn, which may depend on the
+// call's context, to every call.
+func WithMetadataFunc(fn func(context.Context) metadata.MD) Option {
+	withMetadata := func(ctx context.Context) context.Context {
+		for key, values := range fn(ctx) {
+			for _, value := range values {
+				ctx = metadata.AppendToOutgoingContext(ctx, key, value)
+			}
+		}
+		return ctx
+	}
+
+	return func(c *clientConfig) {
+		c.unary = append(c.unary, func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(withMetadata(ctx), method, req, reply, cc, opts...)
+		})
+		c.stream = append(c.stream, func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(withMetadata(ctx), desc, cc, method, opts...)
+		})
+	}
+}
+
+// NamespaceHeader is the metadata naming the namespace whose keys a call reads
+// and writes.
+const NamespaceHeader = "namespace"
+
+// WithNamespace makes every call read and write the keys of a namespace,
+// which must have been created on the server. Keys of different namespaces
+// are independent; without this option, calls use the default namespace.
+func WithNamespace(namespace string) Option {
+	return WithMetadata(NamespaceHeader, namespace)
+}
+
+// WithCallLogging logs every finished call with its method, status code and
+// duration. Successful calls are logged at debug level and failures at a
+// level depending on their code.
+func WithCallLogging(logger *slog.Logger) Option {
+	l := logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
+		logger.Log(ctx, slog.Level(lvl), msg, fields...)
+	})
+	events := logging.WithLogOnEvents(logging.FinishCall)
+
+	return func(c *clientConfig) {
+		c.unary = append(c.unary, logging.UnaryClientInterceptor(l, events))
+		c.stream = append(c.stream, logging.StreamClientInterceptor(l, events))
+	}
+}
+
+// WithDefaultDeadline sets a deadline of timeout on unary calls made without
+// one. Unlike WithCallTimeout, calls with a later deadline keep it. Streaming
+// calls are left alone, as Watch streams are meant to run indefinitely.
+func WithDefaultDeadline(timeout time.Duration) Option {
+	return WithUnaryInterceptors(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+}
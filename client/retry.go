@@ -0,0 +1,260 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+// RetryPolicy configures automatic retries of failed calls with exponential
+// backoff. Retries are performed by gRPC itself, driven by the service config.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// gRPC caps it at 5.
+	MaxAttempts int
+	// InitialBackoff and MaxBackoff bound the randomized delay between
+	// attempts, which grows by BackoffMultiplier after every attempt.
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	BackoffMultiplier float64
+	// RetryableCodes lists the status codes that trigger a retry.
+	RetryableCodes []codes.Code
+}
+
+// DefaultRetryPolicy returns the policy applied to Get unless configured
+// otherwise: up to 4 attempts on Unavailable, backing off from 100ms to 2s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:       4,
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        2 * time.Second,
+		BackoffMultiplier: 2,
+		RetryableCodes:    []codes.Code{codes.Unavailable},
+	}
+}
+
+// HedgingPolicy configures hedged reads: if an attempt has not completed after
+// Delay, another one is sent without cancelling the first, up to MaxAttempts
+// in total. The first successful response wins and the other attempts are
+// cancelled. An attempt failing with one of NonFatalCodes immediately starts
+// the next one; any other failure ends the call.
+type HedgingPolicy struct {
+	MaxAttempts   int
+	Delay         time.Duration
+	NonFatalCodes []codes.Code
+}
+
+// WithRetryPolicy sets the retry policy for Get, and for Put if enabled with
+// WithPutRetries. Batched calls follow the policy of the call they batch.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *clientConfig) {
+		c.retry = &policy
+	}
+}
+
+// WithoutRetries disables the default retries of Get.
+func WithoutRetries() Option {
+	return func(c *clientConfig) {
+		c.retry = nil
+	}
+}
+
+// WithPutRetries also applies the retry policy to Put and Delete. Both
+// replace the whole value, so retrying them is safe as long as concurrent
+// writers of the same key are not relying on ordering.
+func WithPutRetries() Option {
+	return func(c *clientConfig) {
+		c.retryPuts = true
+	}
+}
+
+// WithCallTimeout sets a deadline for every call that doesn't already have an
+// earlier one, covering all retry attempts.
+func WithCallTimeout(timeout time.Duration) Option {
+	return func(c *clientConfig) {
+		c.callTimeout = timeout
+	}
+}
+
+// WithHedging hedges Get calls according to the policy. Hedged reads replace
+// the retry policy for Get.
+func WithHedging(policy HedgingPolicy) Option {
+	return func(c *clientConfig) {
+		c.hedging = &policy
+	}
+}
+
+// methodName identifies methods in the service config.
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method,omitempty"`
+}
+
+type retryPolicyConfig struct {
+	MaxAttempts          int          `json:"maxAttempts"`
+	InitialBackoff       string       `json:"initialBackoff"`
+	MaxBackoff           string       `json:"maxBackoff"`
+	BackoffMultiplier    float64      `json:"backoffMultiplier"`
+	RetryableStatusCodes []codes.Code `json:"retryableStatusCodes"`
+}
+
+type methodConfig struct {
+	Name        []methodName       `json:"name"`
+	Timeout     string             `json:"timeout,omitempty"`
+	RetryPolicy *retryPolicyConfig `json:"retryPolicy,omitempty"`
+}
+
+type healthCheckConfig struct {
+	ServiceName string `json:"serviceName"`
+}
+
+type serviceConfig struct {
+	LoadBalancingConfig []map[string]*balancerConfig `json:"loadBalancingConfig,omitempty"`
+	HealthCheckConfig   *healthCheckConfig           `json:"healthCheckConfig,omitempty"`
+	MethodConfig        []methodConfig               `json:"methodConfig,omitempty"`
+}
+
+// serviceConfig renders the retry, timeout and load balancing options as a
+// gRPC service config, or returns "" if there is nothing to configure.
+func (c *clientConfig) serviceConfig() (string, error) {
+	var retry *retryPolicyConfig
+	if c.retry != nil {
+		if c.retry.MaxAttempts < 2 {
+			return "", fmt.Errorf("retry policy needs at least 2 attempts, got %d", c.retry.MaxAttempts)
+		}
+		if len(c.retry.RetryableCodes) == 0 {
+			return "", fmt.Errorf("retry policy needs at least one retryable code")
+		}
+
+		retry = &retryPolicyConfig{
+			MaxAttempts:          c.retry.MaxAttempts,
+			InitialBackoff:       durationJSON(c.retry.InitialBackoff),
+			MaxBackoff:           durationJSON(c.retry.MaxBackoff),
+			BackoffMultiplier:    c.retry.BackoffMultiplier,
+			RetryableStatusCodes: c.retry.RetryableCodes,
+		}
+	}
+
+	var timeout string
+	if c.callTimeout > 0 {
+		timeout = durationJSON(c.callTimeout)
+	}
+
+	service := frontendpb.FrontendService_ServiceDesc.ServiceName
+	get := methodConfig{
+		Name:    []methodName{{Service: service, Method: "Get"}, {Service: service, Method: "BatchGet"}},
+		Timeout: timeout,
+	}
+	put := methodConfig{
+		Name: []methodName{
+			{Service: service, Method: "Put"},
+			{Service: service, Method: "BatchPut"},
+			{Service: service, Method: "Delete"},
+		},
+		Timeout: timeout,
+	}
+	if c.hedging == nil {
+		get.RetryPolicy = retry
+	}
+	if c.retryPuts {
+		put.RetryPolicy = retry
+	}
+
+	var sc serviceConfig
+	for _, mc := range []methodConfig{get, put} {
+		if mc.Timeout != "" || mc.RetryPolicy != nil {
+			sc.MethodConfig = append(sc.MethodConfig, mc)
+		}
+	}
+	if c.balancing != nil {
+		lb, err := newBalancerConfig(*c.balancing)
+		if err != nil {
+			return "", err
+		}
+		sc.LoadBalancingConfig = []map[string]*balancerConfig{{balancerName: lb}}
+		if c.balancing.HealthCheck {
+			sc.HealthCheckConfig = &healthCheckConfig{ServiceName: service}
+		}
+	}
+	if len(sc.MethodConfig) == 0 && sc.LoadBalancingConfig == nil {
+		return "", nil
+	}
+
+	data, err := json.Marshal(sc)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// durationJSON formats d the way the service config expects ("1.5s").
+func durationJSON(d time.Duration) string {
+	return fmt.Sprintf("%gs", d.Seconds())
+}
+
+// hedgingInterceptor hedges calls to the given method.
+func hedgingInterceptor(method string, policy HedgingPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, m string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if m != method || policy.MaxAttempts < 2 {
+			return invoker(ctx, m, req, reply, cc, opts...)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type result struct {
+			reply proto.Message
+			err   error
+		}
+		results := make(chan result, policy.MaxAttempts)
+		attempt := func() {
+			r := reply.(proto.Message).ProtoReflect().New().Interface()
+			err := invoker(ctx, m, req, r, cc, opts...)
+			results <- result{reply: r, err: err}
+		}
+
+		started, finished := 1, 0
+		go attempt()
+
+		timer := time.NewTimer(policy.Delay)
+		defer timer.Stop()
+
+		var lastErr error
+		for {
+			select {
+			case <-timer.C:
+				if started < policy.MaxAttempts {
+					started++
+					go attempt()
+					timer.Reset(policy.Delay)
+				}
+			case res := <-results:
+				finished++
+				if res.err == nil {
+					proto.Merge(reply.(proto.Message), res.reply)
+					return nil
+				}
+				lastErr = res.err
+				if !slices.Contains(policy.NonFatalCodes, status.Code(res.err)) {
+					return res.err
+				}
+				if started < policy.MaxAttempts {
+					started++
+					go attempt()
+					timer.Reset(policy.Delay)
+				} else if finished == started {
+					return lastErr
+				}
+			}
+		}
+	}
+}
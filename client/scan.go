@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"math"
+
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+// KeyValue is a stored entry.
+type KeyValue struct {
+	Key   int64
+	Value string
+}
+
+// ScanOption narrows a scan.
+type ScanOption func(*frontendpb.ScanRequest)
+
+// ScanFrom starts the scan at key, inclusive. Scans start at the smallest
+// key by default.
+func ScanFrom(key int64) ScanOption {
+	return func(req *frontendpb.ScanRequest) {
+		req.SetStartKey(key)
+	}
+}
+
+// ScanTo stops the scan before key. Scans run to the largest key by default.
+func ScanTo(key int64) ScanOption {
+	return func(req *frontendpb.ScanRequest) {
+		req.SetEndKey(key)
+	}
+}
+
+// ScanLimit stops the scan after n entries.
+func ScanLimit(n int) ScanOption {
+	return func(req *frontendpb.ScanRequest) {
+		req.SetLimit(int64(n))
+	}
+}
+
+func newScanRequest(opts []ScanOption) *frontendpb.ScanRequest {
+	req := frontendpb.ScanRequest_builder{StartKey: math.MinInt64}.Build()
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req
+}
+
+// Scan iterates over entries in key order. Iteration stops at the first
+// error, which is yielded with a zero KeyValue.
+func (c *Client) Scan(ctx context.Context, opts ...ScanOption) iter.Seq2[KeyValue, error] {
+	req := newScanRequest(opts)
+	return func(yield func(KeyValue, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stream, err := c.client.Scan(ctx, req)
+		if err != nil {
+			yield(KeyValue{}, err)
+			return
+		}
+
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(KeyValue{}, err)
+				return
+			}
+			if !yield(KeyValue{Key: resp.GetKey(), Value: resp.GetValue()}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Change is a committed change streamed by Watch.
+type Change struct {
+	Key     int64
+	Value   string
+	Deleted bool
+}
+
+// Watch iterates over changes of the given keys, or of all keys if none are
+// given, committed after the stream is established. It runs until ctx is done
+// or the stream fails; if the server disconnects a watcher that fell behind,
+// the ResourceExhausted error is yielded and changes may have been missed, as
+// they may after the Aborted error yielded when the server restores a backup.
+func (c *Client) Watch(ctx context.Context, keys ...int64) iter.Seq2[Change, error] {
+	req := frontendpb.WatchRequest_builder{Keys: keys}.Build()
+
+	return func(yield func(Change, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stream, err := c.client.Watch(ctx, req)
+		if err != nil {
+			yield(Change{}, err)
+			return
+		}
+
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				yield(Change{}, err)
+				return
+			}
+			change := Change{Key: resp.GetKey(), Value: resp.GetValue(), Deleted: resp.GetDeleted()}
+			if !yield(change, nil) {
+				return
+			}
+		}
+	}
+}
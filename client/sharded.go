@@ -0,0 +1,272 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sharded partitions keys across several frontend instances according to a
+// Topology.
+//
+// To reshard, deploy a topology whose Previous field holds the topology in
+// use until then. Writes go to the key's new shard right away, while reads
+// that miss there fall back to the key's previous shard, so keys not moved
+// yet stay visible. Deletes remove a key from both shards. Once Migrate has
+// moved every key, drop Previous from the topology.
+type Sharded struct {
+	current  partitioner
+	previous partitioner // nil unless resharding
+	clients  map[string]*Client
+}
+
+// NewSharded connects to every shard of the topology, and of its previous
+// topology, applying opts to each connection after setting its target.
+func NewSharded(topology *Topology, opts ...Option) (*Sharded, error) {
+	if err := topology.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid topology: %w", err)
+	}
+
+	s := &Sharded{
+		current: newPartitioner(topology),
+		clients: make(map[string]*Client),
+	}
+
+	shards := topology.Shards
+	if topology.Previous != nil {
+		s.previous = newPartitioner(topology.Previous)
+		shards = append(shards[:len(shards):len(shards)], topology.Previous.Shards...)
+	}
+
+	for _, shard := range shards {
+		if _, ok := s.clients[shard.Target]; ok {
+			continue
+		}
+
+		c, err := New(append([]Option{WithTarget(shard.Target)}, opts...)...)
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("failed to connect to shard %s: %w", shard.Name, err)
+		}
+		s.clients[shard.Target] = c
+	}
+
+	return s, nil
+}
+
+// Close closes the connections to all shards.
+func (s *Sharded) Close() error {
+	var errs []error
+	for _, c := range s.clients {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// route returns the client of the key's shard and, while resharding, of its
+// previous shard if that is a different instance.
+func (s *Sharded) route(key int64) (current, previous *Client) {
+	current = s.clients[s.current.owner(key).Target]
+	if s.previous != nil {
+		if prev := s.clients[s.previous.owner(key).Target]; prev != current {
+			previous = prev
+		}
+	}
+	return current, previous
+}
+
+// Put stores a value on the key's shard.
+func (s *Sharded) Put(ctx context.Context, key int64, value string) error {
+	current, _ := s.route(key)
+	return current.Put(ctx, key, value)
+}
+
+// Get reads a value from the key's shard, falling back to its previous shard
+// while resharding.
+func (s *Sharded) Get(ctx context.Context, key int64) (string, error) {
+	current, previous := s.route(key)
+
+	value, err := current.Get(ctx, key)
+	if previous != nil && status.Code(err) == codes.NotFound {
+		return previous.Get(ctx, key)
+	}
+	return value, err
+}
+
+// Delete removes a key from its shard and, while resharding, its previous
+// shard, reporting whether it existed on either.
+func (s *Sharded) Delete(ctx context.Context, key int64) (bool, error) {
+	current, previous := s.route(key)
+
+	found, err := current.Delete(ctx, key)
+	if err != nil || previous == nil {
+		return found, err
+	}
+
+	foundPrevious, err := previous.Delete(ctx, key)
+	return found || foundPrevious, err
+}
+
+// Scan iterates over entries of all shards in key order, merging concurrent
+// scans of each shard. While resharding, an entry is taken from the key's
+// shard if present there and from its previous shard otherwise; left-over
+// copies on other shards are skipped.
+func (s *Sharded) Scan(ctx context.Context, opts ...ScanOption) iter.Seq2[KeyValue, error] {
+	return func(yield func(KeyValue, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// Pull from every shard, keeping the head entry of each
+		type head struct {
+			c     *Client
+			entry KeyValue
+			next  func() (KeyValue, error, bool)
+			stop  func()
+		}
+		var heads []*head
+		defer func() {
+			for _, h := range heads {
+				h.stop()
+			}
+		}()
+
+		advance := func(h *head) (bool, error) {
+			entry, err, ok := h.next()
+			if !ok {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			h.entry = entry
+			return true, nil
+		}
+
+		// Skipped copies would count against a per-shard limit, so while
+		// resharding the limit is only applied to the merged entries
+		limit := scanLimit(opts)
+		shardOpts := opts
+		if s.previous != nil {
+			shardOpts = append(opts[:len(opts):len(opts)], ScanLimit(0))
+		}
+
+		for _, c := range s.clients {
+			next, stop := iter.Pull2(c.Scan(ctx, shardOpts...))
+			h := &head{c: c, next: next, stop: stop}
+			ok, err := advance(h)
+			if err != nil {
+				stop()
+				yield(KeyValue{}, err)
+				return
+			}
+			if ok {
+				heads = append(heads, h)
+			} else {
+				stop()
+			}
+		}
+
+		for yielded := 0; len(heads) > 0 && (limit == 0 || yielded < limit); {
+			// Find the smallest key and every shard holding it
+			key := heads[0].entry.Key
+			for _, h := range heads[1:] {
+				key = min(key, h.entry.Key)
+			}
+
+			current, previous := s.route(key)
+			var chosen *KeyValue
+			var fromPrevious KeyValue
+			hasPrevious := false
+			remaining := heads[:0]
+			for _, h := range heads {
+				if h.entry.Key != key {
+					remaining = append(remaining, h)
+					continue
+				}
+
+				switch h.c {
+				case current:
+					entry := h.entry
+					chosen = &entry
+				case previous:
+					fromPrevious, hasPrevious = h.entry, true
+				}
+
+				ok, err := advance(h)
+				if err != nil {
+					yield(KeyValue{}, err)
+					return
+				}
+				if ok {
+					remaining = append(remaining, h)
+				} else {
+					h.stop()
+				}
+			}
+			heads = remaining
+
+			if chosen == nil && hasPrevious {
+				chosen = &fromPrevious
+			}
+			if chosen == nil {
+				continue
+			}
+			if !yield(*chosen, nil) {
+				return
+			}
+			yielded++
+		}
+	}
+}
+
+// scanLimit returns the limit set by opts, or zero.
+func scanLimit(opts []ScanOption) int {
+	return int(newScanRequest(opts).GetLimit())
+}
+
+// Migrate moves keys whose shard changed from their previous shard to their
+// new one, skipping keys already written there, and returns the number of
+// keys moved. A write to a key between Migrate checking and copying it is
+// overwritten, so it should run while writes to moving keys are paused or
+// can be repeated.
+func (s *Sharded) Migrate(ctx context.Context) (int, error) {
+	if s.previous == nil {
+		return 0, nil
+	}
+
+	moved := 0
+	for _, source := range s.clients {
+		for entry, err := range source.Scan(ctx) {
+			if err != nil {
+				return moved, err
+			}
+
+			current, previous := s.route(entry.Key)
+			if previous != source {
+				continue
+			}
+
+			_, err := current.Get(ctx, entry.Key)
+			switch status.Code(err) {
+			case codes.OK:
+			case codes.NotFound:
+				if err := current.Put(ctx, entry.Key, entry.Value); err != nil {
+					return moved, fmt.Errorf("failed to copy key %d: %w", entry.Key, err)
+				}
+			default:
+				return moved, fmt.Errorf("failed to check key %d: %w", entry.Key, err)
+			}
+
+			if _, err := source.Delete(ctx, entry.Key); err != nil {
+				return moved, fmt.Errorf("failed to remove moved key %d: %w", entry.Key, err)
+			}
+			moved++
+		}
+	}
+
+	return moved, nil
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"slices"
+
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+// Snapshot reads keys as of a single revision, so its reads are consistent
+// with each other however many writes are committed in between. Reads fail
+// with OutOfRange once the server compacts the versions they need.
+type Snapshot struct {
+	client   *Client
+	revision int64
+}
+
+// Snapshot pins the latest revision for later reads.
+func (c *Client) Snapshot(ctx context.Context) (*Snapshot, error) {
+	resp, err := c.client.BatchGet(ctx, &frontendpb.BatchGetRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{client: c, revision: resp.GetRevision()}, nil
+}
+
+// SnapshotAt reads as of revision, as returned by an earlier snapshot.
+func (c *Client) SnapshotAt(revision int64) *Snapshot {
+	return &Snapshot{client: c, revision: revision}
+}
+
+// Revision returns the revision the snapshot reads, which SnapshotAt takes to
+// read the same state again.
+func (s *Snapshot) Revision() int64 {
+	return s.revision
+}
+
+// BatchGet returns the values of the keys that existed as of the snapshot's
+// revision.
+func (s *Snapshot) BatchGet(ctx context.Context, keys ...int64) (map[int64]string, error) {
+	req := frontendpb.BatchGetRequest_builder{Keys: keys, Revision: s.revision}.Build()
+	resp, err := s.client.client.BatchGet(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[int64]string, len(keys))
+	for _, result := range resp.GetResults() {
+		if result.GetFound() {
+			values[result.GetKey()] = result.GetValue()
+		}
+	}
+	return values, nil
+}
+
+// Scan iterates over entries as of the snapshot's revision, like Client.Scan.
+func (s *Snapshot) Scan(ctx context.Context, opts ...ScanOption) iter.Seq2[KeyValue, error] {
+	return s.client.Scan(ctx, slices.Concat(opts, []ScanOption{scanAtRevision(s.revision)})...)
+}
+
+func scanAtRevision(revision int64) ScanOption {
+	return func(req *frontendpb.ScanRequest) {
+		req.SetRevision(revision)
+	}
+}
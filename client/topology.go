@@ -0,0 +1,209 @@
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Sharding strategies of a Topology.
+const (
+	// StrategyHash places keys on a consistent hash ring, so adding a shard
+	// only moves about 1/N of the keys.
+	StrategyHash = "hash"
+	// StrategyRange assigns each shard the keys from its start key up to the
+	// next shard's start key.
+	StrategyRange = "range"
+)
+
+// defaultVirtualNodes is the number of ring points per shard with StrategyHash.
+const defaultVirtualNodes = 128
+
+// Topology describes how keys are partitioned across frontend instances.
+type Topology struct {
+	Strategy string  `yaml:"strategy"`
+	Shards   []Shard `yaml:"shards"`
+	// VirtualNodes is the number of ring points per shard with StrategyHash.
+	// More points spread keys more evenly.
+	VirtualNodes int `yaml:"virtual_nodes"`
+	// Previous is the topology being migrated away from. While set, reads
+	// that miss fall back to the key's previous shard; see Sharded.
+	Previous *Topology `yaml:"previous"`
+}
+
+// Shard is a partition of the key space served by one frontend instance.
+type Shard struct {
+	Name   string `yaml:"name"`
+	Target string `yaml:"target"`
+	// Start is the first key of the shard with StrategyRange. Exactly one
+	// shard must start at math.MinInt64.
+	Start int64 `yaml:"start"`
+}
+
+// LoadTopology reads a topology from a YAML file and validates it.
+func LoadTopology(path string) (*Topology, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read topology: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var t Topology
+	if err := dec.Decode(&t); err != nil {
+		return nil, fmt.Errorf("failed to parse topology %s: %w", path, err)
+	}
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid topology %s: %w", path, err)
+	}
+
+	return &t, nil
+}
+
+// Validate reports every problem with the topology and its previous one.
+func (t *Topology) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if t.Strategy != StrategyHash && t.Strategy != StrategyRange {
+		invalid("strategy must be %q or %q, got %q", StrategyHash, StrategyRange, t.Strategy)
+	}
+	if len(t.Shards) == 0 {
+		invalid("at least one shard is required")
+	}
+	if t.VirtualNodes < 0 {
+		invalid("virtual_nodes must not be negative, got %d", t.VirtualNodes)
+	}
+
+	names := make(map[string]bool)
+	starts := make(map[int64]string)
+	for i, shard := range t.Shards {
+		if shard.Name == "" {
+			invalid("shard %d has no name", i)
+		} else if names[shard.Name] {
+			invalid("shard name %q is used twice", shard.Name)
+		}
+		names[shard.Name] = true
+
+		if shard.Target == "" {
+			invalid("shard %q has no target", shard.Name)
+		}
+
+		if t.Strategy == StrategyRange {
+			if other, ok := starts[shard.Start]; ok {
+				invalid("shards %q and %q both start at %d", other, shard.Name, shard.Start)
+			}
+			starts[shard.Start] = shard.Name
+		}
+	}
+	if _, ok := starts[math.MinInt64]; t.Strategy == StrategyRange && !ok && len(t.Shards) > 0 {
+		invalid("one shard must start at %d", int64(math.MinInt64))
+	}
+
+	if t.Previous != nil {
+		if t.Previous.Previous != nil {
+			invalid("previous topology must not have a previous topology itself")
+		}
+		if err := t.Previous.Validate(); err != nil {
+			invalid("previous: %w", err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// partitioner maps keys to shards.
+type partitioner interface {
+	owner(key int64) Shard
+}
+
+func newPartitioner(t *Topology) partitioner {
+	if t.Strategy == StrategyRange {
+		shards := slices.Clone(t.Shards)
+		slices.SortFunc(shards, func(a, b Shard) int { return cmp.Compare(a.Start, b.Start) })
+		return rangePartitioner(shards)
+	}
+
+	vnodes := t.VirtualNodes
+	if vnodes == 0 {
+		vnodes = defaultVirtualNodes
+	}
+
+	ring := &hashRing{}
+	for _, shard := range t.Shards {
+		for i := range vnodes {
+			ring.points = append(ring.points, ringPoint{
+				hash:  hashString(shard.Name + "#" + strconv.Itoa(i)),
+				shard: shard,
+			})
+		}
+	}
+	slices.SortFunc(ring.points, func(a, b ringPoint) int { return cmp.Compare(a.hash, b.hash) })
+	return ring
+}
+
+// rangePartitioner holds shards sorted by start key.
+type rangePartitioner []Shard
+
+func (r rangePartitioner) owner(key int64) Shard {
+	i := sort.Search(len(r), func(i int) bool { return r[i].Start > key })
+	return r[i-1]
+}
+
+type ringPoint struct {
+	hash  uint64
+	shard Shard
+}
+
+// hashRing is a consistent hash ring. Shards own the keys hashing up to each
+// of their points.
+type hashRing struct {
+	points []ringPoint
+}
+
+func (r *hashRing) owner(key int64) Shard {
+	h := hashKey(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].shard
+}
+
+func hashKey(key int64) uint64 {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(key))
+	h := fnv.New64a()
+	h.Write(buf[:])
+	return mix64(h.Sum64())
+}
+
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return mix64(h.Sum64())
+}
+
+// mix64 scrambles FNV's output, whose high bits barely change between
+// similar inputs, so ring points and keys spread evenly.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+// importMessageSize is the number of bytes sent per Import message.
+const importMessageSize = 64 << 10
+
+// Format is an encoding of entries for Import and Export.
+type Format int
+
+const (
+	// FormatJSONL is one {"key": 1, "value": "..."} object per line.
+	FormatJSONL Format = Format(frontendpb.Format_FORMAT_JSONL)
+	// FormatCSV is one key,value record per line. Imports may start with a
+	// key,value header.
+	FormatCSV Format = Format(frontendpb.Format_FORMAT_CSV)
+	// FormatProto is a sequence of size-delimited PutRequest messages, as
+	// written by protodelim.
+	FormatProto Format = Format(frontendpb.Format_FORMAT_DELIMITED_PROTO)
+)
+
+// ImportOption configures an import.
+type ImportOption func(*frontendpb.ImportRequest, *transferConfig)
+
+// ExportOption configures an export.
+type ExportOption func(*frontendpb.ExportRequest, *transferConfig)
+
+type transferConfig struct {
+	progress func(Progress)
+}
+
+// Progress reports how far an import or export got.
+type Progress struct {
+	// Bytes is the number of encoded bytes sent or received.
+	Bytes int64
+	// Entries is the number of entries exported. Imports report it once
+	// done.
+	Entries int64
+	// LastKey is the key of the last entry exported.
+	LastKey int64
+}
+
+// ImportChunkSize stores n entries per transaction instead of the server's
+// default.
+func ImportChunkSize(n int) ImportOption {
+	return func(req *frontendpb.ImportRequest, _ *transferConfig) {
+		req.SetChunkSize(int64(n))
+	}
+}
+
+// ImportDryRun validates the entries without storing them.
+func ImportDryRun() ImportOption {
+	return func(req *frontendpb.ImportRequest, _ *transferConfig) {
+		req.SetDryRun(true)
+	}
+}
+
+// ImportProgress calls fn after each part of the data is sent.
+func ImportProgress(fn func(Progress)) ImportOption {
+	return func(_ *frontendpb.ImportRequest, c *transferConfig) {
+		c.progress = fn
+	}
+}
+
+// ExportFrom starts the export at key, inclusive. To resume an interrupted
+// export, start it after the LastKey of its result.
+func ExportFrom(key int64) ExportOption {
+	return func(req *frontendpb.ExportRequest, _ *transferConfig) {
+		req.SetStartKey(key)
+	}
+}
+
+// ExportTo stops the export before key.
+func ExportTo(key int64) ExportOption {
+	return func(req *frontendpb.ExportRequest, _ *transferConfig) {
+		req.SetEndKey(key)
+	}
+}
+
+// ExportChunkSize has the server send n entries per message instead of its
+// default.
+func ExportChunkSize(n int) ExportOption {
+	return func(req *frontendpb.ExportRequest, _ *transferConfig) {
+		req.SetChunkSize(int64(n))
+	}
+}
+
+// ExportProgress calls fn after each chunk is written.
+func ExportProgress(fn func(Progress)) ExportOption {
+	return func(_ *frontendpb.ExportRequest, c *transferConfig) {
+		c.progress = fn
+	}
+}
+
+// ImportResult summarizes an import.
+type ImportResult struct {
+	// Entries is the number of entries stored, or validated on a dry run.
+	Entries int64
+	// Chunks is the number of transactions the entries were stored in.
+	Chunks int64
+}
+
+// Import stores the entries read from r in the given format, committing them
+// in chunks. If it fails, chunks committed before the failure are kept. Read
+// caches learn of the imported entries through Watch.
+func (c *Client) Import(ctx context.Context, r io.Reader, format Format, opts ...ImportOption) (ImportResult, error) {
+	req := frontendpb.ImportRequest_builder{Format: frontendpb.Format(format)}.Build()
+	var config transferConfig
+	for _, opt := range opts {
+		opt(req, &config)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.Import(ctx)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	buf := make([]byte, importMessageSize)
+	var sent int64
+	for {
+		// The first message carries the options even if there's no data
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 || sent == 0 {
+			req.SetData(buf[:n])
+			if err := stream.Send(req); errors.Is(err, io.EOF) {
+				break // the server ended the call, CloseAndRecv returns why
+			} else if err != nil {
+				return ImportResult{}, err
+			}
+			sent += int64(n)
+			if config.progress != nil {
+				config.progress(Progress{Bytes: sent})
+			}
+			req = &frontendpb.ImportRequest{}
+		}
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if readErr != nil {
+			return ImportResult{}, fmt.Errorf("failed to read entries: %w", readErr)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return ImportResult{}, err
+	}
+	result := ImportResult{Entries: resp.GetEntries(), Chunks: resp.GetChunks()}
+	if config.progress != nil {
+		config.progress(Progress{Bytes: sent, Entries: result.Entries})
+	}
+	return result, nil
+}
+
+// ExportResult summarizes an export.
+type ExportResult struct {
+	// Entries is the number of entries written.
+	Entries int64
+	// LastKey is the key of the last entry written, if any.
+	LastKey int64
+}
+
+// Export writes entries in key order to w in the given format. Data is
+// written in whole entries, so an export that fails can be resumed by
+// appending to w from the LastKey of the result plus one.
+func (c *Client) Export(ctx context.Context, w io.Writer, format Format, opts ...ExportOption) (ExportResult, error) {
+	req := frontendpb.ExportRequest_builder{Format: frontendpb.Format(format), StartKey: math.MinInt64}.Build()
+	var config transferConfig
+	for _, opt := range opts {
+		opt(req, &config)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.Export(ctx, req)
+	if err != nil {
+		return ExportResult{}, err
+	}
+
+	var result ExportResult
+	var received int64
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return result, err
+		}
+
+		if _, err := w.Write(resp.GetData()); err != nil {
+			return result, err
+		}
+		received += int64(len(resp.GetData()))
+		result = ExportResult{Entries: resp.GetEntries(), LastKey: resp.GetLastKey()}
+		if config.progress != nil {
+			config.progress(Progress{Bytes: received, Entries: result.Entries, LastKey: result.LastKey})
+		}
+	}
+}
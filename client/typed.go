@@ -0,0 +1,247 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec converts values of type T to and from bytes.
+type Codec[T any] interface {
+	Marshal(v T) ([]byte, error)
+	Unmarshal(data []byte, v *T) error
+	// Binary reports whether the output may not be valid UTF-8. Values are
+	// stored as strings, so binary output is stored base64 encoded.
+	Binary() bool
+}
+
+// Compression compresses encoded values before they are stored.
+type Compression interface {
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+// DecodeError is returned by Typed.Get when the stored value of a key can't be
+// decoded.
+type DecodeError struct {
+	Key int64
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode value of key %d: %v", e.Key, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Typed stores values of type T, encoded with a codec, through a Client.
+type Typed[T any] struct {
+	client      *Client
+	codec       Codec[T]
+	compression Compression
+}
+
+// TypedOption configures a Typed client.
+type TypedOption func(*typedConfig)
+
+type typedConfig struct {
+	compression Compression
+}
+
+// WithCompression compresses values after encoding them. Compressed values are
+// always stored base64 encoded, and readers must use the same compression.
+func WithCompression(compression Compression) TypedOption {
+	return func(c *typedConfig) {
+		c.compression = compression
+	}
+}
+
+// NewTyped wraps c to store values of type T encoded with codec.
+func NewTyped[T any](c *Client, codec Codec[T], opts ...TypedOption) *Typed[T] {
+	var config typedConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &Typed[T]{
+		client:      c,
+		codec:       codec,
+		compression: config.compression,
+	}
+}
+
+// Put encodes and stores a value
+func (t *Typed[T]) Put(ctx context.Context, key int64, value T) error {
+	encoded, err := t.encode(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value of key %d: %w", key, err)
+	}
+
+	return t.client.Put(ctx, key, encoded)
+}
+
+// Get retrieves and decodes a value by key. A value that can't be decoded is
+// reported as a *DecodeError.
+func (t *Typed[T]) Get(ctx context.Context, key int64) (T, error) {
+	var value T
+
+	encoded, err := t.client.Get(ctx, key)
+	if err != nil {
+		return value, err
+	}
+
+	if err := t.decode(encoded, &value); err != nil {
+		return value, &DecodeError{Key: key, Err: err}
+	}
+	return value, nil
+}
+
+func (t *Typed[T]) encode(value T) (string, error) {
+	data, err := t.codec.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	if t.compression != nil {
+		if data, err = t.compression.Compress(data); err != nil {
+			return "", fmt.Errorf("failed to compress: %w", err)
+		}
+	}
+
+	if t.compression != nil || t.codec.Binary() {
+		return base64.StdEncoding.EncodeToString(data), nil
+	}
+	return string(data), nil
+}
+
+func (t *Typed[T]) decode(encoded string, value *T) error {
+	data := []byte(encoded)
+	if t.compression != nil || t.codec.Binary() {
+		var err error
+		if data, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+			return fmt.Errorf("invalid base64: %w", err)
+		}
+	}
+
+	if t.compression != nil {
+		var err error
+		if data, err = t.compression.Decompress(data); err != nil {
+			return fmt.Errorf("failed to decompress: %w", err)
+		}
+	}
+
+	return t.codec.Unmarshal(data, value)
+}
+
+// JSONCodec encodes values as JSON.
+func JSONCodec[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+type jsonCodec[T any] struct{}
+
+func (jsonCodec[T]) Marshal(v T) ([]byte, error)       { return json.Marshal(v) }
+func (jsonCodec[T]) Unmarshal(data []byte, v *T) error { return json.Unmarshal(data, v) }
+func (jsonCodec[T]) Binary() bool                      { return false }
+
+// ProtoCodec encodes protobuf messages in the binary wire format. T is a
+// generated message pointer type, such as *frontendpb.PutRequest.
+func ProtoCodec[T proto.Message]() Codec[T] {
+	return protoCodec[T]{}
+}
+
+type protoCodec[T proto.Message] struct{}
+
+func (protoCodec[T]) Marshal(v T) ([]byte, error) {
+	return proto.Marshal(v)
+}
+
+func (protoCodec[T]) Unmarshal(data []byte, v *T) error {
+	// The zero T is a nil pointer, which still knows its message type
+	var zero T
+	msg := zero.ProtoReflect().New().Interface()
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return err
+	}
+	*v = msg.(T)
+	return nil
+}
+
+func (protoCodec[T]) Binary() bool { return true }
+
+// GobCodec encodes values with encoding/gob. Every value carries its own type
+// information, so gob is best suited to values that are few and large.
+func GobCodec[T any]() Codec[T] {
+	return gobCodec[T]{}
+}
+
+type gobCodec[T any] struct{}
+
+func (gobCodec[T]) Marshal(v T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec[T]) Unmarshal(data []byte, v *T) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+func (gobCodec[T]) Binary() bool { return true }
+
+// MsgpackCodec encodes values as MessagePack.
+func MsgpackCodec[T any]() Codec[T] {
+	return msgpackCodec[T]{}
+}
+
+type msgpackCodec[T any] struct{}
+
+func (msgpackCodec[T]) Marshal(v T) ([]byte, error)       { return msgpack.Marshal(v) }
+func (msgpackCodec[T]) Unmarshal(data []byte, v *T) error { return msgpack.Unmarshal(data, v) }
+func (msgpackCodec[T]) Binary() bool                      { return true }
+
+// Gzip compresses values with gzip at the given level, such as
+// gzip.DefaultCompression.
+func Gzip(level int) Compression {
+	return gzipCompression{level: level}
+}
+
+type gzipCompression struct {
+	level int
+}
+
+func (g gzipCompression) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, g.level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCompression) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
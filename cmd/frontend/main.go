@@ -2,64 +2,257 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/earthboundkid/versioninfo/v2"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
 
+	"github.com/dynoinc/gh-go/internal/admin"
+	"github.com/dynoinc/gh-go/internal/audit"
+	"github.com/dynoinc/gh-go/internal/cdc"
 	"github.com/dynoinc/gh-go/internal/config"
 	"github.com/dynoinc/gh-go/internal/frontend"
+	"github.com/dynoinc/gh-go/internal/listen"
+	"github.com/dynoinc/gh-go/internal/logger"
 	"github.com/dynoinc/gh-go/internal/sqlbackend"
 )
 
+// configPollInterval is how often the config file is checked for changes.
+const configPollInterval = 5 * time.Second
+
 func main() {
 	versioninfo.AddFlag(flag.CommandLine)
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file (env CONFIG_FILE)")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration and exit")
+	config.RegisterFlags(flag.CommandLine)
 	flag.Parse()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Load configuration from environment variables
-	cfg, err := config.Load()
+	// Load configuration from flags, environment variables and config file
+	cfg, err := config.Load(*configPath, flag.CommandLine)
 	if err != nil {
 		slog.Error("failed to load config", "error", err)
 		os.Exit(1)
 	}
 
-	backend, err := sqlbackend.New(ctx)
+	if *printConfig {
+		if err := config.Write(os.Stdout, cfg.WithoutSecrets()); err != nil {
+			slog.Error("failed to print config", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Holds the latest applied configuration for components reading it per call
+	var current atomic.Pointer[config.Config]
+	current.Store(cfg)
+
+	// Route logs through a level variable so the admin listener can adjust it
+	level := new(slog.LevelVar)
+	level.Set(cfg.LogLevel)
+	log, err := logger.New(os.Stderr, cfg.LogFormat, level)
+	if err != nil {
+		slog.Error("failed to create logger", "error", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(log)
+
+	backendOpts := []sqlbackend.Option{
+		sqlbackend.WithHistoryRetention(sqlbackend.HistoryRetention{
+			Versions: cfg.HistoryVersions,
+			MaxAge:   cfg.HistoryMaxAge,
+		}),
+		sqlbackend.WithChangeLogRetention(sqlbackend.ChangeLogRetention{
+			Changes: cfg.ChangeLogChanges,
+			MaxAge:  cfg.ChangeLogMaxAge,
+		}),
+		sqlbackend.WithCompactionInterval(cfg.HistoryCompactionInterval),
+	}
+	if cfg.JournalDir != "" {
+		backendOpts = append(backendOpts,
+			sqlbackend.WithJournal(cfg.JournalDir),
+			sqlbackend.WithJournalSegmentSize(int64(cfg.JournalSegmentSize)),
+		)
+		slog.Info("journaling writes", "dir", cfg.JournalDir)
+	}
+	backend, err := sqlbackend.New(ctx, backendOpts...)
 	if err != nil {
 		slog.Error("failed to create backend", "error", err)
 		os.Exit(1)
 	}
 
+	var auditLog *audit.Log
+	if cfg.AuditLog != "" {
+		if auditLog, err = audit.Open(cfg.AuditLog, []byte(cfg.AuditKey)); err != nil {
+			slog.Error("failed to open audit log", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("auditing writes", "path", cfg.AuditLog)
+	}
+
+	logSettings := frontend.NewLogSettings(logOptions(cfg))
+	limiter := rate.NewLimiter(rateLimit(cfg), cfg.RateBurst)
+
+	authToken := func() string { return current.Load().AuthToken }
+	peerToken := func() string { return current.Load().PeerToken }
+	serverOpts := []frontend.ServerOption{
+		frontend.WithLogger(log),
+		frontend.WithLogSettings(logSettings),
+		frontend.WithAuthTokenFunc(authToken),
+		frontend.WithPeerTokenFunc(peerToken),
+		frontend.WithRateLimiter(limiter),
+	}
+	if cfg.ReplicateFrom != "" {
+		serverOpts = append(serverOpts, frontend.WithReplication(frontend.ReplicationConfig{
+			Primary:       cfg.ReplicateFrom,
+			ForwardWrites: cfg.ForwardWrites,
+			AuthToken:     authToken,
+			PeerToken:     peerToken,
+		}))
+		slog.Info("replicating from primary", "primary", cfg.ReplicateFrom, "forward_writes", cfg.ForwardWrites)
+	}
+	var clusterNamespaces *frontend.ClusterNamespaces
+	if cfg.RaftAddress != "" {
+		clusterNamespaces = &frontend.ClusterNamespaces{}
+		var peers []frontend.ClusterPeer
+		for _, peer := range cfg.RaftPeers {
+			id, addr, _ := strings.Cut(peer, "=")
+			peers = append(peers, frontend.ClusterPeer{ID: id, RaftAddress: addr})
+		}
+		serverOpts = append(serverOpts, frontend.WithCluster(frontend.ClusterConfig{
+			ID:          cfg.RaftID,
+			RaftAddress: cfg.RaftAddress,
+			Dir:         cfg.RaftDir,
+			Bootstrap:   cfg.RaftBootstrap,
+			Peers:       peers,
+			AuthToken:   authToken,
+			PeerToken:   peerToken,
+			Namespaces:  clusterNamespaces,
+		}))
+		slog.Info("running as cluster node", "id", cfg.RaftID, "raft_address", cfg.RaftAddress)
+	}
+	if auditLog != nil {
+		serverOpts = append(serverOpts, frontend.WithAuditLog(auditLog))
+	}
+
 	// Create gRPC server with OpenTelemetry instrumentation (enabled by default)
-	server, otelCleanup, err := frontend.NewServer(ctx, backend)
+	server, otelCleanup, err := frontend.NewServer(ctx, backend, serverOpts...)
 	if err != nil {
 		slog.Error("failed to create gRPC server", "error", err)
 		os.Exit(1)
 	}
 	slog.Info("created gRPC server with OpenTelemetry instrumentation")
 
-	// Listen on TCP port
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
+	// Listen on the configured addresses plus any sockets passed by systemd
+	listeners, err := listen.Activated()
+	if err != nil {
+		slog.Error("failed to use socket activated listeners", "error", err)
+		os.Exit(1)
+	}
+	addrs := cfg.ListenAddrs()
+	if len(listeners) > 0 && len(cfg.Listen) == 0 {
+		addrs = nil // socket activation replaces the default port
+	}
+	configured, err := listen.Listen(addrs, cfg.SocketFileMode())
 	if err != nil {
 		slog.Error("failed to listen", "error", err)
 		os.Exit(1)
 	}
+	listeners = append(listeners, configured...)
 
 	// Create errgroup for coordinating goroutines
 	g, ctx := errgroup.WithContext(ctx)
 
-	// Start server in a goroutine
+	// Serve every listener from the same server in its own goroutine
+	for _, lis := range listeners {
+		g.Go(func() error {
+			slog.Info("starting gRPC server", "addr", listen.Addr(lis))
+			return server.Serve(lis)
+		})
+	}
+
+	// Start the opt-in admin listener
+	if cfg.AdminPort != 0 {
+		adminOpts := []admin.Option{
+			admin.WithToken(cfg.AdminToken),
+			admin.WithConfig(func() any { return current.Load().Redacted() }),
+			admin.WithLevel(level),
+		}
+		if backups, ok := backend.(sqlbackend.Backupper); ok {
+			adminOpts = append(adminOpts, admin.WithBackups(backups))
+		}
+		if auditLog != nil {
+			adminOpts = append(adminOpts, admin.WithAudit(auditLog))
+		}
+		if clusterNamespaces != nil {
+			adminOpts = append(adminOpts, admin.WithNamespaces(clusterNamespaces))
+		} else if namespaces, ok := backend.(sqlbackend.Namespaces); ok {
+			adminOpts = append(adminOpts, admin.WithNamespaces(namespaces))
+		}
+		handler, adminCleanup, err := admin.NewHandler(adminOpts...)
+		if err != nil {
+			slog.Error("failed to create admin handler", "error", err)
+			os.Exit(1)
+		}
+		defer adminCleanup()
+
+		adminLis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.AdminPort))
+		if err != nil {
+			slog.Error("failed to listen on admin port", "error", err)
+			os.Exit(1)
+		}
+
+		adminServer := admin.NewServer(handler)
+		g.Go(func() error {
+			slog.Info("starting admin server", "port", cfg.AdminPort)
+			if err := adminServer.Serve(adminLis); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		})
+		g.Go(func() error {
+			<-ctx.Done()
+			return adminServer.Shutdown(context.Background())
+		})
+	}
+
+	// Deliver committed changes to the configured CDC sinks
+	pipelines, closeSinks, err := cdcPipelines(ctx, cfg, backend, func() string { return current.Load().CDCWebhookToken })
+	if err != nil {
+		slog.Error("failed to start change data capture", "error", err)
+		os.Exit(1)
+	}
+	defer closeSinks()
+	for _, pipeline := range pipelines {
+		g.Go(func() error {
+			pipeline.Run(ctx)
+			return nil
+		})
+	}
+
+	// Apply safe-to-change settings on SIGHUP or config file changes
 	g.Go(func() error {
-		slog.Info("starting gRPC server", "port", cfg.Port)
-		return server.Serve(lis)
+		return config.Watch(ctx, *configPath, flag.CommandLine, *cfg, configPollInterval, func(next config.Config) {
+			level.Set(next.LogLevel)
+			logSettings.Update(logOptions(&next))
+			limiter.SetLimit(rateLimit(&next))
+			limiter.SetBurst(next.RateBurst)
+			current.Store(&next)
+		})
 	})
 
 	// Start signal handler in a separate goroutine
@@ -94,4 +287,82 @@ func main() {
 	if err := backend.Close(context.Background()); err != nil {
 		slog.Error("failed to close backend", "error", err)
 	}
+	if auditLog != nil {
+		if err := auditLog.Close(); err != nil {
+			slog.Error("failed to close audit log", "error", err)
+		}
+	}
+}
+
+// cdcPipelines returns a pipeline for each configured CDC sink, and a function
+// closing the sinks once the pipelines have stopped.
+func cdcPipelines(ctx context.Context, cfg *config.Config, backend sqlbackend.Backend, webhookToken func() string) ([]*cdc.Pipeline, func(), error) {
+	var sinks []cdc.Sink
+	var names []string
+	closeSinks := func() {
+		for _, sink := range sinks {
+			if err := sink.Close(); err != nil {
+				slog.Error("failed to close CDC sink", "error", err)
+			}
+		}
+	}
+
+	if cfg.CDCFileDir != "" {
+		sink, err := cdc.NewFileSink(cfg.CDCFileDir, int64(cfg.CDCFileMaxSize))
+		if err != nil {
+			return nil, nil, err
+		}
+		sinks, names = append(sinks, sink), append(names, "file")
+	}
+	if cfg.CDCWebhookURL != "" {
+		sinks, names = append(sinks, cdc.NewWebhookSink(cfg.CDCWebhookURL, webhookToken)), append(names, "webhook")
+	}
+	if cfg.CDCGRPCTarget != "" {
+		sink, err := cdc.NewGRPCSink(cfg.CDCGRPCTarget)
+		if err != nil {
+			closeSinks()
+			return nil, nil, err
+		}
+		sinks, names = append(sinks, sink), append(names, "grpc")
+	}
+	if len(sinks) == 0 {
+		return nil, closeSinks, nil
+	}
+
+	log, ok := backend.(sqlbackend.ChangeLog)
+	if !ok {
+		closeSinks()
+		return nil, nil, errors.New("backend does not keep a change log")
+	}
+	pipelines := make([]*cdc.Pipeline, 0, len(sinks))
+	for i, sink := range sinks {
+		pipeline, err := cdc.New(ctx, names[i], log, sink, cfg.CDCOffsetDir,
+			cdc.WithBatchSize(cfg.CDCBatchSize),
+			cdc.WithPollInterval(cfg.CDCPollInterval),
+		)
+		if err != nil {
+			closeSinks()
+			return nil, nil, err
+		}
+		slog.Info("capturing changes", "sink", names[i], "offset", pipeline.Offset())
+		pipelines = append(pipelines, pipeline)
+	}
+	return pipelines, closeSinks, nil
+}
+
+func logOptions(cfg *config.Config) frontend.LogOptions {
+	return frontend.LogOptions{
+		SampleRates:  cfg.LogSampleRates,
+		Payloads:     cfg.LogPayloads,
+		RedactFields: cfg.LogRedactFields,
+	}
+}
+
+// rateLimit maps the configured rate to a limiter rate, where zero means
+// unlimited.
+func rateLimit(cfg *config.Config) rate.Limit {
+	if cfg.RateLimit == 0 {
+		return rate.Inf
+	}
+	return rate.Limit(cfg.RateLimit)
 }
@@ -0,0 +1,226 @@
+// Package admin implements the opt-in debugging listener of the frontend.
+//
+// The handler serves net/http/pprof profiles, the gRPC channelz admin services,
+// the effective (redacted) configuration, build information, a runtime log
+// level knob and, if enabled, the AdminService for online backups, the
+// AuditService and the NamespaceService. Every endpoint requires the admin
+// bearer token, which is kept separate from any token used by the main
+// service.
+package admin
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/earthboundkid/versioninfo/v2"
+	"google.golang.org/grpc"
+	grpcadmin "google.golang.org/grpc/admin"
+
+	"github.com/dynoinc/gh-go/internal/audit"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	adminpb "github.com/dynoinc/gh-go/proto/admin/v1"
+)
+
+// Option configures the admin handler.
+type Option func(*adminConfig)
+
+type adminConfig struct {
+	token      string
+	config     func() any
+	level      *slog.LevelVar
+	backups    sqlbackend.Backupper
+	audit      *audit.Log
+	namespaces sqlbackend.Namespaces
+}
+
+// WithToken sets the bearer token required by every admin endpoint.
+func WithToken(token string) Option {
+	return func(c *adminConfig) {
+		c.token = token
+	}
+}
+
+// WithConfig sets the function returning the value served as JSON from
+// /debug/config. Callers are responsible for redacting secrets.
+func WithConfig(config func() any) Option {
+	return func(c *adminConfig) {
+		c.config = config
+	}
+}
+
+// WithLevel sets the level variable read and updated by /debug/loglevel.
+func WithLevel(level *slog.LevelVar) Option {
+	return func(c *adminConfig) {
+		c.level = level
+	}
+}
+
+// WithBackups serves the AdminService, backing up and restoring the given
+// backend.
+func WithBackups(backups sqlbackend.Backupper) Option {
+	return func(c *adminConfig) {
+		c.backups = backups
+	}
+}
+
+// WithAudit serves the AuditService, querying the given audit log.
+func WithAudit(log *audit.Log) Option {
+	return func(c *adminConfig) {
+		c.audit = log
+	}
+}
+
+// WithNamespaces serves the NamespaceService, managing the namespaces of the
+// given backend.
+func WithNamespaces(namespaces sqlbackend.Namespaces) Option {
+	return func(c *adminConfig) {
+		c.namespaces = namespaces
+	}
+}
+
+// BuildInfo describes the running binary.
+type BuildInfo struct {
+	Version    string    `json:"version"`
+	Revision   string    `json:"revision"`
+	LastCommit time.Time `json:"last_commit"`
+	DirtyBuild bool      `json:"dirty_build"`
+	GoVersion  string    `json:"go_version"`
+}
+
+// NewHandler creates the admin handler. gRPC requests (HTTP/2 with an
+// application/grpc content type) are routed to the channelz admin services, all
+// other requests to the HTTP debug endpoints. The returned cleanup function
+// releases the admin service resources.
+func NewHandler(opts ...Option) (http.Handler, func(), error) {
+	cfg := &adminConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.token == "" {
+		return nil, nil, errors.New("admin token is required")
+	}
+
+	grpcServer := grpc.NewServer()
+	adminCleanup, err := grpcadmin.Register(grpcServer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to register admin services: %w", err)
+	}
+	if cfg.backups != nil {
+		adminpb.RegisterAdminServiceServer(grpcServer, &backupServer{backups: cfg.backups})
+	}
+	if cfg.audit != nil {
+		adminpb.RegisterAuditServiceServer(grpcServer, &auditServer{log: cfg.audit})
+	}
+	if cfg.namespaces != nil {
+		adminpb.RegisterNamespaceServiceServer(grpcServer, &namespaceServer{namespaces: cfg.namespaces})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("GET /debug/config", func(w http.ResponseWriter, r *http.Request) {
+		if cfg.config == nil {
+			http.Error(w, "config is not available", http.StatusNotImplemented)
+			return
+		}
+		writeJSON(w, cfg.config())
+	})
+	mux.HandleFunc("GET /debug/buildinfo", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, BuildInfo{
+			Version:    versioninfo.Version,
+			Revision:   versioninfo.Revision,
+			LastCommit: versioninfo.LastCommit,
+			DirtyBuild: versioninfo.DirtyBuild,
+			GoVersion:  runtime.Version(),
+		})
+	})
+	mux.HandleFunc("/debug/loglevel", cfg.handleLogLevel)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !cfg.authorized(r) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+
+	cleanup := func() {
+		grpcServer.Stop()
+		adminCleanup()
+	}
+
+	return handler, cleanup, nil
+}
+
+// NewServer wraps the admin handler in an http.Server that accepts both
+// HTTP/1.1 and unencrypted HTTP/2, so gRPC admin clients can connect without
+// TLS.
+func NewServer(handler http.Handler) *http.Server {
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+
+	return &http.Server{
+		Handler:           handler,
+		Protocols:         &protocols,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+func (c *adminConfig) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(c.token)) == 1
+}
+
+// handleLogLevel reports the current log level on GET and changes it on
+// PUT/POST, taking the new level from the "level" query or form parameter.
+func (c *adminConfig) handleLogLevel(w http.ResponseWriter, r *http.Request) {
+	if c.level == nil {
+		http.Error(w, "log level is not adjustable", http.StatusNotImplemented)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(r.FormValue("level"))); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Info("changing log level", "from", c.level.Level(), "to", level)
+		c.level.Set(level)
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	writeJSON(w, map[string]string{"level": c.level.Level().String()})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("failed to write admin response", "error", err)
+	}
+}
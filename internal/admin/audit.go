@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/internal/audit"
+	adminpb "github.com/dynoinc/gh-go/proto/admin/v1"
+)
+
+const (
+	// defaultAuditLimit is the number of entries QueryAudit returns unless
+	// the request sets a limit.
+	defaultAuditLimit = 1000
+	// maxAuditLimit bounds the limit QueryAudit requests may set.
+	maxAuditLimit = 10000
+)
+
+// auditServer implements the AuditService.
+type auditServer struct {
+	adminpb.UnimplementedAuditServiceServer
+
+	log *audit.Log
+}
+
+func (s *auditServer) QueryAudit(ctx context.Context, req *adminpb.QueryAuditRequest) (*adminpb.QueryAuditResponse, error) {
+	limit := req.GetLimit()
+	if limit < 0 || limit > maxAuditLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 0 and %d, got %d", maxAuditLimit, limit)
+	}
+	if limit == 0 {
+		limit = defaultAuditLimit
+	}
+
+	filter := audit.Filter{
+		Principal: req.GetPrincipal(),
+		Method:    req.GetMethod(),
+		AfterSeq:  req.GetAfterSeq(),
+		Limit:     int(limit),
+	}
+	if req.HasNamespace() {
+		namespace := req.GetNamespace()
+		filter.Namespace = &namespace
+	}
+	if req.HasKey() {
+		key := req.GetKey()
+		filter.Key = &key
+	}
+	if req.GetSince() != 0 {
+		filter.Since = time.Unix(0, req.GetSince())
+	}
+	if req.GetUntil() != 0 {
+		filter.Until = time.Unix(0, req.GetUntil())
+	}
+
+	// The head is read first, so it covers every entry returned
+	seq, head := s.log.Head()
+	entries, err := s.log.Query(filter)
+	if errors.Is(err, audit.ErrTampered) {
+		return nil, status.Error(codes.DataLoss, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := make([]*adminpb.AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		resp = append(resp, adminpb.AuditEntry_builder{
+			Seq:       entry.Seq,
+			Time:      entry.Time.UnixNano(),
+			Principal: entry.Principal,
+			Peer:      entry.Peer,
+			Via:       entry.Via,
+			Method:    entry.Method,
+			Namespace: entry.Namespace,
+			Key:       entry.Key,
+			OldHash:   entry.OldHash,
+			NewHash:   entry.NewHash,
+			TraceId:   entry.TraceID,
+			PrevHash:  entry.PrevHash,
+			Hash:      entry.Hash,
+		}.Build())
+	}
+	return adminpb.QueryAuditResponse_builder{Entries: resp, HeadSeq: seq, HeadHash: head}.Build(), nil
+}
@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	adminpb "github.com/dynoinc/gh-go/proto/admin/v1"
+)
+
+// backupServer implements the AdminService.
+type backupServer struct {
+	adminpb.UnimplementedAdminServiceServer
+
+	backups sqlbackend.Backupper
+}
+
+func (s *backupServer) Backup(ctx context.Context, req *adminpb.BackupRequest) (*adminpb.BackupResponse, error) {
+	if !filepath.IsAbs(req.GetPath()) {
+		return nil, status.Errorf(codes.InvalidArgument, "path must be absolute, got %q", req.GetPath())
+	}
+
+	info, err := s.backups.Backup(ctx, req.GetPath())
+	if err != nil {
+		return nil, backupError(err)
+	}
+	slog.InfoContext(ctx, "backed up database", "path", req.GetPath(), "size", info.Size, "last_seq", info.LastSeq)
+	return adminpb.BackupResponse_builder{Info: backupInfo(req.GetPath(), info)}.Build(), nil
+}
+
+func (s *backupServer) Restore(ctx context.Context, req *adminpb.RestoreRequest) (*adminpb.RestoreResponse, error) {
+	if !filepath.IsAbs(req.GetPath()) {
+		return nil, status.Errorf(codes.InvalidArgument, "path must be absolute, got %q", req.GetPath())
+	}
+
+	info, err := s.backups.Restore(ctx, req.GetPath())
+	if err != nil {
+		return nil, backupError(err)
+	}
+	slog.WarnContext(ctx, "restored database from backup", "path", req.GetPath(), "created_at", info.CreatedAt, "last_seq", info.LastSeq)
+	return adminpb.RestoreResponse_builder{Info: backupInfo(req.GetPath(), info)}.Build(), nil
+}
+
+func backupInfo(path string, info sqlbackend.BackupInfo) *adminpb.BackupInfo {
+	return adminpb.BackupInfo_builder{
+		Path:          path,
+		Sha256:        info.SHA256,
+		SizeBytes:     info.Size,
+		SchemaVersion: info.SchemaVersion,
+		LastSeq:       info.LastSeq,
+		CreatedAt:     info.CreatedAt.UnixNano(),
+	}.Build()
+}
+
+func backupError(err error) error {
+	switch {
+	case errors.Is(err, os.ErrExist):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, os.ErrNotExist):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, sqlbackend.ErrChecksumMismatch), errors.Is(err, sqlbackend.ErrSchemaMismatch):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	adminpb "github.com/dynoinc/gh-go/proto/admin/v1"
+)
+
+// namespaceServer implements the NamespaceService.
+type namespaceServer struct {
+	adminpb.UnimplementedNamespaceServiceServer
+
+	namespaces sqlbackend.Namespaces
+}
+
+func (s *namespaceServer) CreateNamespace(ctx context.Context, req *adminpb.CreateNamespaceRequest) (*adminpb.CreateNamespaceResponse, error) {
+	if req.GetName() == sqlbackend.DefaultNamespace {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	quota := sqlbackend.Quota{MaxKeys: req.GetMaxKeys(), MaxBytes: req.GetMaxBytes()}
+	ns, err := s.namespaces.CreateNamespace(ctx, req.GetName(), quota)
+	if err != nil {
+		return nil, namespaceError(err)
+	}
+	return adminpb.CreateNamespaceResponse_builder{Namespace: namespaceProto(ns)}.Build(), nil
+}
+
+func (s *namespaceServer) GetNamespace(ctx context.Context, req *adminpb.GetNamespaceRequest) (*adminpb.GetNamespaceResponse, error) {
+	ns, err := s.namespaces.GetNamespace(ctx, req.GetName())
+	if err != nil {
+		return nil, namespaceError(err)
+	}
+	return adminpb.GetNamespaceResponse_builder{Namespace: namespaceProto(ns)}.Build(), nil
+}
+
+func (s *namespaceServer) ListNamespaces(ctx context.Context, _ *adminpb.ListNamespacesRequest) (*adminpb.ListNamespacesResponse, error) {
+	namespaces, err := s.namespaces.ListNamespaces(ctx)
+	if err != nil {
+		return nil, namespaceError(err)
+	}
+
+	resp := make([]*adminpb.Namespace, 0, len(namespaces))
+	for _, ns := range namespaces {
+		resp = append(resp, namespaceProto(ns))
+	}
+	return adminpb.ListNamespacesResponse_builder{Namespaces: resp}.Build(), nil
+}
+
+func (s *namespaceServer) UpdateNamespace(ctx context.Context, req *adminpb.UpdateNamespaceRequest) (*adminpb.UpdateNamespaceResponse, error) {
+	quota := sqlbackend.Quota{MaxKeys: req.GetMaxKeys(), MaxBytes: req.GetMaxBytes()}
+	ns, err := s.namespaces.SetQuota(ctx, req.GetName(), quota)
+	if err != nil {
+		return nil, namespaceError(err)
+	}
+	return adminpb.UpdateNamespaceResponse_builder{Namespace: namespaceProto(ns)}.Build(), nil
+}
+
+func (s *namespaceServer) DeleteNamespace(ctx context.Context, req *adminpb.DeleteNamespaceRequest) (*adminpb.DeleteNamespaceResponse, error) {
+	if err := s.namespaces.DeleteNamespace(ctx, req.GetName()); err != nil {
+		return nil, namespaceError(err)
+	}
+	return &adminpb.DeleteNamespaceResponse{}, nil
+}
+
+// namespaceError maps errors of namespace operations to status errors. Those
+// of changes a cluster node forwarded to its leader already are.
+func namespaceError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, sqlbackend.ErrInvalidNamespace):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, sqlbackend.ErrNamespaceNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, sqlbackend.ErrNamespaceExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, sqlbackend.ErrNamespaceNotEmpty):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func namespaceProto(ns sqlbackend.Namespace) *adminpb.Namespace {
+	return adminpb.Namespace_builder{
+		Name:      ns.Name,
+		MaxKeys:   ns.Quota.MaxKeys,
+		MaxBytes:  ns.Quota.MaxBytes,
+		Keys:      ns.Keys,
+		Bytes:     ns.Bytes,
+		CreatedAt: ns.CreatedAt.UnixNano(),
+	}.Build()
+}
@@ -0,0 +1,334 @@
+// Package audit keeps a tamper-evident log of the writes a server handles.
+//
+// The log is a file of JSON entries, one per line. Each entry carries the hash
+// of the entry before it and its own hash, computed over its other fields, so
+// changing, inserting or removing an entry breaks the chain from that entry
+// on. With a key, hashes are HMACs, so the chain can't be recomputed after
+// tampering without the key. Removing entries from the end of the log is only
+// evident against a head hash recorded elsewhere.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"iter"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrTampered is returned when the log's hash chain is broken.
+var ErrTampered = errors.New("audit log hash chain is broken")
+
+// maxEntrySize bounds the size of an entry in the log.
+const maxEntrySize = 1 << 20
+
+// Entry records a change of a key.
+type Entry struct {
+	// Seq numbers entries from one.
+	Seq  int64     `json:"seq"`
+	Time time.Time `json:"time"`
+	// Principal identifies the caller; Peer is its address.
+	Principal string `json:"principal"`
+	Peer      string `json:"peer"`
+	// Via is the Principal of the server that forwarded the write on the
+	// caller's behalf, empty if the caller made it directly.
+	Via string `json:"via,omitempty"`
+	// Method is the full gRPC method name of the call.
+	Method string `json:"method"`
+	// Namespace is the key's namespace, empty for the default namespace.
+	Namespace string `json:"namespace,omitempty"`
+	Key       int64  `json:"key"`
+	// OldHash and NewHash are the ValueHash of the value before and after
+	// the change, or empty if the key didn't exist or was deleted.
+	OldHash string `json:"old_hash,omitempty"`
+	NewHash string `json:"new_hash,omitempty"`
+	TraceID string `json:"trace_id,omitempty"`
+	// PrevHash is the Hash of the previous entry, empty for the first.
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+// ValueHash returns the hex-encoded SHA-256 hash of a value.
+func ValueHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// Filter selects entries. Zero fields match every entry.
+type Filter struct {
+	// Namespace and Key, if not nil, match entries of keys in this namespace
+	// and this key.
+	Namespace *string
+	Key       *int64
+	Principal string
+	Method    string
+	// Since and Until bound the entries' times, inclusive.
+	Since, Until time.Time
+	// AfterSeq matches entries following this one, to page through results.
+	AfterSeq int64
+	// Limit caps the number of entries returned.
+	Limit int
+}
+
+func (f *Filter) match(e *Entry) bool {
+	return e.Seq > f.AfterSeq &&
+		(f.Namespace == nil || e.Namespace == *f.Namespace) &&
+		(f.Key == nil || e.Key == *f.Key) &&
+		(f.Principal == "" || e.Principal == f.Principal) &&
+		(f.Method == "" || e.Method == f.Method) &&
+		(f.Since.IsZero() || !e.Time.Before(f.Since)) &&
+		(f.Until.IsZero() || !e.Time.After(f.Until))
+}
+
+// Log appends entries to an audit log file.
+type Log struct {
+	path string
+	key  []byte
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64 // of the complete entries written
+	seq  int64
+	head string
+}
+
+// Open opens the log at path, creating it if needed, after verifying its
+// hash chain with key, which may be empty. An entry torn by a crash at the
+// end of the log is removed.
+func Open(path string, key []byte) (*Log, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	l := &Log{path: path, key: key, f: f}
+	for entry, err := range readEntries(f, key) {
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		l.seq, l.head = entry.Seq, entry.Hash
+	}
+
+	// Drop a torn entry, so the next one starts on its own line
+	if l.size, err = completeSize(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Truncate(l.size); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to truncate audit log: %w", err)
+	}
+	if _, err := f.Seek(l.size, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// Append assigns the entries their Seq, PrevHash and Hash and appends them,
+// syncing the log before it returns.
+func (l *Log) Append(entries ...Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.f == nil {
+		return errors.New("audit log is closed")
+	}
+
+	var buf bytes.Buffer
+	seq, head := l.seq, l.head
+	for _, entry := range entries {
+		seq++
+		entry.Seq, entry.PrevHash, entry.Time = seq, head, entry.Time.UTC()
+		entry.Hash = entryHash(l.key, entry)
+		head = entry.Hash
+
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	if _, err := l.f.Write(buf.Bytes()); err != nil {
+		l.rollback()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if err := l.f.Sync(); err != nil {
+		l.rollback()
+		return fmt.Errorf("failed to sync audit log: %w", err)
+	}
+	l.size += int64(buf.Len())
+	l.seq, l.head = seq, head
+	return nil
+}
+
+// rollback drops whatever a failed append wrote, so no partial entry is left
+// before the next one.
+func (l *Log) rollback() {
+	l.f.Truncate(l.size)
+	l.f.Seek(l.size, io.SeekStart)
+}
+
+// Head returns the Seq and Hash of the last entry, which pin the log's
+// current contents.
+func (l *Log) Head() (int64, string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.seq, l.head
+}
+
+// Query returns the entries matching filter, in log order.
+func (l *Log) Query(filter Filter) ([]Entry, error) {
+	l.mu.Lock()
+	size := l.size
+	l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	for entry, err := range readEntries(io.LimitReader(f, size), l.key) {
+		if err != nil {
+			return nil, err
+		}
+		if !filter.match(&entry) {
+			continue
+		}
+		entries = append(entries, entry)
+		if filter.Limit > 0 && len(entries) == filter.Limit {
+			break
+		}
+	}
+	return entries, nil
+}
+
+// Close closes the log.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
+
+// Verify checks the hash chain of the log at path with key, returning its
+// last entry, which is zero if the log is empty.
+func Verify(path string, key []byte) (Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var last Entry
+	for entry, err := range readEntries(f, key) {
+		if err != nil {
+			return last, err
+		}
+		last = entry
+	}
+	return last, nil
+}
+
+// readEntries reads the complete entries of a log, verifying their chain.
+// After an error it stops.
+func readEntries(r io.Reader, key []byte) iter.Seq2[Entry, error] {
+	return func(yield func(Entry, error) bool) {
+		br := bufio.NewReader(r)
+		var prev Entry
+		for {
+			line, err := br.ReadBytes('\n')
+			if errors.Is(err, io.EOF) {
+				return // a torn entry, if any, was never acknowledged
+			}
+			if err != nil {
+				yield(Entry{}, fmt.Errorf("failed to read audit log: %w", err))
+				return
+			}
+			if len(line) > maxEntrySize {
+				yield(Entry{}, fmt.Errorf("%w: entry %d is too large", ErrTampered, prev.Seq+1))
+				return
+			}
+
+			var entry Entry
+			if err := json.Unmarshal(line, &entry); err != nil {
+				yield(Entry{}, fmt.Errorf("%w: entry %d is malformed: %v", ErrTampered, prev.Seq+1, err))
+				return
+			}
+			switch {
+			case entry.Seq != prev.Seq+1:
+				yield(Entry{}, fmt.Errorf("%w: entry %d follows entry %d", ErrTampered, entry.Seq, prev.Seq))
+				return
+			case entry.PrevHash != prev.Hash:
+				yield(Entry{}, fmt.Errorf("%w: entry %d doesn't link to the entry before it", ErrTampered, entry.Seq))
+				return
+			case !hmac.Equal([]byte(entry.Hash), []byte(entryHash(key, entry))):
+				yield(Entry{}, fmt.Errorf("%w: entry %d doesn't match its hash", ErrTampered, entry.Seq))
+				return
+			}
+
+			if !yield(entry, nil) {
+				return
+			}
+			prev = entry
+		}
+	}
+}
+
+// entryHash hashes an entry's fields other than Hash.
+func entryHash(key []byte, entry Entry) string {
+	entry.Hash = ""
+	data, _ := json.Marshal(entry) // only fails for unsupported types
+
+	var h hash.Hash
+	if len(key) > 0 {
+		h = hmac.New(sha256.New, key)
+	} else {
+		h = sha256.New()
+	}
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// completeSize returns the size of the complete lines of f.
+func completeSize(f *os.File) (int64, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	size := info.Size()
+
+	// Scan back from the end for the last newline
+	buf := make([]byte, 4096)
+	for end := size; end > 0; {
+		start := max(end-int64(len(buf)), 0)
+		n, err := f.ReadAt(buf[:end-start], start)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			return start + int64(i) + 1, nil
+		}
+		end = start
+	}
+	return 0, nil
+}
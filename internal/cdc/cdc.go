@@ -0,0 +1,245 @@
+// Package cdc captures the changes committed to a backend and delivers them to
+// downstream systems.
+//
+// A Pipeline tails the backend's change log and hands changes to a Sink in
+// batches, in log order. Once a sink accepts a batch, the pipeline records the
+// batch's last sequence number in an offset file, so after a restart delivery
+// resumes where it left off. Batches are retried until delivered, and batches
+// delivered but not yet recorded are delivered again after a crash, so sinks
+// see every change at least once.
+package cdc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+const (
+	// DefaultBatchSize is the maximum number of changes delivered at once
+	// unless configured otherwise.
+	DefaultBatchSize = 500
+	// DefaultPollInterval is how often an idle pipeline checks for new
+	// changes unless configured otherwise.
+	DefaultPollInterval = time.Second
+)
+
+// Sink receives captured changes.
+type Sink interface {
+	// Deliver stores or forwards changes, given in log order. Returning nil
+	// acknowledges them; on error the same changes are delivered again.
+	Deliver(ctx context.Context, changes []sqlbackend.Change) error
+	// Close releases the sink's resources.
+	Close() error
+}
+
+// Pipeline delivers the changes of a change log to a sink.
+type Pipeline struct {
+	name    string
+	log     sqlbackend.ChangeLog
+	sink    Sink
+	offsets string // path of the offset file
+
+	batchSize    int
+	pollInterval time.Duration
+	logger       *slog.Logger
+
+	offset atomic.Int64 // sequence number of the last change delivered
+}
+
+// Option configures a pipeline.
+type Option func(*Pipeline)
+
+// WithBatchSize delivers up to n changes at once. The default is
+// DefaultBatchSize.
+func WithBatchSize(n int) Option {
+	return func(p *Pipeline) {
+		p.batchSize = n
+	}
+}
+
+// WithPollInterval sets how often an idle pipeline checks for new changes.
+// The default is DefaultPollInterval.
+func WithPollInterval(interval time.Duration) Option {
+	return func(p *Pipeline) {
+		p.pollInterval = interval
+	}
+}
+
+// WithLogger sets the logger delivery failures are reported to. The default
+// is slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(p *Pipeline) {
+		p.logger = logger
+	}
+}
+
+// New returns a pipeline delivering the changes of log to sink, keeping its
+// offset in offsetDir under name. Pipelines of different sinks need different
+// names. A new pipeline starts with the first change in the log.
+//
+// The log must keep numbering changes across restarts. An offset beyond its
+// last change means the log was numbered anew, and delivery would skip the
+// changes numbered up to the offset, so it's an error, as is an offset that
+// changes were trimmed beyond. The log keeps the changes following the offset
+// from then on.
+func New(ctx context.Context, name string, log sqlbackend.ChangeLog, sink Sink, offsetDir string, opts ...Option) (*Pipeline, error) {
+	p := &Pipeline{
+		name:         name,
+		log:          log,
+		sink:         sink,
+		offsets:      filepath.Join(offsetDir, name+".offset"),
+		batchSize:    DefaultBatchSize,
+		pollInterval: DefaultPollInterval,
+		logger:       slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if err := os.MkdirAll(offsetDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create offset directory: %w", err)
+	}
+	offset, err := readOffset(p.offsets)
+	if err != nil {
+		return nil, err
+	}
+	last, err := log.LastSeq(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read last change: %w", err)
+	}
+	if offset > last {
+		return nil, fmt.Errorf("offset %d of sink %q is beyond the last change %d, so the change log was reset; remove %s to deliver from the start", offset, name, last, p.offsets)
+	}
+	p.offset.Store(offset)
+
+	// Retain the changes before checking they're still there, so they can't
+	// be trimmed in between
+	release := log.Retain(p.Offset)
+	if _, err := log.Changes(ctx, offset, 1); err != nil {
+		release()
+		return nil, fmt.Errorf("failed to read changes following offset %d of sink %q: %w", offset, name, err)
+	}
+	return p, nil
+}
+
+// Offset returns the sequence number of the last change delivered.
+func (p *Pipeline) Offset() int64 {
+	return p.offset.Load()
+}
+
+// Run delivers changes until ctx is done, retrying failures with backoff.
+func (p *Pipeline) Run(ctx context.Context) {
+	const minBackoff, maxBackoff = 100 * time.Millisecond, 30 * time.Second
+
+	backoff := minBackoff
+	for {
+		delivered, err := p.deliver(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		wait := p.pollInterval
+		switch {
+		case err != nil:
+			p.logger.WarnContext(ctx, "failed to deliver changes", "sink", p.name, "error", err, "retry_in", backoff)
+			wait = backoff
+			backoff = min(2*backoff, maxBackoff)
+		case delivered == p.batchSize:
+			backoff = minBackoff
+			continue // more changes may be waiting
+		default:
+			backoff = minBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// deliver delivers the next batch of changes, returning how many it
+// delivered.
+func (p *Pipeline) deliver(ctx context.Context) (int, error) {
+	changes, err := p.log.Changes(ctx, p.offset.Load(), p.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read changes: %w", err)
+	}
+	if len(changes) == 0 {
+		return 0, nil
+	}
+
+	if err := p.sink.Deliver(ctx, changes); err != nil {
+		return 0, err
+	}
+	offset := changes[len(changes)-1].Seq
+	p.offset.Store(offset)
+
+	// A stale offset only causes changes to be delivered again
+	if err := writeOffset(p.offsets, offset); err != nil {
+		p.logger.WarnContext(ctx, "failed to record delivery offset", "sink", p.name, "error", err)
+	}
+	return len(changes), nil
+}
+
+// readOffset reads an offset file, returning zero if there's none.
+func readOffset(path string) (int64, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read offset: %w", err)
+	}
+
+	offset, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read offset: invalid offset file %s", path)
+	}
+	return offset, nil
+}
+
+// writeOffset replaces an offset file, syncing it so the offset survives a
+// crash.
+func writeOffset(path string, offset int64) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(strconv.FormatInt(offset, 10) + "\n")
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir syncs a directory, persisting the files created or renamed in it.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
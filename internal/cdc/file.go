@@ -0,0 +1,130 @@
+package cdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+// DefaultFileSize is the size after which FileSink moves on to a new file
+// unless configured otherwise.
+const DefaultFileSize = 64 << 20
+
+// changeJSON is the JSON encoding of a change written by FileSink and
+// WebhookSink.
+type changeJSON struct {
+	Seq         int64     `json:"seq"`
+	Namespace   string    `json:"namespace,omitempty"`
+	Key         int64     `json:"key"`
+	Value       string    `json:"value"`
+	Deleted     bool      `json:"deleted"`
+	CommittedAt time.Time `json:"committed_at"`
+}
+
+func encodeChange(change sqlbackend.Change) changeJSON {
+	return changeJSON{
+		Seq:         change.Seq,
+		Namespace:   change.Namespace,
+		Key:         change.Key,
+		Value:       change.Value,
+		Deleted:     change.Deleted,
+		CommittedAt: change.CommittedAt.UTC(),
+	}
+}
+
+// FileSink writes changes to JSONL files in a directory, one change per line.
+// Files are named after the sequence number of their first change, so they
+// sort in log order, and a new one is started once the current one reaches
+// the maximum size. Consumers may remove files other than the newest.
+type FileSink struct {
+	dir     string
+	maxSize int64
+
+	f    *os.File
+	size int64
+}
+
+// NewFileSink returns a sink writing to dir, starting a new file after
+// maxSize bytes, or DefaultFileSize if zero.
+func NewFileSink(dir string, maxSize int64) (*FileSink, error) {
+	if maxSize == 0 {
+		maxSize = DefaultFileSize
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create CDC directory: %w", err)
+	}
+	return &FileSink{dir: dir, maxSize: maxSize}, nil
+}
+
+// Deliver appends changes to the current file and syncs it.
+func (s *FileSink) Deliver(_ context.Context, changes []sqlbackend.Change) error {
+	if s.f == nil || s.size >= s.maxSize {
+		if err := s.rotate(changes[0].Seq); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, change := range changes {
+		if err := enc.Encode(encodeChange(change)); err != nil {
+			return err
+		}
+	}
+
+	// A partial write leaves a torn line, which redelivery follows with
+	// whole ones
+	n, err := s.f.Write(buf.Bytes())
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write changes: %w", err)
+	}
+	if err := s.f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync changes: %w", err)
+	}
+	return nil
+}
+
+// rotate closes the current file and opens the one starting at seq.
+func (s *FileSink) rotate(seq int64) error {
+	if s.f != nil {
+		if err := s.f.Close(); err != nil {
+			return err
+		}
+		s.f = nil
+	}
+
+	// Changes delivered again after a restart may start at a file's first
+	// change, in which case they are appended to it
+	path := filepath.Join(s.dir, fmt.Sprintf("changes-%020d.jsonl", seq))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create CDC file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if err := syncDir(s.dir); err != nil {
+		f.Close()
+		return err
+	}
+
+	s.f, s.size = f, info.Size()
+	return nil
+}
+
+// Close closes the current file.
+func (s *FileSink) Close() error {
+	if s.f == nil {
+		return nil
+	}
+	return s.f.Close()
+}
@@ -0,0 +1,55 @@
+package cdc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	cdcpb "github.com/dynoinc/gh-go/proto/cdc/v1"
+)
+
+// GRPCSink delivers changes to an endpoint implementing
+// cdc.v1.ChangeSinkService.
+type GRPCSink struct {
+	conn   *grpc.ClientConn
+	client cdcpb.ChangeSinkServiceClient
+}
+
+// NewGRPCSink returns a sink delivering to target. The connection uses
+// insecure credentials unless opts override them.
+func NewGRPCSink(target string, opts ...grpc.DialOption) (*GRPCSink, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to CDC endpoint: %w", err)
+	}
+	return &GRPCSink{conn: conn, client: cdcpb.NewChangeSinkServiceClient(conn)}, nil
+}
+
+// Deliver sends changes in a single Deliver call.
+func (s *GRPCSink) Deliver(ctx context.Context, changes []sqlbackend.Change) error {
+	batch := make([]*cdcpb.Change, 0, len(changes))
+	for _, change := range changes {
+		batch = append(batch, cdcpb.Change_builder{
+			Seq:         change.Seq,
+			Namespace:   change.Namespace,
+			Key:         change.Key,
+			Value:       change.Value,
+			Deleted:     change.Deleted,
+			CommittedAt: change.CommittedAt.UnixNano(),
+		}.Build())
+	}
+
+	if _, err := s.client.Deliver(ctx, cdcpb.DeliverRequest_builder{Changes: batch}.Build()); err != nil {
+		return fmt.Errorf("failed to deliver changes: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection.
+func (s *GRPCSink) Close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,90 @@
+package cdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+const (
+	// webhookTimeout bounds each webhook request.
+	webhookTimeout = 30 * time.Second
+	// FirstSeqHeader and LastSeqHeader carry the sequence numbers of the
+	// first and last change of a webhook request, letting receivers skip
+	// batches delivered again.
+	FirstSeqHeader = "X-Change-First-Seq"
+	LastSeqHeader  = "X-Change-Last-Seq"
+)
+
+// WebhookSink posts changes as JSON to a URL, as {"changes": [...]}. Any 2xx
+// response acknowledges them; other responses and failed requests are
+// retried by the pipeline.
+type WebhookSink struct {
+	url    string
+	token  func() string
+	client *http.Client
+}
+
+// NewWebhookSink returns a sink posting to url. If token is not nil and
+// returns a token, requests present it as a bearer token.
+func NewWebhookSink(url string, token func() string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Deliver posts changes in a single request.
+func (s *WebhookSink) Deliver(ctx context.Context, changes []sqlbackend.Change) error {
+	body := struct {
+		Changes []changeJSON `json:"changes"`
+	}{Changes: make([]changeJSON, 0, len(changes))}
+	for _, change := range changes {
+		body.Changes = append(body.Changes, encodeChange(change))
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(FirstSeqHeader, strconv.FormatInt(changes[0].Seq, 10))
+	req.Header.Set(LastSeqHeader, strconv.FormatInt(changes[len(changes)-1].Seq, 10))
+	if s.token != nil {
+		if token := s.token(); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post changes: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to post changes: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// Close closes idle connections.
+func (s *WebhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
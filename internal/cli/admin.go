@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	adminpb "github.com/dynoinc/gh-go/proto/admin/v1"
+)
+
+// connectAdmin connects to the admin listener, which serves gRPC with the
+// admin token.
+func (e *env) connectAdmin() (*grpc.ClientConn, error) {
+	creds, err := e.transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+	return grpc.NewClient(e.adminAddr, grpc.WithTransportCredentials(creds))
+}
+
+// withAdminToken adds the admin token to calls to the admin listener.
+func (e *env) withAdminToken(ctx context.Context) context.Context {
+	if e.adminToken == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+e.adminToken)
+}
+
+type backupRecord struct {
+	Path          string    `json:"path"`
+	SHA256        string    `json:"sha256"`
+	Size          int64     `json:"size"`
+	SchemaVersion uint64    `json:"schema_version"`
+	LastSeq       int64     `json:"last_seq"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (r backupRecord) row() []string {
+	return []string{
+		r.Path,
+		r.SHA256,
+		strconv.FormatInt(r.Size, 10),
+		strconv.FormatUint(r.SchemaVersion, 10),
+		strconv.FormatInt(r.LastSeq, 10),
+		r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (e *env) writeBackupInfo(info *adminpb.BackupInfo) error {
+	return e.writeBackupRecord(backupRecord{
+		Path:          info.GetPath(),
+		SHA256:        info.GetSha256(),
+		Size:          info.GetSizeBytes(),
+		SchemaVersion: info.GetSchemaVersion(),
+		LastSeq:       info.GetLastSeq(),
+		CreatedAt:     time.Unix(0, info.GetCreatedAt()).UTC(),
+	})
+}
+
+func (e *env) writeBackupRecord(record backupRecord) error {
+	w := e.newWriter([]string{"PATH", "SHA256", "SIZE", "SCHEMA", "LAST_SEQ", "CREATED_AT"})
+	w.write(record)
+	return w.flush()
+}
+
+func (e *env) backup(ctx context.Context, conn *grpc.ClientConn, args []string) error {
+	if len(args) != 1 {
+		return usagef("backup takes exactly one path")
+	}
+
+	resp, err := adminpb.NewAdminServiceClient(conn).Backup(e.withAdminToken(ctx), adminpb.BackupRequest_builder{Path: args[0]}.Build())
+	if err != nil {
+		return err
+	}
+	return e.writeBackupInfo(resp.GetInfo())
+}
+
+func (e *env) restore(ctx context.Context, conn *grpc.ClientConn, args []string) error {
+	if len(args) != 1 {
+		return usagef("restore takes exactly one path")
+	}
+
+	resp, err := adminpb.NewAdminServiceClient(conn).Restore(e.withAdminToken(ctx), adminpb.RestoreRequest_builder{Path: args[0]}.Build())
+	if err != nil {
+		return err
+	}
+	return e.writeBackupInfo(resp.GetInfo())
+}
+
+// recover runs locally, on a copy of a backup and the journal directory.
+func (e *env) recover(ctx context.Context, args []string) error {
+	fs := e.newFlagSet("recover")
+	backup := fs.String("backup", "", "backup to recover from")
+	journal := fs.String("journal", "", "journal directory")
+	until := fs.String("until", "", "recover changes committed up to this RFC 3339 time")
+	revision := fs.Int64("revision", 0, "recover changes up to this sequence number")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("recover takes exactly one output path")
+	}
+	if *backup == "" || *journal == "" {
+		return usagef("recover requires -backup and -journal")
+	}
+
+	target := sqlbackend.RecoveryTarget{Seq: *revision}
+	if *until != "" {
+		t, err := time.Parse(time.RFC3339Nano, *until)
+		if err != nil {
+			return usagef("invalid -until %q: must be an RFC 3339 time", *until)
+		}
+		target.Time = t
+	}
+
+	info, err := sqlbackend.Recover(ctx, *backup, *journal, fs.Arg(0), target)
+	if err != nil {
+		return err
+	}
+	return e.writeBackupRecord(backupRecord{
+		Path:          fs.Arg(0),
+		SHA256:        info.SHA256,
+		Size:          info.Size,
+		SchemaVersion: info.SchemaVersion,
+		LastSeq:       info.LastSeq,
+		CreatedAt:     info.CreatedAt.UTC(),
+	})
+}
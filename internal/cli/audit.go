@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/dynoinc/gh-go/internal/audit"
+	adminpb "github.com/dynoinc/gh-go/proto/admin/v1"
+)
+
+type auditRecord struct {
+	Seq       int64     `json:"seq"`
+	Time      time.Time `json:"time"`
+	Principal string    `json:"principal"`
+	Peer      string    `json:"peer"`
+	Via       string    `json:"via,omitempty"`
+	Method    string    `json:"method"`
+	Namespace string    `json:"namespace,omitempty"`
+	Key       int64     `json:"key"`
+	OldHash   string    `json:"old_hash,omitempty"`
+	NewHash   string    `json:"new_hash,omitempty"`
+	TraceID   string    `json:"trace_id,omitempty"`
+	Hash      string    `json:"hash"`
+}
+
+func (r auditRecord) row() []string {
+	return []string{
+		strconv.FormatInt(r.Seq, 10),
+		r.Time.Format(time.RFC3339Nano),
+		r.Principal,
+		r.Peer,
+		r.Method,
+		r.Namespace,
+		strconv.FormatInt(r.Key, 10),
+		shortHash(r.OldHash),
+		shortHash(r.NewHash),
+	}
+}
+
+// shortHash abbreviates a hash for tables; JSON output has it in full.
+func shortHash(hash string) string {
+	if hash == "" {
+		return "-"
+	}
+	return hash[:min(len(hash), 12)]
+}
+
+type auditHeadRecord struct {
+	Entries int64  `json:"entries"`
+	Hash    string `json:"head_hash"`
+}
+
+func (r auditHeadRecord) row() []string {
+	return []string{strconv.FormatInt(r.Entries, 10), r.Hash}
+}
+
+func (e *env) queryAudit(ctx context.Context, conn *grpc.ClientConn, args []string) error {
+	fs := e.newFlagSet("audit")
+	namespace := fs.String("namespace", "", "only entries of keys in this namespace, empty for the default one")
+	key := fs.String("key", "", "only entries of this key")
+	principal := fs.String("principal", "", "only entries of this principal")
+	method := fs.String("method", "", "only entries of this full gRPC method name")
+	since := fs.String("since", "", "only entries at or after this RFC 3339 time")
+	until := fs.String("until", "", "only entries at or before this RFC 3339 time")
+	after := fs.Int64("after", 0, "only entries following this sequence number")
+	limit := fs.Int64("limit", 0, "maximum number of entries, 0 for the server's default")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usagef("audit takes no arguments")
+	}
+
+	req := adminpb.QueryAuditRequest_builder{
+		Principal: *principal,
+		Method:    *method,
+		AfterSeq:  *after,
+		Limit:     *limit,
+	}.Build()
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "namespace" {
+			req.SetNamespace(*namespace)
+		}
+	})
+	if *key != "" {
+		k, err := parseKey(*key)
+		if err != nil {
+			return err
+		}
+		req.SetKey(k)
+	}
+	for _, bound := range []struct {
+		name  string
+		value string
+		set   func(int64)
+	}{{"since", *since, req.SetSince}, {"until", *until, req.SetUntil}} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, bound.value)
+		if err != nil {
+			return usagef("invalid -%s %q: must be an RFC 3339 time", bound.name, bound.value)
+		}
+		bound.set(t.UnixNano())
+	}
+
+	resp, err := adminpb.NewAuditServiceClient(conn).QueryAudit(e.withAdminToken(ctx), req)
+	if err != nil {
+		return err
+	}
+
+	w := e.newWriter([]string{"SEQ", "TIME", "PRINCIPAL", "PEER", "METHOD", "NAMESPACE", "KEY", "OLD_HASH", "NEW_HASH"})
+	for _, entry := range resp.GetEntries() {
+		w.write(auditRecord{
+			Seq:       entry.GetSeq(),
+			Time:      time.Unix(0, entry.GetTime()).UTC(),
+			Principal: entry.GetPrincipal(),
+			Peer:      entry.GetPeer(),
+			Via:       entry.GetVia(),
+			Method:    entry.GetMethod(),
+			Namespace: entry.GetNamespace(),
+			Key:       entry.GetKey(),
+			OldHash:   entry.GetOldHash(),
+			NewHash:   entry.GetNewHash(),
+			TraceID:   entry.GetTraceId(),
+			Hash:      entry.GetHash(),
+		})
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stderr, "head: entry %d, hash %s\n", resp.GetHeadSeq(), resp.GetHeadHash())
+	return nil
+}
+
+// verifyAudit runs locally, on the audit log file.
+func (e *env) verifyAudit(_ context.Context, args []string) error {
+	fs := e.newFlagSet("audit-verify")
+	key := fs.String("key", os.Getenv("GHGO_AUDIT_KEY"), "key of the log's hashes (env GHGO_AUDIT_KEY)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("audit-verify takes exactly one file")
+	}
+
+	last, err := audit.Verify(fs.Arg(0), []byte(*key))
+	if err != nil {
+		return err
+	}
+
+	w := e.newWriter([]string{"ENTRIES", "HEAD_HASH"})
+	w.write(auditHeadRecord{Entries: last.Seq, Hash: last.Hash})
+	return w.flush()
+}
@@ -0,0 +1,441 @@
+// Package cli implements the ghgo command-line client.
+//
+// Exit codes are shell friendly: 0 on success, 1 when a key is not found and
+// 2 on any other error, including usage errors.
+package cli
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/client"
+)
+
+const (
+	exitOK       = 0
+	exitNotFound = 1
+	exitError    = 2
+)
+
+var (
+	// errNotFound marks errors that exit with exitNotFound.
+	errNotFound = errors.New("not found")
+	// errReported marks errors already reported by the flag package.
+	errReported = errors.New("invalid flags")
+)
+
+// usageError marks errors caused by invalid arguments.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+const usage = `Usage: ghgo [flags] <command> [args]
+
+Commands:
+  get KEY                  print the value of a key
+  put KEY VALUE            store a value; VALUE "-" reads it from stdin
+  delete KEY               delete a key
+  scan [flags]             print entries in key order
+  watch [KEY...]           print changes as they are committed
+  import [flags] [FILE]    store entries read from FILE or stdin
+  export [flags] [FILE]    write entries to FILE or stdout
+  backup PATH              back up the database to PATH on the server
+  restore PATH             replace all data with the backup at PATH on the server
+  recover [flags] OUTPUT   write a backup to OUTPUT recovered from a backup and
+                           the journal, without connecting to the server
+  audit [flags]            print audit log entries of the server's writes
+  audit-verify [-key KEY] FILE
+                           verify the hash chain of an audit log file, without
+                           connecting to the server
+  namespace list           print the server's namespaces and their usage
+  namespace get NAME       print a namespace
+  namespace create [flags] NAME
+                           create a namespace, optionally with a quota
+  namespace update [flags] NAME
+                           change the quota of a namespace
+  namespace delete NAME    delete an empty namespace
+
+backup, restore, audit and namespace use the admin listener at -admin-addr.
+The other commands read and write the namespace given by -namespace.
+
+
+Flags:
+`
+
+// env holds the streams and connection settings shared by all commands.
+type env struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+
+	addr          string
+	token         string
+	adminAddr     string
+	adminToken    string
+	useTLS        bool
+	tlsCA         string
+	tlsServerName string
+	namespace     string
+	timeout       time.Duration
+	output        string
+}
+
+// Run runs the CLI with the given arguments, excluding the program name, and
+// returns its exit code.
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	e := &env{stdin: stdin, stdout: stdout, stderr: stderr}
+
+	fs := flag.NewFlagSet("ghgo", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&e.addr, "addr", envOr("GHGO_ADDR", "localhost:5051"), "frontend address (env GHGO_ADDR)")
+	fs.StringVar(&e.token, "token", os.Getenv("GHGO_TOKEN"), "bearer token (env GHGO_TOKEN)")
+	fs.StringVar(&e.adminAddr, "admin-addr", envOr("GHGO_ADMIN_ADDR", "localhost:5052"), "admin listener address (env GHGO_ADMIN_ADDR)")
+	fs.StringVar(&e.adminToken, "admin-token", os.Getenv("GHGO_ADMIN_TOKEN"), "admin bearer token (env GHGO_ADMIN_TOKEN)")
+	fs.BoolVar(&e.useTLS, "tls", false, "connect using TLS")
+	fs.StringVar(&e.tlsCA, "tls-ca", "", "PEM file of CA certificates to verify the server with, implies -tls")
+	fs.StringVar(&e.tlsServerName, "tls-server-name", "", "server name to verify, if different from the address")
+	fs.StringVar(&e.namespace, "namespace", os.Getenv("GHGO_NAMESPACE"), "namespace of the keys, empty for the default one (env GHGO_NAMESPACE)")
+	fs.DurationVar(&e.timeout, "timeout", 10*time.Second, "timeout of get, put and delete")
+	fs.StringVar(&e.output, "o", "table", `output format, "table" or "json"`)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitError
+	}
+
+	err := e.run(ctx, fs.Args())
+	switch {
+	case err == nil || errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errReported):
+		return exitError
+	case errors.Is(err, errNotFound):
+		fmt.Fprintln(stderr, "ghgo:", err)
+		return exitNotFound
+	default:
+		fmt.Fprintln(stderr, "ghgo:", err)
+		var usageErr *usageError
+		if errors.As(err, &usageErr) {
+			fmt.Fprintln(stderr, "Run 'ghgo -h' for usage.")
+		}
+		return exitError
+	}
+}
+
+func (e *env) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usagef("no command given")
+	}
+	if e.output != "table" && e.output != "json" {
+		return usagef(`output format must be "table" or "json", got %q`, e.output)
+	}
+
+	localCommands := map[string]func(context.Context, []string) error{
+		"recover":      e.recover,
+		"audit-verify": e.verifyAudit,
+	}
+	if cmd, ok := localCommands[args[0]]; ok {
+		return cmd(ctx, args[1:])
+	}
+
+	adminCommands := map[string]func(context.Context, *grpc.ClientConn, []string) error{
+		"backup":    e.backup,
+		"restore":   e.restore,
+		"audit":     e.queryAudit,
+		"namespace": e.namespaceCommand,
+	}
+	if cmd, ok := adminCommands[args[0]]; ok {
+		conn, err := e.connectAdmin()
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		return cmd(ctx, conn, args[1:])
+	}
+
+	commands := map[string]func(context.Context, *client.Client, []string) error{
+		"get":    e.get,
+		"put":    e.put,
+		"delete": e.delete,
+		"scan":   e.scan,
+		"watch":  e.watch,
+		"import": e.importEntries,
+		"export": e.exportEntries,
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return usagef("unknown command %q", args[0])
+	}
+
+	c, err := e.connect()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	return cmd(ctx, c, args[1:])
+}
+
+func (e *env) connect() (*client.Client, error) {
+	opts := []client.Option{client.WithTarget(e.addr)}
+
+	creds, err := e.transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+	if creds != nil {
+		opts = append(opts, client.WithTransportCredentials(creds))
+	}
+
+	if e.token != "" {
+		opts = append(opts, client.WithBearerToken(e.token))
+	}
+	if e.namespace != "" {
+		opts = append(opts, client.WithNamespace(e.namespace))
+	}
+
+	return client.New(opts...)
+}
+
+// transportCredentials returns the TLS credentials selected by the flags, or
+// nil if TLS is off.
+func (e *env) transportCredentials() (credentials.TransportCredentials, error) {
+	if !e.useTLS && e.tlsCA == "" {
+		return nil, nil
+	}
+
+	config := &tls.Config{ServerName: e.tlsServerName}
+	if e.tlsCA != "" {
+		pem, err := os.ReadFile(e.tlsCA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", e.tlsCA)
+		}
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// withTimeout bounds single calls by the -timeout flag.
+func (e *env) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if e.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, e.timeout)
+}
+
+func (e *env) get(ctx context.Context, c *client.Client, args []string) error {
+	if len(args) != 1 {
+		return usagef("get takes exactly one key")
+	}
+	key, err := parseKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := e.withTimeout(ctx)
+	defer cancel()
+
+	value, err := c.Get(ctx, key)
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("key %d: %w", key, errNotFound)
+	}
+	if err != nil {
+		return err
+	}
+
+	w := e.newWriter([]string{"KEY", "VALUE"})
+	w.write(entryRecord{Key: key, Value: value})
+	return w.flush()
+}
+
+func (e *env) put(ctx context.Context, c *client.Client, args []string) error {
+	if len(args) != 2 {
+		return usagef("put takes a key and a value")
+	}
+	key, err := parseKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	value := args[1]
+	if value == "-" {
+		data, err := io.ReadAll(e.stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read value: %w", err)
+		}
+		value = string(data)
+	}
+
+	ctx, cancel := e.withTimeout(ctx)
+	defer cancel()
+
+	return c.Put(ctx, key, value)
+}
+
+func (e *env) delete(ctx context.Context, c *client.Client, args []string) error {
+	if len(args) != 1 {
+		return usagef("delete takes exactly one key")
+	}
+	key, err := parseKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := e.withTimeout(ctx)
+	defer cancel()
+
+	found, err := c.Delete(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("key %d: %w", key, errNotFound)
+	}
+	return nil
+}
+
+// scanFlags registers the flags selecting a key range.
+func scanFlags(fs *flag.FlagSet) func() ([]client.ScanOption, error) {
+	from := fs.String("from", "", "first key, inclusive")
+	to := fs.String("to", "", "key to stop before")
+	limit := fs.Int("limit", 0, "maximum number of entries, 0 for all")
+
+	return func() ([]client.ScanOption, error) {
+		var opts []client.ScanOption
+		if *from != "" {
+			key, err := parseKey(*from)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, client.ScanFrom(key))
+		}
+		if *to != "" {
+			key, err := parseKey(*to)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, client.ScanTo(key))
+		}
+		if *limit > 0 {
+			opts = append(opts, client.ScanLimit(*limit))
+		}
+		return opts, nil
+	}
+}
+
+func (e *env) scan(ctx context.Context, c *client.Client, args []string) error {
+	fs := e.newFlagSet("scan")
+	scanOpts := scanFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("scan takes no arguments")
+	}
+	opts, err := scanOpts()
+	if err != nil {
+		return err
+	}
+
+	w := e.newWriter([]string{"KEY", "VALUE"})
+	for entry, err := range c.Scan(ctx, opts...) {
+		if err != nil {
+			w.flush()
+			return err
+		}
+		w.write(entryRecord{Key: entry.Key, Value: entry.Value})
+	}
+	return w.flush()
+}
+
+func (e *env) watch(ctx context.Context, c *client.Client, args []string) error {
+	keys := make([]int64, 0, len(args))
+	for _, arg := range args {
+		key, err := parseKey(arg)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+
+	// Changes are printed as they arrive, so the table isn't aligned
+	w := e.newWriter([]string{"KEY", "VALUE", "DELETED"})
+	for change, err := range c.Watch(ctx, keys...) {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		w.write(changeRecord{Key: change.Key, Value: change.Value, Deleted: change.Deleted})
+		if err := w.flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseKey parses a key argument.
+func parseKey(s string) (int64, error) {
+	key, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, usagef("invalid key %q: must be a 64-bit integer", s)
+	}
+	return key, nil
+}
+
+func (e *env) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	return fs
+}
+
+// parseFlags parses the flags of a command, which the flag package reports
+// errors for itself.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errReported
+	}
+	return nil
+}
+
+func envOr(name, fallback string) string {
+	if value, ok := os.LookupEnv(name); ok {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+
+	adminpb "github.com/dynoinc/gh-go/proto/admin/v1"
+)
+
+type namespaceRecord struct {
+	Name      string    `json:"name"`
+	MaxKeys   int64     `json:"max_keys"`
+	MaxBytes  int64     `json:"max_bytes"`
+	Keys      int64     `json:"keys"`
+	Bytes     int64     `json:"bytes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (r namespaceRecord) row() []string {
+	name := r.Name
+	if name == "" {
+		name = "(default)"
+	}
+	return []string{
+		name,
+		strconv.FormatInt(r.Keys, 10),
+		limit(r.MaxKeys),
+		strconv.FormatInt(r.Bytes, 10),
+		limit(r.MaxBytes),
+		r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// limit formats a quota limit for tables, where zero is unlimited.
+func limit(n int64) string {
+	if n == 0 {
+		return "-"
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+func (e *env) writeNamespaces(namespaces ...*adminpb.Namespace) error {
+	w := e.newWriter([]string{"NAME", "KEYS", "MAX_KEYS", "BYTES", "MAX_BYTES", "CREATED_AT"})
+	for _, ns := range namespaces {
+		w.write(namespaceRecord{
+			Name:      ns.GetName(),
+			MaxKeys:   ns.GetMaxKeys(),
+			MaxBytes:  ns.GetMaxBytes(),
+			Keys:      ns.GetKeys(),
+			Bytes:     ns.GetBytes(),
+			CreatedAt: time.Unix(0, ns.GetCreatedAt()).UTC(),
+		})
+	}
+	return w.flush()
+}
+
+// namespaceCommand runs the namespace subcommands.
+func (e *env) namespaceCommand(ctx context.Context, conn *grpc.ClientConn, args []string) error {
+	if len(args) == 0 {
+		return usagef("namespace takes a subcommand: list, get, create, update or delete")
+	}
+	client := adminpb.NewNamespaceServiceClient(conn)
+	ctx = e.withAdminToken(ctx)
+
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "list":
+		if len(args) > 0 {
+			return usagef("namespace list takes no arguments")
+		}
+		resp, err := client.ListNamespaces(ctx, &adminpb.ListNamespacesRequest{})
+		if err != nil {
+			return err
+		}
+		return e.writeNamespaces(resp.GetNamespaces()...)
+
+	case "get":
+		if len(args) != 1 {
+			return usagef("namespace get takes exactly one name")
+		}
+		resp, err := client.GetNamespace(ctx, adminpb.GetNamespaceRequest_builder{Name: args[0]}.Build())
+		if err != nil {
+			return err
+		}
+		return e.writeNamespaces(resp.GetNamespace())
+
+	case "create":
+		fs := e.newFlagSet("namespace create")
+		maxKeys := fs.Int64("max-keys", 0, "maximum number of keys, 0 for no limit")
+		maxBytes := fs.Int64("max-bytes", 0, "maximum total size of values, 0 for no limit")
+		if err := parseFlags(fs, args); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return usagef("namespace create takes exactly one name")
+		}
+		resp, err := client.CreateNamespace(ctx, adminpb.CreateNamespaceRequest_builder{
+			Name:     fs.Arg(0),
+			MaxKeys:  *maxKeys,
+			MaxBytes: *maxBytes,
+		}.Build())
+		if err != nil {
+			return err
+		}
+		return e.writeNamespaces(resp.GetNamespace())
+
+	case "update":
+		fs := e.newFlagSet("namespace update")
+		maxKeys := fs.Int64("max-keys", 0, "maximum number of keys, 0 for no limit")
+		maxBytes := fs.Int64("max-bytes", 0, "maximum total size of values, 0 for no limit")
+		if err := parseFlags(fs, args); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return usagef("namespace update takes exactly one name")
+		}
+
+		// Limits not given are kept
+		current, err := client.GetNamespace(ctx, adminpb.GetNamespaceRequest_builder{Name: fs.Arg(0)}.Build())
+		if err != nil {
+			return err
+		}
+		req := adminpb.UpdateNamespaceRequest_builder{
+			Name:     fs.Arg(0),
+			MaxKeys:  current.GetNamespace().GetMaxKeys(),
+			MaxBytes: current.GetNamespace().GetMaxBytes(),
+		}.Build()
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "max-keys":
+				req.SetMaxKeys(*maxKeys)
+			case "max-bytes":
+				req.SetMaxBytes(*maxBytes)
+			}
+		})
+		resp, err := client.UpdateNamespace(ctx, req)
+		if err != nil {
+			return err
+		}
+		return e.writeNamespaces(resp.GetNamespace())
+
+	case "delete":
+		if len(args) != 1 {
+			return usagef("namespace delete takes exactly one name")
+		}
+		_, err := client.DeleteNamespace(ctx, adminpb.DeleteNamespaceRequest_builder{Name: args[0]}.Build())
+		return err
+
+	default:
+		return usagef("unknown namespace subcommand %q", cmd)
+	}
+}
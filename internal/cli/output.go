@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// record is a row of output.
+type record interface {
+	row() []string
+}
+
+type entryRecord struct {
+	Key   int64  `json:"key"`
+	Value string `json:"value"`
+}
+
+func (r entryRecord) row() []string {
+	return []string{strconv.FormatInt(r.Key, 10), r.Value}
+}
+
+type changeRecord struct {
+	Key     int64  `json:"key"`
+	Value   string `json:"value,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+func (r changeRecord) row() []string {
+	return []string{strconv.FormatInt(r.Key, 10), r.Value, strconv.FormatBool(r.Deleted)}
+}
+
+// writer prints records as an aligned table or as JSON lines.
+type writer struct {
+	json   *json.Encoder
+	table  *tabwriter.Writer
+	header []string // printed before the first row
+	err    error
+}
+
+func (e *env) newWriter(header []string) *writer {
+	if e.output == "json" {
+		return &writer{json: json.NewEncoder(e.stdout)}
+	}
+	return &writer{
+		table:  tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0),
+		header: header,
+	}
+}
+
+func (w *writer) write(r record) {
+	if w.err != nil {
+		return
+	}
+
+	if w.json != nil {
+		w.err = w.json.Encode(r)
+		return
+	}
+
+	if w.header != nil {
+		w.writeRow(w.header)
+		w.header = nil
+	}
+	w.writeRow(r.row())
+}
+
+func (w *writer) writeRow(columns []string) {
+	// Tabs and newlines in values would break the table
+	escaped := make([]string, len(columns))
+	for i, column := range columns {
+		escaped[i] = strings.NewReplacer("\t", `\t`, "\n", `\n`).Replace(column)
+	}
+	if _, err := fmt.Fprintln(w.table, strings.Join(escaped, "\t")); err != nil {
+		w.err = err
+	}
+}
+
+// flush writes out buffered rows and returns the first write error.
+func (w *writer) flush() error {
+	if w.table != nil && w.err == nil {
+		w.err = w.table.Flush()
+	}
+	return w.err
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dynoinc/gh-go/client"
+)
+
+// formats maps the values of the -format flag to formats.
+var formats = map[string]client.Format{
+	"jsonl": client.FormatJSONL,
+	"csv":   client.FormatCSV,
+	"proto": client.FormatProto,
+}
+
+func parseFormat(name string) (client.Format, error) {
+	format, ok := formats[name]
+	if !ok {
+		return 0, usagef(`format must be "jsonl", "csv" or "proto", got %q`, name)
+	}
+	return format, nil
+}
+
+func (e *env) importEntries(ctx context.Context, c *client.Client, args []string) error {
+	fs := e.newFlagSet("import")
+	formatName := fs.String("format", "jsonl", `input format, "jsonl", "csv" or "proto"`)
+	batchSize := fs.Int("batch", 0, "entries stored per transaction, 0 for the server's default")
+	dryRun := fs.Bool("dry-run", false, "validate the entries without storing them")
+	progress := fs.Bool("progress", false, "report progress on stderr")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return usagef("import takes at most one file")
+	}
+	if *batchSize < 0 {
+		return usagef("batch size must not be negative, got %d", *batchSize)
+	}
+	format, err := parseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	r := e.stdin
+	if name := fs.Arg(0); name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	opts := []client.ImportOption{client.ImportChunkSize(*batchSize)}
+	if *dryRun {
+		opts = append(opts, client.ImportDryRun())
+	}
+	if *progress {
+		opts = append(opts, client.ImportProgress(func(p client.Progress) {
+			fmt.Fprintf(e.stderr, "sent %d bytes\n", p.Bytes)
+		}))
+	}
+
+	result, err := c.Import(ctx, r, format, opts...)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		fmt.Fprintf(e.stderr, "validated %d entries\n", result.Entries)
+	} else {
+		fmt.Fprintf(e.stderr, "imported %d entries\n", result.Entries)
+	}
+	return nil
+}
+
+func (e *env) exportEntries(ctx context.Context, c *client.Client, args []string) error {
+	fs := e.newFlagSet("export")
+	formatName := fs.String("format", "jsonl", `output format, "jsonl", "csv" or "proto"`)
+	from := fs.String("from", "", "first key, inclusive")
+	to := fs.String("to", "", "key to stop before")
+	chunkSize := fs.Int("chunk", 0, "entries per message, 0 for the server's default")
+	appendFile := fs.Bool("append", false, "append to FILE, to resume an interrupted export with -from")
+	progress := fs.Bool("progress", false, "report progress on stderr")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return usagef("export takes at most one file")
+	}
+	format, err := parseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	opts := []client.ExportOption{client.ExportChunkSize(*chunkSize)}
+	if *from != "" {
+		key, err := parseKey(*from)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, client.ExportFrom(key))
+	}
+	if *to != "" {
+		key, err := parseKey(*to)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, client.ExportTo(key))
+	}
+	if *progress {
+		opts = append(opts, client.ExportProgress(func(p client.Progress) {
+			fmt.Fprintf(e.stderr, "exported %d entries up to key %d\n", p.Entries, p.LastKey)
+		}))
+	}
+
+	var w io.Writer = e.stdout
+	if name := fs.Arg(0); name != "" && name != "-" {
+		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if *appendFile {
+			flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+		}
+		f, err := os.OpenFile(name, flags, 0o666)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	result, err := c.Export(ctx, w, format, opts...)
+	if err != nil {
+		if result.Entries > 0 {
+			return fmt.Errorf("failed to export after %d entries, resume with -append -from %d: %w", result.Entries, result.LastKey+1, err)
+		}
+		return err
+	}
+
+	fmt.Fprintf(e.stderr, "exported %d entries\n", result.Entries)
+	return nil
+}
@@ -1,28 +1,386 @@
+// Package config loads the frontend configuration.
+//
+// Every setting can come from four layers, in increasing precedence: built-in
+// defaults, a YAML or TOML config file, environment variables (including a
+// .env file) and command-line flags. The file key, environment variable and
+// flag name of each setting are given by the yaml, envconfig and flag struct
+// tags of Config; config.example.yaml documents the full schema.
 package config
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"iter"
+	"log/slog"
+	"net"
+	"net/url"
 	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 )
 
-// Config holds application configuration
+// redacted replaces the value of secret fields in Redacted.
+const redacted = "REDACTED"
+
+// Config holds application configuration.
+//
+// Fields tagged `reload:"true"` are safe to change while the server runs and
+// are picked up by Watch; changes to other fields need a restart.
 type Config struct {
-	Port int `envconfig:"PORT" default:"5051"`
+	Port int `yaml:"port" toml:"port" envconfig:"PORT" flag:"port"`
+	// Listen lists addresses to serve on, as "tcp://host:port" or
+	// "unix:///path". Defaults to all interfaces on Port.
+	Listen []string `yaml:"listen" toml:"listen" envconfig:"LISTEN" flag:"listen"`
+	// SocketMode is the octal file mode of Unix sockets created for Listen.
+	SocketMode string `yaml:"socket_mode" toml:"socket_mode" envconfig:"SOCKET_MODE" flag:"socket-mode"`
+
+	// LogFormat is either "text" or "json".
+	LogFormat string     `yaml:"log_format" toml:"log_format" envconfig:"LOG_FORMAT" flag:"log-format"`
+	LogLevel  slog.Level `yaml:"log_level" toml:"log_level" envconfig:"LOG_LEVEL" flag:"log-level" reload:"true"`
+	// LogSampleRates maps methods or services to the fraction of calls logged,
+	// e.g. "/frontend.v1.FrontendService/Get:0.01,*:0.5".
+	LogSampleRates  map[string]float64 `yaml:"log_sample_rates" toml:"log_sample_rates" envconfig:"LOG_SAMPLE_RATES" flag:"log-sample-rates" reload:"true"`
+	LogPayloads     bool               `yaml:"log_payloads" toml:"log_payloads" envconfig:"LOG_PAYLOADS" flag:"log-payloads" reload:"true"`
+	LogRedactFields []string           `yaml:"log_redact_fields" toml:"log_redact_fields" envconfig:"LOG_REDACT_FIELDS" flag:"log-redact-fields" reload:"true"`
+
+	// AuthToken, when set, is required as a bearer token on every call.
+	AuthToken string `yaml:"auth_token" toml:"auth_token" envconfig:"AUTH_TOKEN" flag:"auth-token" secret:"true" reload:"true"`
+	// PeerToken is shared by a primary and its followers, or the nodes of a
+	// cluster, so writes forwarded between them are audited as their callers'.
+	PeerToken string `yaml:"peer_token" toml:"peer_token" envconfig:"PEER_TOKEN" flag:"peer-token" secret:"true" reload:"true"`
+	// RateLimit caps accepted calls per second across all methods; zero
+	// disables rate limiting.
+	RateLimit float64 `yaml:"rate_limit" toml:"rate_limit" envconfig:"RATE_LIMIT" flag:"rate-limit" reload:"true"`
+	RateBurst int     `yaml:"rate_burst" toml:"rate_burst" envconfig:"RATE_BURST" flag:"rate-burst" reload:"true"`
+
+	// ReplicateFrom runs the server as a follower of the primary at this gRPC
+	// target, presenting AuthToken to it.
+	ReplicateFrom string `yaml:"replicate_from" toml:"replicate_from" envconfig:"REPLICATE_FROM" flag:"replicate-from"`
+	// ForwardWrites makes a follower forward writes to the primary instead of
+	// rejecting them.
+	ForwardWrites bool `yaml:"forward_writes" toml:"forward_writes" envconfig:"FORWARD_WRITES" flag:"forward-writes"`
+
+	// RaftAddress runs the server as a node of a Raft cluster, whose other
+	// nodes reach its Raft transport at this host:port.
+	RaftAddress string `yaml:"raft_address" toml:"raft_address" envconfig:"RAFT_ADDRESS" flag:"raft-address"`
+	// RaftID identifies the node and is the gRPC address other nodes reach
+	// it at.
+	RaftID string `yaml:"raft_id" toml:"raft_id" envconfig:"RAFT_ID" flag:"raft-id"`
+	// RaftDir keeps the node's Raft log and snapshots.
+	RaftDir string `yaml:"raft_dir" toml:"raft_dir" envconfig:"RAFT_DIR" flag:"raft-dir"`
+	// RaftBootstrap forms a new cluster of the node and RaftPeers unless the
+	// node has state already.
+	RaftBootstrap bool `yaml:"raft_bootstrap" toml:"raft_bootstrap" envconfig:"RAFT_BOOTSTRAP" flag:"raft-bootstrap"`
+	// RaftPeers lists the other initial nodes to bootstrap as
+	// "raft_id=raft_address".
+	RaftPeers []string `yaml:"raft_peers" toml:"raft_peers" envconfig:"RAFT_PEERS" flag:"raft-peers"`
+
+	// JournalDir enables the write-ahead change journal in this directory,
+	// for point-in-time recovery.
+	JournalDir string `yaml:"journal_dir" toml:"journal_dir" envconfig:"JOURNAL_DIR" flag:"journal-dir"`
+	// JournalSegmentSize is the size in bytes after which the journal moves
+	// on to a new segment file.
+	JournalSegmentSize int `yaml:"journal_segment_size" toml:"journal_segment_size" envconfig:"JOURNAL_SEGMENT_SIZE" flag:"journal-segment-size"`
+
+	// HistoryVersions is the number of versions kept per key; zero keeps
+	// all.
+	HistoryVersions int `yaml:"history_versions" toml:"history_versions" envconfig:"HISTORY_VERSIONS" flag:"history-versions"`
+	// HistoryMaxAge is how long versions are kept; zero keeps them
+	// regardless of age. The latest version of a key is always kept.
+	HistoryMaxAge time.Duration `yaml:"history_max_age" toml:"history_max_age" envconfig:"HISTORY_MAX_AGE" flag:"history-max-age"`
+	// ChangeLogChanges is the number of changes kept in the change log for
+	// followers and change data capture; zero keeps all. Followers needing
+	// trimmed changes start over from a copy of the primary.
+	ChangeLogChanges int `yaml:"changelog_changes" toml:"changelog_changes" envconfig:"CHANGELOG_CHANGES" flag:"changelog-changes"`
+	// ChangeLogMaxAge is how long changes are kept; zero keeps them
+	// regardless of age. Changes not yet delivered by a sink are kept.
+	ChangeLogMaxAge time.Duration `yaml:"changelog_max_age" toml:"changelog_max_age" envconfig:"CHANGELOG_MAX_AGE" flag:"changelog-max-age"`
+	// HistoryCompactionInterval is how often old versions and changes are
+	// trimmed.
+	HistoryCompactionInterval time.Duration `yaml:"history_compaction_interval" toml:"history_compaction_interval" envconfig:"HISTORY_COMPACTION_INTERVAL" flag:"history-compaction-interval"`
+
+	// CDCOffsetDir keeps the delivery offsets of the change data capture
+	// sinks, which are enabled by CDCFileDir, CDCWebhookURL and CDCGRPCTarget
+	// and also require JournalDir.
+	CDCOffsetDir string `yaml:"cdc_offset_dir" toml:"cdc_offset_dir" envconfig:"CDC_OFFSET_DIR" flag:"cdc-offset-dir"`
+	// CDCFileDir enables writing changes to rotating JSONL files in this
+	// directory.
+	CDCFileDir string `yaml:"cdc_file_dir" toml:"cdc_file_dir" envconfig:"CDC_FILE_DIR" flag:"cdc-file-dir"`
+	// CDCFileMaxSize is the size in bytes after which a new CDC file is
+	// started.
+	CDCFileMaxSize int `yaml:"cdc_file_max_size" toml:"cdc_file_max_size" envconfig:"CDC_FILE_MAX_SIZE" flag:"cdc-file-max-size"`
+	// CDCWebhookURL enables posting changes to this URL.
+	CDCWebhookURL string `yaml:"cdc_webhook_url" toml:"cdc_webhook_url" envconfig:"CDC_WEBHOOK_URL" flag:"cdc-webhook-url"`
+	// CDCWebhookToken, when set, is presented to the webhook as a bearer
+	// token.
+	CDCWebhookToken string `yaml:"cdc_webhook_token" toml:"cdc_webhook_token" envconfig:"CDC_WEBHOOK_TOKEN" flag:"cdc-webhook-token" secret:"true" reload:"true"`
+	// CDCGRPCTarget enables delivering changes to this gRPC target, which
+	// implements cdc.v1.ChangeSinkService.
+	CDCGRPCTarget string `yaml:"cdc_grpc_target" toml:"cdc_grpc_target" envconfig:"CDC_GRPC_TARGET" flag:"cdc-grpc-target"`
+	// CDCBatchSize is the maximum number of changes delivered at once.
+	CDCBatchSize int `yaml:"cdc_batch_size" toml:"cdc_batch_size" envconfig:"CDC_BATCH_SIZE" flag:"cdc-batch-size"`
+	// CDCPollInterval is how often idle sinks check for new changes.
+	CDCPollInterval time.Duration `yaml:"cdc_poll_interval" toml:"cdc_poll_interval" envconfig:"CDC_POLL_INTERVAL" flag:"cdc-poll-interval"`
+
+	// AuditLog enables recording every change made by a write in this
+	// hash-chained file.
+	AuditLog string `yaml:"audit_log" toml:"audit_log" envconfig:"AUDIT_LOG" flag:"audit-log"`
+	// AuditKey, when set, keys the audit log's hashes, so they can't be
+	// recomputed after tampering without it.
+	AuditKey string `yaml:"audit_key" toml:"audit_key" envconfig:"AUDIT_KEY" flag:"audit-key" secret:"true"`
+
+	// AdminPort enables the admin HTTP listener when non-zero.
+	AdminPort  int    `yaml:"admin_port" toml:"admin_port" envconfig:"ADMIN_PORT" flag:"admin-port"`
+	AdminToken string `yaml:"admin_token" toml:"admin_token" envconfig:"ADMIN_TOKEN" flag:"admin-token" secret:"true"`
 }
 
-// Load loads configuration from environment variables and .env file
-func Load() (*Config, error) {
+// Default returns the configuration used when no layer sets a value.
+func Default() Config {
+	return Config{
+		Port:            5051,
+		SocketMode:      "0660",
+		LogFormat:       "text",
+		LogLevel:        slog.LevelInfo,
+		LogRedactFields: []string{"value"},
+		RateBurst:       100,
+
+		JournalSegmentSize: 64 << 20,
+
+		HistoryVersions:           10,
+		ChangeLogChanges:          100_000,
+		HistoryCompactionInterval: time.Minute,
+
+		CDCFileMaxSize:  64 << 20,
+		CDCBatchSize:    500,
+		CDCPollInterval: time.Second,
+	}
+}
+
+// Load builds the configuration from defaults, the config file at path (if
+// not empty), environment variables and .env file, and the flags registered
+// by RegisterFlags that were set on fs (if not nil), then validates it.
+func Load(path string, fs *flag.FlagSet) (*Config, error) {
+	config := Default()
+
+	if path != "" {
+		if err := decodeFile(path, &config); err != nil {
+			return nil, err
+		}
+	}
+
 	// Load .env file if it exists, but don't fail if it doesn't
 	if err := godotenv.Load(); err != nil && !os.IsNotExist(err) {
 		return nil, err
 	}
 
-	var config Config
+	// Unset variables leave earlier layers alone, which is why Config carries
+	// no envconfig default tags.
 	if err := envconfig.Process("", &config); err != nil {
 		return nil, err
 	}
 
+	if err := applyFlags(fs, &config); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &config, nil
 }
+
+// Validate reports every invalid setting, naming settings by their file key.
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(key, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	// A redacted dump used as a config file would otherwise set the
+	// placeholder as the secret
+	for key, f := range c.secrets() {
+		if f.String() == redacted {
+			invalid(key, "is the %s placeholder, not a secret", redacted)
+		}
+	}
+
+	if c.Port < 1 || c.Port > 65535 {
+		invalid("port", "must be between 1 and 65535, got %d", c.Port)
+	}
+	for _, addr := range c.Listen {
+		if err := validateListenAddr(addr); err != nil {
+			invalid("listen", "%v", err)
+		}
+	}
+	if _, err := strconv.ParseUint(c.SocketMode, 8, 32); err != nil {
+		invalid("socket_mode", "must be an octal file mode like 0660, got %q", c.SocketMode)
+	}
+	if c.LogFormat != "text" && c.LogFormat != "json" {
+		invalid("log_format", `must be "text" or "json", got %q`, c.LogFormat)
+	}
+	for method, rate := range c.LogSampleRates {
+		if rate < 0 || rate > 1 {
+			invalid("log_sample_rates", "rate for %q must be between 0 and 1, got %g", method, rate)
+		}
+	}
+	if c.RateLimit < 0 {
+		invalid("rate_limit", "must not be negative, got %g", c.RateLimit)
+	}
+	if c.RateLimit > 0 && c.RateBurst < 1 {
+		invalid("rate_burst", "must be at least 1 when rate_limit is set, got %d", c.RateBurst)
+	}
+	if c.ForwardWrites && c.ReplicateFrom == "" {
+		invalid("forward_writes", "requires replicate_from")
+	}
+	if c.RaftAddress != "" {
+		if c.RaftID == "" {
+			invalid("raft_id", "is required when raft_address is set")
+		}
+		if c.RaftDir == "" {
+			invalid("raft_dir", "is required when raft_address is set")
+		}
+		if c.ReplicateFrom != "" {
+			invalid("replicate_from", "cannot be set on a cluster node")
+		}
+	} else if c.RaftID != "" || c.RaftDir != "" || c.RaftBootstrap || len(c.RaftPeers) > 0 {
+		invalid("raft_address", "is required when other raft settings are set")
+	}
+	for _, peer := range c.RaftPeers {
+		if id, addr, ok := strings.Cut(peer, "="); !ok || id == "" || addr == "" {
+			invalid("raft_peers", `must be "raft_id=raft_address", got %q`, peer)
+		}
+	}
+	if c.JournalSegmentSize < 1 {
+		invalid("journal_segment_size", "must be positive, got %d", c.JournalSegmentSize)
+	}
+	if c.HistoryVersions < 0 {
+		invalid("history_versions", "must not be negative, got %d", c.HistoryVersions)
+	}
+	if c.HistoryMaxAge < 0 {
+		invalid("history_max_age", "must not be negative, got %s", c.HistoryMaxAge)
+	}
+	if c.ChangeLogChanges < 0 {
+		invalid("changelog_changes", "must not be negative, got %d", c.ChangeLogChanges)
+	}
+	if c.ChangeLogMaxAge < 0 {
+		invalid("changelog_max_age", "must not be negative, got %s", c.ChangeLogMaxAge)
+	}
+	if c.HistoryCompactionInterval <= 0 {
+		invalid("history_compaction_interval", "must be positive, got %s", c.HistoryCompactionInterval)
+	}
+	if c.CDCFileDir != "" || c.CDCWebhookURL != "" || c.CDCGRPCTarget != "" {
+		if c.CDCOffsetDir == "" {
+			invalid("cdc_offset_dir", "is required when a CDC sink is set")
+		}
+		// Offsets outlive the in-memory change log, whose numbering only
+		// continues across restarts after the journaled changes
+		if c.JournalDir == "" {
+			invalid("journal_dir", "is required when a CDC sink is set")
+		}
+	}
+	if c.CDCWebhookURL != "" {
+		if u, err := url.Parse(c.CDCWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			invalid("cdc_webhook_url", "must be an http or https URL, got %q", c.CDCWebhookURL)
+		}
+	}
+	if c.CDCFileMaxSize < 1 {
+		invalid("cdc_file_max_size", "must be positive, got %d", c.CDCFileMaxSize)
+	}
+	if c.CDCBatchSize < 1 {
+		invalid("cdc_batch_size", "must be positive, got %d", c.CDCBatchSize)
+	}
+	if c.CDCPollInterval <= 0 {
+		invalid("cdc_poll_interval", "must be positive, got %s", c.CDCPollInterval)
+	}
+	if c.AuditKey != "" && c.AuditLog == "" {
+		invalid("audit_key", "requires audit_log")
+	}
+	if c.AdminPort < 0 || c.AdminPort > 65535 {
+		invalid("admin_port", "must be between 0 and 65535, got %d", c.AdminPort)
+	}
+	if c.AdminPort != 0 && c.AdminPort == c.Port {
+		invalid("admin_port", "must differ from port %d", c.Port)
+	}
+	if c.AdminPort != 0 && c.AdminToken == "" {
+		invalid("admin_token", "is required when admin_port is set")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// ListenAddrs returns the addresses to serve on.
+func (c *Config) ListenAddrs() []string {
+	if len(c.Listen) == 0 {
+		return []string{fmt.Sprintf("tcp://:%d", c.Port)}
+	}
+	return c.Listen
+}
+
+// SocketFileMode returns SocketMode as a file mode. It assumes the
+// configuration has been validated.
+func (c *Config) SocketFileMode() fs.FileMode {
+	mode, _ := strconv.ParseUint(c.SocketMode, 8, 32)
+	return fs.FileMode(mode)
+}
+
+func validateListenAddr(addr string) error {
+	if path, ok := strings.CutPrefix(addr, "unix://"); ok {
+		if path == "" {
+			return fmt.Errorf("unix address %q has no socket path", addr)
+		}
+		return nil
+	}
+
+	if _, _, err := net.SplitHostPort(strings.TrimPrefix(addr, "tcp://")); err != nil {
+		return fmt.Errorf("invalid address %q: %v", addr, err)
+	}
+	return nil
+}
+
+// Redacted returns a copy of the configuration with every non-empty field
+// tagged `secret:"true"` replaced, so it is safe to log or expose.
+func (c Config) Redacted() Config {
+	for _, f := range c.secrets() {
+		if f.String() != "" {
+			f.SetString(redacted)
+		}
+	}
+	return c
+}
+
+// WithoutSecrets returns a copy of the configuration with every field tagged
+// `secret:"true"` cleared, so it is safe to print as a config file.
+func (c Config) WithoutSecrets() Config {
+	for _, f := range c.secrets() {
+		f.SetString("")
+	}
+	return c
+}
+
+// secrets iterates over the string fields of c tagged `secret:"true"`, by
+// config file key.
+func (c *Config) secrets() iter.Seq2[string, reflect.Value] {
+	return func(yield func(string, reflect.Value) bool) {
+		v := reflect.ValueOf(c).Elem()
+		t := v.Type()
+		for i := range t.NumField() {
+			if t.Field(i).Tag.Get("secret") != "true" || v.Field(i).Kind() != reflect.String {
+				continue
+			}
+			if !yield(t.Field(i).Tag.Get("yaml"), v.Field(i)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// decodeFile overlays the settings present in the YAML or TOML file at path
+// onto config. Unknown keys are rejected so typos don't go unnoticed.
+func decodeFile(path string, config *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(config); err != nil && err != io.EOF {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	case ".toml":
+		md, err := toml.Decode(string(data), config)
+		if err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("failed to parse config file %s: unknown keys %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("unsupported config file extension %q, want .yaml, .yml or .toml", ext)
+	}
+
+	return nil
+}
+
+// Write prints the configuration as YAML in the config file schema.
+func Write(w io.Writer, config Config) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(config); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// flagValue records the raw text of a config flag so it can be applied on top
+// of the other layers after they have been loaded.
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (v *flagValue) String() string     { return v.value }
+func (v *flagValue) Set(s string) error { v.value = s; return nil }
+func (v *flagValue) IsBoolFlag() bool   { return v.isBool }
+
+// RegisterFlags defines a flag on fs for every setting of Config.
+func RegisterFlags(fs *flag.FlagSet) {
+	t := reflect.TypeFor[Config]()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name := field.Tag.Get("flag")
+		if name == "" {
+			continue
+		}
+
+		usage := fmt.Sprintf("overrides %s and config file key %s", field.Tag.Get("envconfig"), field.Tag.Get("yaml"))
+		fs.Var(&flagValue{isBool: field.Type.Kind() == reflect.Bool}, name, usage)
+	}
+}
+
+// applyFlags sets the fields whose flags were given on the command line.
+func applyFlags(fs *flag.FlagSet, config *Config) error {
+	if fs == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(config).Elem()
+	t := v.Type()
+	fields := make(map[string]int, t.NumField())
+	for i := range t.NumField() {
+		if name := t.Field(i).Tag.Get("flag"); name != "" {
+			fields[name] = i
+		}
+	}
+
+	var err error
+	fs.Visit(func(f *flag.Flag) {
+		i, ok := fields[f.Name]
+		if !ok || err != nil {
+			return
+		}
+		if setErr := setField(v.Field(i), f.Value.String()); setErr != nil {
+			err = fmt.Errorf("invalid value %q for flag -%s: %w", f.Value.String(), f.Name, setErr)
+		}
+	})
+
+	return err
+}
+
+// setField parses s into the field using the same list ("a,b") and map
+// ("k:v,k2:v2") syntax as envconfig.
+func setField(field reflect.Value, s string) error {
+	if u, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+
+	if field.Type() == reflect.TypeFor[time.Duration]() {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.ParseInt(s, 10, 0)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		items := reflect.MakeSlice(field.Type(), 0, 0)
+		for item := range strings.SplitSeq(s, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setField(elem, item); err != nil {
+				return err
+			}
+			items = reflect.Append(items, elem)
+		}
+		field.Set(items)
+	case reflect.Map:
+		m := reflect.MakeMap(field.Type())
+		for pair := range strings.SplitSeq(s, ",") {
+			if pair = strings.TrimSpace(pair); pair == "" {
+				continue
+			}
+			i := strings.LastIndex(pair, ":")
+			if i < 0 {
+				return fmt.Errorf("invalid map entry %q, want key:value", pair)
+			}
+			key := reflect.New(field.Type().Key()).Elem()
+			if err := setField(key, pair[:i]); err != nil {
+				return err
+			}
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setField(elem, pair[i+1:]); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, elem)
+		}
+		field.Set(m)
+	default:
+		return fmt.Errorf("unsupported setting type %s", field.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+	"time"
+)
+
+// Watch reloads the configuration whenever the process receives SIGHUP or the
+// config file at path changes, polling the file every interval. Each valid
+// reload is passed to apply as a copy of current with only the fields tagged
+// `reload:"true"` updated; changes to other fields are logged and ignored
+// until restart. Invalid configurations are logged and skipped. Watch returns
+// when ctx is done.
+func Watch(ctx context.Context, path string, fs *flag.FlagSet, current Config, interval time.Duration, apply func(Config)) error {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if path != "" {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	last := fileVersion(path)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-hup:
+			slog.Info("received SIGHUP, reloading config")
+		case <-tick:
+			version := fileVersion(path)
+			if version == last {
+				continue
+			}
+			last = version
+			slog.Info("config file changed, reloading config", "path", path)
+		}
+
+		next, err := Load(path, fs)
+		if err != nil {
+			slog.Error("ignoring invalid config", "error", err)
+			continue
+		}
+
+		merged, restartOnly := mergeReloadable(current, *next)
+		if len(restartOnly) > 0 {
+			slog.Warn("ignoring config changes that require a restart", "settings", restartOnly)
+		}
+		if reflect.DeepEqual(merged, current) {
+			continue
+		}
+
+		current = merged
+		apply(current)
+		slog.Info("applied reloaded config")
+	}
+}
+
+// fileStamp identifies the contents of a file well enough to notice edits and
+// atomic replacements.
+type fileStamp struct {
+	mod  time.Time
+	size int64
+}
+
+func fileVersion(path string) fileStamp {
+	var v fileStamp
+	if info, err := os.Stat(path); err == nil {
+		v.mod, v.size = info.ModTime(), info.Size()
+	}
+	return v
+}
+
+// mergeReloadable copies the reloadable fields of next into current and
+// returns the file keys of the other fields that differ.
+func mergeReloadable(current, next Config) (Config, []string) {
+	var restartOnly []string
+
+	cur := reflect.ValueOf(&current).Elem()
+	nxt := reflect.ValueOf(next)
+	t := cur.Type()
+	for i := range t.NumField() {
+		if t.Field(i).Tag.Get("reload") == "true" {
+			cur.Field(i).Set(nxt.Field(i))
+			continue
+		}
+		if !reflect.DeepEqual(cur.Field(i).Interface(), nxt.Field(i).Interface()) {
+			restartOnly = append(restartOnly, t.Field(i).Tag.Get("yaml"))
+		}
+	}
+
+	return current, restartOnly
+}
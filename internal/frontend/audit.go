@@ -0,0 +1,164 @@
+package frontend
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/internal/audit"
+)
+
+// WithAuditLog records every change made by Put, Delete, BatchPut and Import
+// in the audit log. Writes are acknowledged once they're recorded.
+func WithAuditLog(log *audit.Log) ServerOption {
+	return func(c *serverConfig) {
+		c.auditLog = log
+	}
+}
+
+// Writes forwarded to another server name their original caller in these
+// headers, so the server that applies them audits them as the caller's. The
+// server only trusts them from calls presenting its peer token.
+const (
+	callerPrincipalHeader = "gh-go-caller-principal"
+	callerPeerHeader      = "gh-go-caller-peer"
+	peerTokenHeader       = "gh-go-peer-token"
+)
+
+// keyChange is a change of a key requested by a write.
+type keyChange struct {
+	key     int64
+	value   string
+	deleted bool
+}
+
+// audited runs write, which applies changes in order, and records an audit
+// entry per change once it succeeds, unless write reports that it changed
+// nothing. Only the server applying a write audits it, not the ones that
+// forwarded it there. Audited writes are serialized, so the values read
+// before a write are the ones it replaced.
+func (h *handler) audited(ctx context.Context, changes []keyChange, write func() (bool, error)) error {
+	if h.audit == nil {
+		_, err := write()
+		return err
+	}
+
+	h.auditMu.Lock()
+	defer h.auditMu.Unlock()
+
+	// Hashes of the current values, empty for missing keys
+	current := make(map[int64]string, len(changes))
+	for _, change := range changes {
+		if _, ok := current[change.key]; ok {
+			continue
+		}
+		value, err := h.store(ctx).Get(ctx, change.key)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			current[change.key] = ""
+		case err != nil:
+			return status.Error(codes.Internal, err.Error())
+		default:
+			current[change.key] = audit.ValueHash(value)
+		}
+	}
+
+	if changed, err := write(); err != nil || !changed {
+		return err
+	}
+
+	caller := callerOf(ctx)
+	entries := make([]audit.Entry, 0, len(changes))
+	for _, change := range changes {
+		entry := caller
+		entry.Key = change.key
+		entry.OldHash = current[change.key]
+		if !change.deleted {
+			entry.NewHash = audit.ValueHash(change.value)
+		}
+		current[change.key] = entry.NewHash
+		entries = append(entries, entry)
+	}
+
+	if err := h.audit.Append(entries...); err != nil {
+		return status.Errorf(codes.Internal, "write was applied but not audited: %v", err)
+	}
+	return nil
+}
+
+// callerOf returns an audit entry describing the call in ctx.
+func callerOf(ctx context.Context) audit.Entry {
+	entry := audit.Entry{Time: time.Now(), Principal: principal(ctx), Namespace: namespaceOf(ctx)}
+	entry.Method, _ = grpc.Method(ctx)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		entry.Peer = p.Addr.String()
+	}
+	if span := trace.SpanContextFromContext(ctx); span.HasTraceID() {
+		entry.TraceID = span.TraceID().String()
+	}
+
+	// A forwarded write is the original caller's, made via the forwarder
+	md, _ := metadata.FromIncomingContext(ctx)
+	if principals := md.Get(callerPrincipalHeader); len(principals) > 0 {
+		entry.Via = entry.Principal
+		entry.Principal = principals[0]
+		entry.Peer = ""
+		if peers := md.Get(callerPeerHeader); len(peers) > 0 {
+			entry.Peer = peers[0]
+		}
+	}
+	return entry
+}
+
+// forwardCaller names the caller in ctx on calls made with the returned
+// context, for writes forwarded to another server.
+func forwardCaller(ctx context.Context) context.Context {
+	caller := callerOf(ctx)
+	return metadata.AppendToOutgoingContext(ctx,
+		callerPrincipalHeader, caller.Principal,
+		callerPeerHeader, caller.Peer,
+	)
+}
+
+// peerToken presents a node's peer token to the server it forwards writes to.
+type peerToken func() string
+
+func (t peerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	token := t()
+	if token == "" {
+		return nil, nil
+	}
+	return map[string]string{peerTokenHeader: token}, nil
+}
+
+func (t peerToken) RequireTransportSecurity() bool {
+	return false
+}
+
+// principal identifies the caller by the subject of its verified TLS client
+// certificate or else a fingerprint of its bearer token, which tells tokens
+// apart without revealing them.
+func principal(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) > 0 {
+			return "cert:" + info.State.VerifiedChains[0][0].Subject.String()
+		}
+	}
+	if token, err := auth.AuthFromMD(ctx, "bearer"); err == nil {
+		sum := sha256.Sum256([]byte(token))
+		return "token:" + hex.EncodeToString(sum[:6])
+	}
+	return "anonymous"
+}
@@ -0,0 +1,746 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/raft"
+	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	clusterpb "github.com/dynoinc/gh-go/proto/cluster/v1"
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+const (
+	// forwardedHeader marks calls forwarded to the leader, which must not be
+	// forwarded again.
+	forwardedHeader = "gh-go-forwarded-by"
+	// applyTimeout bounds how long a write waits to be committed when the
+	// caller set no deadline.
+	applyTimeout = 10 * time.Second
+	// appliedPollInterval is how often a read waiting for the log to be
+	// applied checks again, as no-op entries don't advance the state machine.
+	appliedPollInterval = 5 * time.Millisecond
+)
+
+// ClusterConfig makes a server a node of a Raft cluster. Writes are committed
+// through a log replicated to a majority of nodes and then applied to every
+// node's backend; reads are linearizable. Any node accepts calls and forwards
+// writes to the leader, so a cluster survives the loss of a minority of its
+// nodes without manual failover.
+//
+// Nodes number changes alike, so revisions read the same data on every node:
+// snapshots of backends implementing sqlbackend.Backupper copy the database,
+// including its change log and history. Journaling backends number each
+// restore, so their revisions drift from the other nodes' once they catch up
+// from a snapshot.
+type ClusterConfig struct {
+	// ID identifies the node. It must be the gRPC target other nodes reach
+	// the node's server at, as calls are forwarded to the leader by its ID.
+	ID string
+	// RaftAddress is the host:port other nodes reach the node's Raft
+	// transport at.
+	RaftAddress string
+	// RaftListener, if set, serves the Raft transport instead of a TCP
+	// listener on RaftAddress.
+	RaftListener net.Listener
+	// RaftDialer, if set, connects to other nodes' Raft transports instead
+	// of dialing TCP.
+	RaftDialer func(ctx context.Context, address string) (net.Conn, error)
+	// Dir keeps the Raft log and snapshots. If empty they are kept in memory,
+	// which is only safe for tests: a restarted node forgets its votes.
+	Dir string
+	// Bootstrap forms a new cluster of this node and Peers. Nodes with state
+	// ignore it, so it can be left set; give every initial node the same
+	// Peers, or bootstrap one node and add the others with AddMember.
+	Bootstrap bool
+	Peers     []ClusterPeer
+	// Every SnapshotInterval, a node that committed SnapshotThreshold entries
+	// since its last snapshot snapshots its state and truncates its log,
+	// keeping SnapshotThreshold entries for slow nodes. Zero values use
+	// Raft's defaults.
+	SnapshotInterval  time.Duration
+	SnapshotThreshold uint64
+	// AuthToken, if set, returns the bearer token presented when forwarding
+	// calls to the leader.
+	AuthToken func() string
+	// PeerToken, if set, returns the cluster's peer token, so the leader
+	// audits forwarded writes as their callers' rather than the node's.
+	PeerToken func() string
+	// DialOptions configure connections to the leader, which use insecure
+	// credentials unless overridden.
+	DialOptions []grpc.DialOption
+	// Namespaces, if set, manages the node's namespaces once the server is
+	// created.
+	Namespaces *ClusterNamespaces
+}
+
+// ClusterPeer is another initial node of a cluster to bootstrap.
+type ClusterPeer struct {
+	ID          string
+	RaftAddress string
+}
+
+// WithCluster runs the server as a node of a Raft cluster.
+func WithCluster(config ClusterConfig) ServerOption {
+	return func(c *serverConfig) {
+		c.cluster = &config
+	}
+}
+
+// cluster is a node of a Raft cluster with the backend as its state machine.
+// It serves ClusterService and the writes and read barriers of the handler.
+type cluster struct {
+	clusterpb.UnimplementedClusterServiceServer
+
+	id        string
+	raft      *raft.Raft
+	fsm       *clusterFSM
+	logs      raft.LogStore
+	transport *raft.NetworkTransport
+	bolt      *raftboltdb.BoltStore // nil when kept in memory
+	dialOpts  []grpc.DialOption
+
+	// audited audits the writes the node applies as the leader, set by
+	// the handler.
+	audited func(ctx context.Context, changes []keyChange, write func() (bool, error)) error
+
+	// readyTerm is the term in which the node as leader last committed an
+	// entry. Only then is its commit index known to be current.
+	readyTerm atomic.Uint64
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn // by node ID
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newCluster(config *ClusterConfig, backend sqlbackend.Backend, changes *changeHub, logger *slog.Logger) (*cluster, error) {
+	if config.ID == "" || config.RaftAddress == "" {
+		return nil, fmt.Errorf("cluster nodes require an ID and a Raft address")
+	}
+
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if config.AuthToken != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearerToken(config.AuthToken)))
+	}
+	if config.PeerToken != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(peerToken(config.PeerToken)))
+	}
+	c := &cluster{
+		id:       config.ID,
+		fsm:      newClusterFSM(backend, changes),
+		dialOpts: append(dialOpts, config.DialOptions...),
+		conns:    make(map[string]*grpc.ClientConn),
+		stop:     make(chan struct{}),
+	}
+
+	raftLog := raftLogger{Logger: hclog.NewNullLogger(), logger: logger.With("component", "raft")}
+	raftConfig := raft.DefaultConfig()
+	raftConfig.LocalID = raft.ServerID(config.ID)
+	raftConfig.Logger = raftLog
+	if config.SnapshotInterval > 0 {
+		raftConfig.SnapshotInterval = config.SnapshotInterval
+	}
+	if config.SnapshotThreshold > 0 {
+		raftConfig.SnapshotThreshold = config.SnapshotThreshold
+		raftConfig.TrailingLogs = config.SnapshotThreshold
+	}
+
+	var stable raft.StableStore
+	var snapshots raft.SnapshotStore
+	if config.Dir == "" {
+		store := raft.NewInmemStore()
+		c.logs, stable, snapshots = store, store, raft.NewInmemSnapshotStore()
+	} else {
+		var err error
+		c.bolt, err = raftboltdb.NewBoltStore(filepath.Join(config.Dir, "raft.db"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open Raft log: %w", err)
+		}
+		c.logs, stable = c.bolt, c.bolt
+
+		snapshots, err = raft.NewFileSnapshotStoreWithLogger(config.Dir, 2, raftLog)
+		if err != nil {
+			c.close()
+			return nil, fmt.Errorf("failed to open snapshot store: %w", err)
+		}
+	}
+
+	lis := config.RaftListener
+	if lis == nil {
+		var err error
+		lis, err = net.Listen("tcp", config.RaftAddress)
+		if err != nil {
+			c.close()
+			return nil, fmt.Errorf("failed to listen for Raft: %w", err)
+		}
+	}
+	dial := config.RaftDialer
+	if dial == nil {
+		var d net.Dialer
+		dial = func(ctx context.Context, address string) (net.Conn, error) {
+			return d.DialContext(ctx, "tcp", address)
+		}
+	}
+	c.transport = raft.NewNetworkTransportWithConfig(&raft.NetworkTransportConfig{
+		Stream:  &streamLayer{Listener: lis, addr: raftAddr(config.RaftAddress), dial: dial},
+		MaxPool: 3,
+		Timeout: 10 * time.Second,
+		Logger:  raftLog,
+	})
+
+	var err error
+	c.raft, err = raft.NewRaft(raftConfig, c.fsm, c.logs, stable, snapshots, c.transport)
+	if err != nil {
+		c.close()
+		return nil, fmt.Errorf("failed to start Raft: %w", err)
+	}
+
+	if config.Bootstrap {
+		servers := []raft.Server{{ID: raft.ServerID(config.ID), Address: raft.ServerAddress(config.RaftAddress)}}
+		for _, peer := range config.Peers {
+			servers = append(servers, raft.Server{ID: raft.ServerID(peer.ID), Address: raft.ServerAddress(peer.RaftAddress)})
+		}
+		err := c.raft.BootstrapCluster(raft.Configuration{Servers: servers}).Error()
+		if err != nil && !errors.Is(err, raft.ErrCantBootstrap) {
+			c.close()
+			return nil, fmt.Errorf("failed to bootstrap cluster: %w", err)
+		}
+	}
+
+	c.wg.Go(c.monitorLeadership)
+	return c, nil
+}
+
+// close stops the node. It may be called on a partially created node.
+func (c *cluster) close() {
+	close(c.stop)
+	if c.raft != nil {
+		c.raft.Shutdown().Error()
+	}
+	c.wg.Wait()
+	if c.transport != nil {
+		c.transport.Close()
+	}
+	if c.bolt != nil {
+		c.bolt.Close()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, conn := range c.conns {
+		conn.Close()
+	}
+}
+
+// monitorLeadership marks the node ready to serve read indexes once, as the
+// leader, it committed an entry of its term.
+func (c *cluster) monitorLeadership() {
+	for {
+		select {
+		case <-c.stop:
+			return
+		case leader := <-c.raft.LeaderCh():
+			if !leader {
+				continue
+			}
+			term := c.raft.CurrentTerm()
+			if err := c.raft.Barrier(0).Error(); err == nil {
+				c.readyTerm.Store(term)
+			}
+		}
+	}
+}
+
+// leader returns a connection to the leader and ctx marked as forwarded, or
+// a nil connection if this node leads.
+func (c *cluster) leader(ctx context.Context) (*grpc.ClientConn, context.Context, error) {
+	_, id := c.raft.LeaderWithID()
+	switch id {
+	case "":
+		return nil, nil, status.Error(codes.Unavailable, "cluster has no leader")
+	case raft.ServerID(c.id):
+		return nil, ctx, nil
+	}
+
+	if md, _ := metadata.FromIncomingContext(ctx); len(md.Get(forwardedHeader)) > 0 {
+		return nil, nil, status.Errorf(codes.Unavailable, "leadership moved to %s while forwarding", id)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	conn, ok := c.conns[string(id)]
+	if !ok {
+		var err error
+		conn, err = grpc.NewClient(string(id), c.dialOpts...)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to connect to leader: %v", err)
+		}
+		c.conns[string(id)] = conn
+	}
+	return conn, metadata.AppendToOutgoingContext(forward(ctx), forwardedHeader, c.id), nil
+}
+
+// apply commits a command as the leader and returns its result.
+func (c *cluster) apply(ctx context.Context, cmd *clusterpb.Command) (any, error) {
+	result, err := c.commit(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	if err, ok := result.(error); ok {
+		return nil, writeError(err)
+	}
+	return result, nil
+}
+
+// commit commits a command as the leader and returns what applying it
+// returned, which is an error if it failed.
+func (c *cluster) commit(ctx context.Context, cmd *clusterpb.Command) (any, error) {
+	data, err := proto.Marshal(cmd)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	timeout := applyTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	future := c.raft.Apply(data, timeout)
+	if err := future.Error(); err != nil {
+		return nil, raftError(err)
+	}
+	return future.Response(), nil
+}
+
+func (c *cluster) batchPut(ctx context.Context, req *frontendpb.BatchPutRequest) (*frontendpb.BatchPutResponse, error) {
+	conn, ctx, err := c.leader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if conn != nil {
+		return frontendpb.NewFrontendServiceClient(conn).BatchPut(ctx, req)
+	}
+
+	entries := make([]*clusterpb.Entry, 0, len(req.GetEntries()))
+	changes := make([]keyChange, 0, len(req.GetEntries()))
+	for _, entry := range req.GetEntries() {
+		entries = append(entries, clusterpb.Entry_builder{Key: entry.GetKey(), Value: entry.GetValue()}.Build())
+		changes = append(changes, keyChange{key: entry.GetKey(), value: entry.GetValue()})
+	}
+	cmd := clusterpb.Command_builder{BatchPut: clusterpb.BatchPut_builder{
+		Entries:   entries,
+		Namespace: namespaceOf(ctx),
+	}.Build()}.Build()
+	err = c.audited(ctx, changes, func() (bool, error) {
+		_, err := c.apply(ctx, cmd)
+		return len(changes) > 0, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &frontendpb.BatchPutResponse{}, nil
+}
+
+func (c *cluster) put(ctx context.Context, req *frontendpb.PutRequest) (*frontendpb.PutResponse, error) {
+	if _, err := c.batchPut(ctx, frontendpb.BatchPutRequest_builder{Entries: []*frontendpb.PutRequest{req}}.Build()); err != nil {
+		return nil, err
+	}
+	return &frontendpb.PutResponse{}, nil
+}
+
+func (c *cluster) delete(ctx context.Context, req *frontendpb.DeleteRequest) (*frontendpb.DeleteResponse, error) {
+	conn, ctx, err := c.leader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if conn != nil {
+		return frontendpb.NewFrontendServiceClient(conn).Delete(ctx, req)
+	}
+
+	cmd := clusterpb.Command_builder{Delete: clusterpb.Delete_builder{
+		Key:       req.GetKey(),
+		Namespace: namespaceOf(ctx),
+	}.Build()}.Build()
+	var found bool
+	err = c.audited(ctx, []keyChange{{key: req.GetKey(), deleted: true}}, func() (bool, error) {
+		result, err := c.apply(ctx, cmd)
+		if err != nil {
+			return false, err
+		}
+		found = result.(bool)
+		return found, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return frontendpb.DeleteResponse_builder{Found: found}.Build(), nil
+}
+
+// linearize waits until reads from the local backend reflect every write
+// committed before it was called, using the leader's read index.
+func (c *cluster) linearize(ctx context.Context) error {
+	conn, ctx, err := c.leader(ctx)
+	if err != nil {
+		return err
+	}
+
+	var index uint64
+	if conn == nil {
+		index, err = c.readIndex()
+	} else {
+		var resp *clusterpb.ReadIndexResponse
+		resp, err = clusterpb.NewClusterServiceClient(conn).ReadIndex(ctx, &clusterpb.ReadIndexRequest{})
+		index = resp.GetIndex()
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		applied, advanced := c.fsm.progress()
+		if applied >= index || (c.raft.AppliedIndex() >= index && !c.pending(applied, index)) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-advanced:
+		case <-time.After(appliedPollInterval):
+		}
+	}
+}
+
+// pending reports whether any entry after applied up to index is still to be
+// applied by the state machine, rather than an entry it never sees.
+func (c *cluster) pending(applied, index uint64) bool {
+	for i := applied + 1; i <= index; i++ {
+		var entry raft.Log
+		if err := c.logs.GetLog(i, &entry); err != nil {
+			// Entries are only compacted once applied
+			if errors.Is(err, raft.ErrLogNotFound) {
+				continue
+			}
+			return true
+		}
+		if entry.Type == raft.LogCommand || entry.Type == raft.LogConfiguration {
+			return true
+		}
+	}
+	return false
+}
+
+// readIndex returns the commit index after confirming leadership.
+func (c *cluster) readIndex() (uint64, error) {
+	if c.raft.State() != raft.Leader || c.readyTerm.Load() != c.raft.CurrentTerm() {
+		return 0, status.Error(codes.Unavailable, "node is not an established leader")
+	}
+
+	index := c.raft.CommitIndex()
+	if err := c.raft.VerifyLeader().Error(); err != nil {
+		return 0, raftError(err)
+	}
+	return index, nil
+}
+
+func (c *cluster) ReadIndex(context.Context, *clusterpb.ReadIndexRequest) (*clusterpb.ReadIndexResponse, error) {
+	index, err := c.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	return clusterpb.ReadIndexResponse_builder{Index: index}.Build(), nil
+}
+
+func (c *cluster) AddMember(ctx context.Context, req *clusterpb.AddMemberRequest) (*clusterpb.AddMemberResponse, error) {
+	if req.GetId() == "" || req.GetRaftAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id and raft_address are required")
+	}
+
+	conn, ctx, err := c.leader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if conn != nil {
+		return clusterpb.NewClusterServiceClient(conn).AddMember(ctx, req)
+	}
+
+	id, address := raft.ServerID(req.GetId()), raft.ServerAddress(req.GetRaftAddress())
+	add := c.raft.AddVoter
+	if req.GetNonVoter() {
+		add = c.raft.AddNonvoter
+	}
+	if err := add(id, address, 0, 0).Error(); err != nil {
+		return nil, raftError(err)
+	}
+	return &clusterpb.AddMemberResponse{}, nil
+}
+
+func (c *cluster) RemoveMember(ctx context.Context, req *clusterpb.RemoveMemberRequest) (*clusterpb.RemoveMemberResponse, error) {
+	conn, ctx, err := c.leader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if conn != nil {
+		return clusterpb.NewClusterServiceClient(conn).RemoveMember(ctx, req)
+	}
+
+	if err := c.raft.RemoveServer(raft.ServerID(req.GetId()), 0, 0).Error(); err != nil {
+		return nil, raftError(err)
+	}
+	return &clusterpb.RemoveMemberResponse{}, nil
+}
+
+func (c *cluster) ListMembers(context.Context, *clusterpb.ListMembersRequest) (*clusterpb.ListMembersResponse, error) {
+	future := c.raft.GetConfiguration()
+	if err := future.Error(); err != nil {
+		return nil, raftError(err)
+	}
+
+	_, leader := c.raft.LeaderWithID()
+	members := make([]*clusterpb.Member, 0, len(future.Configuration().Servers))
+	for _, server := range future.Configuration().Servers {
+		members = append(members, clusterpb.Member_builder{
+			Id:          string(server.ID),
+			RaftAddress: string(server.Address),
+			Voter:       server.Suffrage == raft.Voter,
+			Leader:      server.ID == leader,
+		}.Build())
+	}
+	return clusterpb.ListMembersResponse_builder{Members: members}.Build(), nil
+}
+
+func (c *cluster) UpdateNamespace(ctx context.Context, req *clusterpb.UpdateNamespaceRequest) (*clusterpb.UpdateNamespaceResponse, error) {
+	switch req.GetCommand().WhichOp() {
+	case clusterpb.Command_CreateNamespace_case, clusterpb.Command_SetQuota_case, clusterpb.Command_DeleteNamespace_case:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "command must change a namespace")
+	}
+
+	ns, err := c.updateNamespace(ctx, req.GetCommand())
+	if err != nil {
+		return nil, namespaceError(err)
+	}
+	return clusterpb.UpdateNamespaceResponse_builder{
+		Name:      ns.Name,
+		Quota:     quotaProto(ns.Quota),
+		Keys:      ns.Keys,
+		Bytes:     ns.Bytes,
+		CreatedAt: ns.CreatedAt.UnixNano(),
+	}.Build(), nil
+}
+
+// updateNamespace commits a namespace change as the leader and returns the
+// namespace as changed. Errors of the leader's backend are returned as they
+// are, and those of changes forwarded to the leader as status errors.
+func (c *cluster) updateNamespace(ctx context.Context, cmd *clusterpb.Command) (sqlbackend.Namespace, error) {
+	conn, ctx, err := c.leader(ctx)
+	if err != nil {
+		return sqlbackend.Namespace{}, err
+	}
+	if conn != nil {
+		req := clusterpb.UpdateNamespaceRequest_builder{Command: cmd}.Build()
+		resp, err := clusterpb.NewClusterServiceClient(conn).UpdateNamespace(ctx, req)
+		if err != nil {
+			return sqlbackend.Namespace{}, err
+		}
+		return sqlbackend.Namespace{
+			Name:      resp.GetName(),
+			Quota:     quotaOf(resp.GetQuota()),
+			Keys:      resp.GetKeys(),
+			Bytes:     resp.GetBytes(),
+			CreatedAt: time.Unix(0, resp.GetCreatedAt()),
+		}, nil
+	}
+
+	result, err := c.commit(ctx, cmd)
+	if err != nil {
+		return sqlbackend.Namespace{}, err
+	}
+	switch result := result.(type) {
+	case error:
+		return sqlbackend.Namespace{}, result
+	case sqlbackend.Namespace:
+		return result, nil
+	}
+	return sqlbackend.Namespace{}, nil
+}
+
+// ClusterNamespaces manages the namespaces of a cluster node through the Raft
+// log, so every node has the same namespaces and checks writes against the
+// same quotas. Set it in ClusterConfig and manage namespaces with it instead
+// of the backend, whose namespace changes only apply to the node itself.
+type ClusterNamespaces struct {
+	mu      sync.Mutex
+	backend sqlbackend.Namespaces
+	cluster *cluster
+}
+
+// node returns the node's cluster and backend, once the server is created.
+func (n *ClusterNamespaces) node() (*cluster, sqlbackend.Namespaces, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.cluster == nil {
+		return nil, nil, status.Error(codes.Unavailable, "cluster node is not running")
+	}
+	return n.cluster, n.backend, nil
+}
+
+// Namespace returns the node's backend of a namespace. It must not be called
+// before the server is created.
+func (n *ClusterNamespaces) Namespace(name string) sqlbackend.Backend {
+	_, backend, _ := n.node()
+	return backend.Namespace(name)
+}
+
+func (n *ClusterNamespaces) CreateNamespace(ctx context.Context, name string, quota sqlbackend.Quota) (sqlbackend.Namespace, error) {
+	return n.update(ctx, clusterpb.Command_builder{CreateNamespace: clusterpb.CreateNamespace_builder{
+		Name:  name,
+		Quota: quotaProto(quota),
+	}.Build()}.Build())
+}
+
+func (n *ClusterNamespaces) GetNamespace(ctx context.Context, name string) (sqlbackend.Namespace, error) {
+	c, backend, err := n.node()
+	if err != nil {
+		return sqlbackend.Namespace{}, err
+	}
+	if err := c.linearize(ctx); err != nil {
+		return sqlbackend.Namespace{}, err
+	}
+	return backend.GetNamespace(ctx, name)
+}
+
+func (n *ClusterNamespaces) ListNamespaces(ctx context.Context) ([]sqlbackend.Namespace, error) {
+	c, backend, err := n.node()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.linearize(ctx); err != nil {
+		return nil, err
+	}
+	return backend.ListNamespaces(ctx)
+}
+
+func (n *ClusterNamespaces) SetQuota(ctx context.Context, name string, quota sqlbackend.Quota) (sqlbackend.Namespace, error) {
+	return n.update(ctx, clusterpb.Command_builder{SetQuota: clusterpb.SetQuota_builder{
+		Name:  name,
+		Quota: quotaProto(quota),
+	}.Build()}.Build())
+}
+
+func (n *ClusterNamespaces) DeleteNamespace(ctx context.Context, name string) error {
+	_, err := n.update(ctx, clusterpb.Command_builder{DeleteNamespace: clusterpb.DeleteNamespace_builder{
+		Name: name,
+	}.Build()}.Build())
+	return err
+}
+
+func (n *ClusterNamespaces) update(ctx context.Context, cmd *clusterpb.Command) (sqlbackend.Namespace, error) {
+	c, _, err := n.node()
+	if err != nil {
+		return sqlbackend.Namespace{}, err
+	}
+	return c.updateNamespace(ctx, cmd)
+}
+
+// linearize makes a cluster node's reads reflect every write committed
+// before the read.
+func (h *handler) linearize(ctx context.Context) error {
+	if h.cluster == nil {
+		return nil
+	}
+	return h.cluster.linearize(ctx)
+}
+
+// raftError maps errors of Raft operations to status errors, marking those
+// worth retrying on the new leader as Unavailable.
+func raftError(err error) error {
+	switch {
+	case errors.Is(err, raft.ErrNotLeader),
+		errors.Is(err, raft.ErrLeadershipLost),
+		errors.Is(err, raft.ErrLeadershipTransferInProgress),
+		errors.Is(err, raft.ErrEnqueueTimeout),
+		errors.Is(err, raft.ErrRaftShutdown):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// streamLayer carries the Raft transport over a listener and dialer.
+type streamLayer struct {
+	net.Listener
+	addr raftAddr
+	dial func(ctx context.Context, address string) (net.Conn, error)
+}
+
+func (s *streamLayer) Dial(address raft.ServerAddress, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return s.dial(ctx, string(address))
+}
+
+// Addr returns the advertised address, which may differ from the listener's.
+func (s *streamLayer) Addr() net.Addr {
+	return s.addr
+}
+
+type raftAddr string
+
+func (a raftAddr) Network() string { return "tcp" }
+func (a raftAddr) String() string  { return string(a) }
+
+// raftLogger routes Raft's logs to slog. Methods Raft doesn't use fall
+// through to the embedded no-op logger.
+type raftLogger struct {
+	hclog.Logger
+	logger *slog.Logger
+}
+
+func (l raftLogger) Log(level hclog.Level, msg string, args ...any) {
+	lvl := slog.LevelInfo
+	switch {
+	case level <= hclog.Debug:
+		lvl = slog.LevelDebug
+	case level == hclog.Warn:
+		lvl = slog.LevelWarn
+	case level >= hclog.Error:
+		lvl = slog.LevelError
+	}
+	for i, arg := range args {
+		if format, ok := arg.(hclog.Format); ok && len(format) > 0 {
+			args[i] = fmt.Sprintf(fmt.Sprint(format[0]), format[1:]...)
+		}
+	}
+	l.logger.Log(context.Background(), lvl, msg, args...)
+}
+
+func (l raftLogger) Trace(msg string, args ...any) { l.Log(hclog.Trace, msg, args...) }
+func (l raftLogger) Debug(msg string, args ...any) { l.Log(hclog.Debug, msg, args...) }
+func (l raftLogger) Info(msg string, args ...any)  { l.Log(hclog.Info, msg, args...) }
+func (l raftLogger) Warn(msg string, args ...any)  { l.Log(hclog.Warn, msg, args...) }
+func (l raftLogger) Error(msg string, args ...any) { l.Log(hclog.Error, msg, args...) }
+
+func (l raftLogger) With(args ...any) hclog.Logger {
+	return raftLogger{Logger: l.Logger, logger: l.logger.With(args...)}
+}
+
+func (l raftLogger) Named(name string) hclog.Logger {
+	return raftLogger{Logger: l.Logger, logger: l.logger.With("logger", name)}
+}
@@ -0,0 +1,436 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/raft"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	clusterpb "github.com/dynoinc/gh-go/proto/cluster/v1"
+)
+
+// clusterFSM is the Raft state machine: it applies committed commands to the
+// backend and publishes them to watchers.
+type clusterFSM struct {
+	backend sqlbackend.Backend
+	changes *changeHub
+
+	mu       sync.Mutex
+	applied  uint64        // index of the last entry applied
+	advanced chan struct{} // closed when applied advances
+}
+
+func newClusterFSM(backend sqlbackend.Backend, changes *changeHub) *clusterFSM {
+	return &clusterFSM{backend: backend, changes: changes, advanced: make(chan struct{})}
+}
+
+// progress returns the index of the last entry applied and a channel closed
+// once it advances.
+func (f *clusterFSM) progress() (uint64, <-chan struct{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.applied, f.advanced
+}
+
+func (f *clusterFSM) setApplied(index uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.applied = index
+	close(f.advanced)
+	f.advanced = make(chan struct{})
+}
+
+// Apply applies a command, returning an error if it failed and, for
+// deletes, whether the key existed or, for namespace changes other than
+// deletes, the namespace as changed.
+func (f *clusterFSM) Apply(l *raft.Log) any {
+	defer f.setApplied(l.Index)
+
+	var cmd clusterpb.Command
+	if err := proto.Unmarshal(l.Data, &cmd); err != nil {
+		return fmt.Errorf("failed to decode command: %w", err)
+	}
+
+	// Commands are applied the same way on every node, whatever the caller's
+	// context, so they are not cancelled halfway through
+	ctx := context.Background()
+	switch cmd.WhichOp() {
+	case clusterpb.Command_BatchPut_case:
+		namespace := cmd.GetBatchPut().GetNamespace()
+		backend, err := namespaced(f.backend, namespace)
+		if err != nil {
+			return err
+		}
+		entries := make([]sqlbackend.KeyValue, 0, len(cmd.GetBatchPut().GetEntries()))
+		for _, entry := range cmd.GetBatchPut().GetEntries() {
+			entries = append(entries, sqlbackend.KeyValue{Key: entry.GetKey(), Value: entry.GetValue()})
+		}
+		if err := backend.BatchPut(ctx, entries); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			f.changes.publish(change{namespace: namespace, key: entry.Key, value: entry.Value})
+		}
+		return nil
+
+	case clusterpb.Command_Delete_case:
+		namespace, key := cmd.GetDelete().GetNamespace(), cmd.GetDelete().GetKey()
+		backend, err := namespaced(f.backend, namespace)
+		if err != nil {
+			return err
+		}
+		found, err := backend.Delete(ctx, key)
+		if err != nil {
+			return err
+		}
+		if found {
+			f.changes.publish(change{namespace: namespace, key: key, deleted: true})
+		}
+		return found
+
+	case clusterpb.Command_CreateNamespace_case:
+		create := cmd.GetCreateNamespace()
+		return f.updateNamespace(func(ns sqlbackend.Namespaces) (any, error) {
+			return ns.CreateNamespace(ctx, create.GetName(), quotaOf(create.GetQuota()))
+		})
+
+	case clusterpb.Command_SetQuota_case:
+		set := cmd.GetSetQuota()
+		return f.updateNamespace(func(ns sqlbackend.Namespaces) (any, error) {
+			return ns.SetQuota(ctx, set.GetName(), quotaOf(set.GetQuota()))
+		})
+
+	case clusterpb.Command_DeleteNamespace_case:
+		return f.updateNamespace(func(ns sqlbackend.Namespaces) (any, error) {
+			return nil, ns.DeleteNamespace(ctx, cmd.GetDeleteNamespace().GetName())
+		})
+
+	default:
+		return fmt.Errorf("unknown command at index %d", l.Index)
+	}
+}
+
+// updateNamespace applies a namespace change, returning its result or error.
+func (f *clusterFSM) updateNamespace(update func(sqlbackend.Namespaces) (any, error)) any {
+	ns, ok := f.backend.(sqlbackend.Namespaces)
+	if !ok {
+		return errors.New("backend does not support namespaces")
+	}
+	result, err := update(ns)
+	if err != nil {
+		return err
+	}
+	return result
+}
+
+// StoreConfiguration is called for membership changes, which count as
+// applied entries too.
+func (f *clusterFSM) StoreConfiguration(index uint64, _ raft.Configuration) {
+	f.setApplied(index)
+}
+
+// Snapshot captures a copy of the database or, if the backend can't be
+// copied, all entries and namespaces. Raft doesn't apply commands until it
+// returns, so the state is consistent.
+func (f *clusterFSM) Snapshot() (raft.FSMSnapshot, error) {
+	applied, _ := f.progress()
+	snapshot := clusterpb.Snapshot_builder{AppliedIndex: applied}
+	if backups, ok := f.backend.(sqlbackend.Backupper); ok {
+		var err error
+		if snapshot.Database, snapshot.DatabaseMetadata, err = copyDatabase(backups); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if snapshot.Entries, snapshot.Namespaces, err = f.state(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := proto.Marshal(snapshot.Build())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return fsmSnapshot(data), nil
+}
+
+// copyDatabase returns a copy of the backend's database and its metadata.
+func copyDatabase(backups sqlbackend.Backupper) ([]byte, []byte, error) {
+	dir, err := os.MkdirTemp("", "gh-go-snapshot-")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "snapshot.db")
+	if _, err := backups.Backup(context.Background(), path); err != nil {
+		return nil, nil, fmt.Errorf("failed to copy database: %w", err)
+	}
+	database, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	metadata, err := os.ReadFile(sqlbackend.MetadataPath(path))
+	if err != nil {
+		return nil, nil, err
+	}
+	return database, metadata, nil
+}
+
+// state returns all entries and namespaces.
+func (f *clusterFSM) state(ctx context.Context) ([]*clusterpb.Entry, []*clusterpb.CreateNamespace, error) {
+	entries, err := f.entries(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read entries: %w", err)
+	}
+
+	snapshot := make([]*clusterpb.Entry, 0, len(entries))
+	for _, entry := range entries {
+		snapshot = append(snapshot, clusterpb.Entry_builder{
+			Namespace: entry.namespace,
+			Key:       entry.Key,
+			Value:     entry.Value,
+		}.Build())
+	}
+
+	var namespaces []*clusterpb.CreateNamespace
+	if ns, ok := f.backend.(sqlbackend.Namespaces); ok {
+		list, err := ns.ListNamespaces(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read namespaces: %w", err)
+		}
+		for _, namespace := range list {
+			namespaces = append(namespaces, clusterpb.CreateNamespace_builder{
+				Name:  namespace.Name,
+				Quota: quotaProto(namespace.Quota),
+			}.Build())
+		}
+	}
+
+	return snapshot, namespaces, nil
+}
+
+// Restore replaces the state with that of a snapshot.
+func (f *clusterFSM) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var snapshot clusterpb.Snapshot
+	if err := proto.Unmarshal(data, &snapshot); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+
+	if len(snapshot.GetDatabase()) > 0 {
+		err = f.restoreDatabase(&snapshot)
+	} else {
+		err = f.restoreState(&snapshot)
+	}
+	if err != nil {
+		return err
+	}
+	f.setApplied(snapshot.GetAppliedIndex())
+	return nil
+}
+
+// restoreDatabase replaces the database with the snapshot's copy. The
+// backend's restore hooks end watches, as the changes aren't published.
+func (f *clusterFSM) restoreDatabase(snapshot *clusterpb.Snapshot) error {
+	backups, ok := f.backend.(sqlbackend.Backupper)
+	if !ok {
+		return errors.New("snapshot holds a database copy, which the backend can't restore")
+	}
+
+	dir, err := os.MkdirTemp("", "gh-go-snapshot-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "snapshot.db")
+	if err := os.WriteFile(path, snapshot.GetDatabase(), 0o600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(sqlbackend.MetadataPath(path), snapshot.GetDatabaseMetadata(), 0o600); err != nil {
+		return err
+	}
+	if _, err := backups.Restore(context.Background(), path); err != nil {
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+	return nil
+}
+
+// restoreState replaces all entries and namespaces with those of a snapshot,
+// writing only the differences so watchers see what changed.
+func (f *clusterFSM) restoreState(snapshot *clusterpb.Snapshot) error {
+	// Quotas are lifted while entries are written, as the snapshot's entries
+	// may exceed quotas lowered after they were written
+	ctx := context.Background()
+	ns, _ := f.backend.(sqlbackend.Namespaces)
+	if ns != nil {
+		for _, namespace := range snapshot.GetNamespaces() {
+			_, err := ns.SetQuota(ctx, namespace.GetName(), sqlbackend.Quota{})
+			if errors.Is(err, sqlbackend.ErrNamespaceNotFound) {
+				_, err = ns.CreateNamespace(ctx, namespace.GetName(), sqlbackend.Quota{})
+			}
+			if err != nil {
+				return fmt.Errorf("failed to restore namespace %q: %w", namespace.GetName(), err)
+			}
+		}
+	}
+
+	current, err := f.entries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read entries: %w", err)
+	}
+	values := make(map[namespacedKey]string, len(current))
+	for _, entry := range current {
+		values[namespacedKey{entry.namespace, entry.Key}] = entry.Value
+	}
+
+	puts := make(map[string][]sqlbackend.KeyValue)
+	for _, entry := range snapshot.GetEntries() {
+		key := namespacedKey{entry.GetNamespace(), entry.GetKey()}
+		value, ok := values[key]
+		delete(values, key)
+		if !ok || value != entry.GetValue() {
+			puts[key.namespace] = append(puts[key.namespace], sqlbackend.KeyValue{Key: key.key, Value: entry.GetValue()})
+		}
+	}
+
+	for key := range values {
+		backend, err := namespaced(f.backend, key.namespace)
+		if err != nil {
+			return err
+		}
+		if _, err := backend.Delete(ctx, key.key); err != nil {
+			return fmt.Errorf("failed to delete key %d: %w", key.key, err)
+		}
+		f.changes.publish(change{namespace: key.namespace, key: key.key, deleted: true})
+	}
+	for namespace, entries := range puts {
+		backend, err := namespaced(f.backend, namespace)
+		if err != nil {
+			return err
+		}
+		if err := backend.BatchPut(ctx, entries); err != nil {
+			return fmt.Errorf("failed to write entries: %w", err)
+		}
+		for _, entry := range entries {
+			f.changes.publish(change{namespace: namespace, key: entry.Key, value: entry.Value})
+		}
+	}
+
+	if ns != nil {
+		return f.restoreNamespaces(ctx, ns, snapshot.GetNamespaces())
+	}
+	return nil
+}
+
+// restoreNamespaces sets the quotas of a snapshot's namespaces, which exist
+// by now, and deletes the namespaces missing from it, which are empty by now.
+func (f *clusterFSM) restoreNamespaces(ctx context.Context, ns sqlbackend.Namespaces, namespaces []*clusterpb.CreateNamespace) error {
+	keep := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		keep[namespace.GetName()] = true
+		if _, err := ns.SetQuota(ctx, namespace.GetName(), quotaOf(namespace.GetQuota())); err != nil {
+			return fmt.Errorf("failed to restore namespace %q: %w", namespace.GetName(), err)
+		}
+	}
+
+	current, err := ns.ListNamespaces(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read namespaces: %w", err)
+	}
+	for _, namespace := range current {
+		if namespace.Name == sqlbackend.DefaultNamespace || keep[namespace.Name] {
+			continue
+		}
+		if err := ns.DeleteNamespace(ctx, namespace.Name); err != nil {
+			return fmt.Errorf("failed to delete namespace %q: %w", namespace.Name, err)
+		}
+	}
+	return nil
+}
+
+func quotaOf(quota *clusterpb.Quota) sqlbackend.Quota {
+	return sqlbackend.Quota{MaxKeys: quota.GetMaxKeys(), MaxBytes: quota.GetMaxBytes()}
+}
+
+func quotaProto(quota sqlbackend.Quota) *clusterpb.Quota {
+	return clusterpb.Quota_builder{MaxKeys: quota.MaxKeys, MaxBytes: quota.MaxBytes}.Build()
+}
+
+// namespacedKey identifies a key across namespaces.
+type namespacedKey struct {
+	namespace string
+	key       int64
+}
+
+// fsmEntry is an entry of any namespace.
+type fsmEntry struct {
+	namespace string
+	sqlbackend.KeyValue
+}
+
+// entries reads all entries of the backend, in namespace and key order.
+func (f *clusterFSM) entries(ctx context.Context) ([]fsmEntry, error) {
+	names := []string{sqlbackend.DefaultNamespace}
+	if ns, ok := f.backend.(sqlbackend.Namespaces); ok {
+		namespaces, err := ns.ListNamespaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names = names[:0]
+		for _, namespace := range namespaces {
+			names = append(names, namespace.Name)
+		}
+	}
+
+	var entries []fsmEntry
+	for _, name := range names {
+		backend, err := namespaced(f.backend, name)
+		if err != nil {
+			return nil, err
+		}
+		first := int64(math.MinInt64)
+		for {
+			page, err := backend.Scan(ctx, first, math.MaxInt64, scanPageSize)
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range page {
+				entries = append(entries, fsmEntry{namespace: name, KeyValue: entry})
+			}
+
+			if len(page) < scanPageSize || page[len(page)-1].Key == math.MaxInt64 {
+				break
+			}
+			first = page[len(page)-1].Key + 1
+		}
+	}
+	return entries, nil
+}
+
+// fsmSnapshot is an encoded clusterpb.Snapshot.
+type fsmSnapshot []byte
+
+func (s fsmSnapshot) Persist(sink raft.SnapshotSink) error {
+	if _, err := sink.Write(s); err != nil {
+		sink.Cancel()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return sink.Close()
+}
+
+func (s fsmSnapshot) Release() {}
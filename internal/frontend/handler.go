@@ -4,32 +4,71 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"math"
+	"sync"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/dynoinc/gh-go/internal/audit"
 	"github.com/dynoinc/gh-go/internal/sqlbackend"
 	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
 )
 
+// scanPageSize is the number of entries Scan reads from the backend at once.
+const scanPageSize = 1000
+
 type handler struct {
 	frontendpb.UnimplementedFrontendServiceServer
 
-	backend sqlbackend.Backend
+	backend  sqlbackend.Backend
+	changes  *changeHub
+	follower *follower // nil unless replicating from a primary
+	cluster  *cluster  // nil unless a cluster node
+
+	audit   *audit.Log // nil unless auditing writes
+	auditMu sync.Mutex
 }
 
 func New(backend sqlbackend.Backend) frontendpb.FrontendServiceServer {
-	return &handler{backend: backend}
+	return newHandler(backend)
+}
+
+func newHandler(backend sqlbackend.Backend) *handler {
+	h := &handler{backend: backend, changes: newChangeHub()}
+	// Watchers are told to start over, as restored changes aren't published
+	if backups, ok := backend.(sqlbackend.Backupper); ok {
+		backups.OnRestore(h.changes.reset)
+	}
+	return h
 }
 
 func (h *handler) Put(
 	ctx context.Context,
 	req *frontendpb.PutRequest,
 ) (*frontendpb.PutResponse, error) {
-	if err := h.backend.Put(ctx, req.GetKey(), req.GetValue()); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	if h.follower != nil {
+		if err := h.follower.writable(); err != nil {
+			return nil, err
+		}
+		return h.follower.primary.Put(forward(ctx), req)
+	}
+	if h.cluster != nil {
+		return h.cluster.put(ctx, req)
 	}
 
+	err := h.audited(ctx, []keyChange{{key: req.GetKey(), value: req.GetValue()}}, func() (bool, error) {
+		if err := h.store(ctx).Put(ctx, req.GetKey(), req.GetValue()); err != nil {
+			return false, writeError(err)
+		}
+		h.changes.publish(change{namespace: namespaceOf(ctx), key: req.GetKey(), value: req.GetValue()})
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
 	return &frontendpb.PutResponse{}, nil
 }
 
@@ -37,7 +76,15 @@ func (h *handler) Get(
 	ctx context.Context,
 	req *frontendpb.GetRequest,
 ) (*frontendpb.GetResponse, error) {
-	value, err := h.backend.Get(ctx, req.GetKey())
+	h.reportLag(func(md metadata.MD) error { return grpc.SetHeader(ctx, md) })
+	if err := h.linearize(ctx); err != nil {
+		return nil, err
+	}
+	if req.GetRevision() != 0 || req.GetReadTime() != 0 {
+		return h.getVersion(ctx, req)
+	}
+
+	value, err := h.store(ctx).Get(ctx, req.GetKey())
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Error(codes.NotFound, err.Error())
@@ -47,3 +94,148 @@ func (h *handler) Get(
 
 	return frontendpb.GetResponse_builder{Value: value}.Build(), nil
 }
+
+func (h *handler) Delete(
+	ctx context.Context,
+	req *frontendpb.DeleteRequest,
+) (*frontendpb.DeleteResponse, error) {
+	if h.follower != nil {
+		if err := h.follower.writable(); err != nil {
+			return nil, err
+		}
+		return h.follower.primary.Delete(forward(ctx), req)
+	}
+	if h.cluster != nil {
+		return h.cluster.delete(ctx, req)
+	}
+
+	var found bool
+	err := h.audited(ctx, []keyChange{{key: req.GetKey(), deleted: true}}, func() (bool, error) {
+		var err error
+		found, err = h.store(ctx).Delete(ctx, req.GetKey())
+		if err != nil {
+			return false, writeError(err)
+		}
+		if found {
+			h.changes.publish(change{namespace: namespaceOf(ctx), key: req.GetKey(), deleted: true})
+		}
+		return found, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return frontendpb.DeleteResponse_builder{Found: found}.Build(), nil
+}
+
+func (h *handler) Scan(
+	req *frontendpb.ScanRequest,
+	stream grpc.ServerStreamingServer[frontendpb.ScanResponse],
+) error {
+	h.reportLag(stream.SetHeader)
+	if err := h.linearize(stream.Context()); err != nil {
+		return err
+	}
+
+	first, last := req.GetStartKey(), int64(math.MaxInt64)
+	if req.HasEndKey() {
+		if req.GetEndKey() <= first {
+			return nil
+		}
+		last = req.GetEndKey() - 1
+	}
+
+	remaining := req.GetLimit()
+	if remaining <= 0 {
+		remaining = math.MaxInt64
+	}
+
+	// Read in pages so large scans don't hold all entries in memory, pinning
+	// later pages to the revision of the first
+	revision := req.GetRevision()
+	for remaining > 0 {
+		page, pinned, err := h.snapshotScan(stream.Context(), first, last, int(min(remaining, scanPageSize)), revision)
+		if err != nil {
+			return err
+		}
+		revision = pinned
+
+		for _, entry := range page {
+			resp := frontendpb.ScanResponse_builder{Key: entry.Key, Value: entry.Value, Revision: revision}.Build()
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		}
+
+		if len(page) < scanPageSize || page[len(page)-1].Key == last {
+			return nil
+		}
+		remaining -= int64(len(page))
+		first = page[len(page)-1].Key + 1
+	}
+
+	return nil
+}
+
+func (h *handler) BatchPut(
+	ctx context.Context,
+	req *frontendpb.BatchPutRequest,
+) (*frontendpb.BatchPutResponse, error) {
+	if h.follower != nil {
+		if err := h.follower.writable(); err != nil {
+			return nil, err
+		}
+		return h.follower.primary.BatchPut(forward(ctx), req)
+	}
+	if h.cluster != nil {
+		return h.cluster.batchPut(ctx, req)
+	}
+
+	entries := make([]sqlbackend.KeyValue, 0, len(req.GetEntries()))
+	changes := make([]keyChange, 0, len(req.GetEntries()))
+	for _, entry := range req.GetEntries() {
+		entries = append(entries, sqlbackend.KeyValue{Key: entry.GetKey(), Value: entry.GetValue()})
+		changes = append(changes, keyChange{key: entry.GetKey(), value: entry.GetValue()})
+	}
+
+	err := h.audited(ctx, changes, func() (bool, error) {
+		if err := h.store(ctx).BatchPut(ctx, entries); err != nil {
+			return false, writeError(err)
+		}
+		namespace := namespaceOf(ctx)
+		for _, entry := range entries {
+			h.changes.publish(change{namespace: namespace, key: entry.Key, value: entry.Value})
+		}
+		return len(entries) > 0, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &frontendpb.BatchPutResponse{}, nil
+}
+
+func (h *handler) BatchGet(
+	ctx context.Context,
+	req *frontendpb.BatchGetRequest,
+) (*frontendpb.BatchGetResponse, error) {
+	h.reportLag(func(md metadata.MD) error { return grpc.SetHeader(ctx, md) })
+	if err := h.linearize(ctx); err != nil {
+		return nil, err
+	}
+
+	values, revision, err := h.snapshotGet(ctx, req.GetKeys(), req.GetRevision())
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*frontendpb.GetResult, 0, len(req.GetKeys()))
+	for _, key := range req.GetKeys() {
+		value, found := values[key]
+		results = append(results, frontendpb.GetResult_builder{
+			Key:   key,
+			Value: value,
+			Found: found,
+		}.Build())
+	}
+
+	return frontendpb.BatchGetResponse_builder{Results: results, Revision: revision}.Build(), nil
+}
@@ -0,0 +1,101 @@
+package frontend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+const (
+	// defaultHistoryLimit is the number of versions History returns unless
+	// the request sets a limit.
+	defaultHistoryLimit = 100
+	// maxHistoryLimit bounds the limit History requests may set.
+	maxHistoryLimit = 1000
+)
+
+// versioned returns the history of the call's namespace, or an error if the
+// backend keeps none.
+func (h *handler) versioned(ctx context.Context) (sqlbackend.Versioned, error) {
+	v, ok := h.store(ctx).(sqlbackend.Versioned)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "backend does not keep history")
+	}
+	return v, nil
+}
+
+// getVersion serves a Get at a revision or time.
+func (h *handler) getVersion(ctx context.Context, req *frontendpb.GetRequest) (*frontendpb.GetResponse, error) {
+	if req.GetRevision() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "revision must not be negative, got %d", req.GetRevision())
+	}
+	v, err := h.versioned(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var at time.Time
+	if req.GetReadTime() != 0 {
+		at = time.Unix(0, req.GetReadTime())
+	}
+	version, err := v.GetVersion(ctx, req.GetKey(), req.GetRevision(), at)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, sqlbackend.ErrCompacted):
+		return nil, status.Error(codes.OutOfRange, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	case version.Deleted:
+		return nil, status.Errorf(codes.NotFound, "key %d was deleted at revision %d", req.GetKey(), version.Revision)
+	}
+
+	return frontendpb.GetResponse_builder{Value: version.Value}.Build(), nil
+}
+
+func (h *handler) History(
+	ctx context.Context,
+	req *frontendpb.HistoryRequest,
+) (*frontendpb.HistoryResponse, error) {
+	h.reportLag(func(md metadata.MD) error { return grpc.SetHeader(ctx, md) })
+	if err := h.linearize(ctx); err != nil {
+		return nil, err
+	}
+
+	limit := req.GetLimit()
+	if limit < 0 || limit > maxHistoryLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 0 and %d, got %d", maxHistoryLimit, limit)
+	}
+	if limit == 0 {
+		limit = defaultHistoryLimit
+	}
+	v, err := h.versioned(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, compacted, err := v.History(ctx, req.GetKey(), req.GetBeforeRevision(), int(limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := make([]*frontendpb.Version, 0, len(versions))
+	for _, version := range versions {
+		resp = append(resp, frontendpb.Version_builder{
+			Revision:    version.Revision,
+			Value:       version.Value,
+			Deleted:     version.Deleted,
+			CommittedAt: version.CommittedAt.UnixNano(),
+		}.Build())
+	}
+	return frontendpb.HistoryResponse_builder{Versions: resp, Compacted: compacted}.Build(), nil
+}
@@ -0,0 +1,214 @@
+package frontend
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/ratelimit"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/validator"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// instrumentationName identifies the frontend's OpenTelemetry instruments.
+const instrumentationName = "github.com/dynoinc/gh-go/internal/frontend"
+
+// unauthenticatedServices are reachable without the auth token so that
+// orchestrators and tooling keep working when auth is enabled.
+var unauthenticatedServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+// interceptor is one layer of the server middleware stack. Every layer
+// provides both a unary and a stream variant so that streaming RPCs get the
+// same treatment as unary ones.
+type interceptor struct {
+	name   string
+	unary  grpc.UnaryServerInterceptor
+	stream grpc.StreamServerInterceptor
+}
+
+// interceptors returns the middleware stack, outermost first. Optional layers
+// are left out when they are not configured.
+func (c *serverConfig) interceptors() ([]interceptor, error) {
+	metricsUnary, metricsStream, err := newMetricsInterceptors()
+	if err != nil {
+		return nil, err
+	}
+	loggingUnary, loggingStream := newLoggingInterceptors(c.logger, c.logSettings)
+	recoveryOpt := recovery.WithRecoveryHandlerContext(c.recoverPanic)
+
+	chain := []interceptor{
+		{"metrics", metricsUnary, metricsStream},
+		{"recovery", recovery.UnaryServerInterceptor(recoveryOpt), recovery.StreamServerInterceptor(recoveryOpt)},
+		{"logging", loggingUnary, loggingStream},
+	}
+
+	if c.authToken != nil {
+		authFn := c.authenticate
+		chain = append(chain, interceptor{"auth", auth.UnaryServerInterceptor(authFn), auth.StreamServerInterceptor(authFn)})
+	}
+
+	chain = append(chain, interceptor{"callers", auth.UnaryServerInterceptor(c.trustCallers), auth.StreamServerInterceptor(c.trustCallers)})
+
+	if c.rateLimiter != nil {
+		limiter := &rateLimiter{limiter: c.rateLimiter}
+		chain = append(chain, interceptor{"ratelimit", ratelimit.UnaryServerInterceptor(limiter), ratelimit.StreamServerInterceptor(limiter)})
+	}
+
+	chain = append(chain, interceptor{"validation", validator.UnaryServerInterceptor(), validator.StreamServerInterceptor()})
+
+	return chain, nil
+}
+
+// serverOptions converts the middleware stack into gRPC server options.
+func (c *serverConfig) serverOptions(chain []interceptor) []grpc.ServerOption {
+	names := make([]string, 0, len(chain))
+	unary := make([]grpc.UnaryServerInterceptor, 0, len(chain))
+	stream := make([]grpc.StreamServerInterceptor, 0, len(chain))
+	for _, i := range chain {
+		names = append(names, i.name)
+		unary = append(unary, i.unary)
+		stream = append(stream, i.stream)
+	}
+	c.logger.Debug("installing server interceptors", "chain", names)
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
+// recoverPanic logs a handler panic with its stack and hides the details
+// from the caller.
+func (c *serverConfig) recoverPanic(ctx context.Context, p any) error {
+	method, _ := grpc.Method(ctx)
+	c.logger.ErrorContext(ctx, "recovered from panic",
+		"grpc.method", method,
+		"panic", fmt.Sprint(p),
+		"stack", string(debug.Stack()),
+	)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// authenticate checks the bearer token sent in the call metadata.
+func (c *serverConfig) authenticate(ctx context.Context) (context.Context, error) {
+	want := c.authToken()
+	if want == "" {
+		return ctx, nil
+	}
+
+	method, _ := grpc.Method(ctx)
+	for _, prefix := range unauthenticatedServices {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+
+	token, err := auth.AuthFromMD(ctx, "bearer")
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(want)) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "invalid auth token")
+	}
+
+	return ctx, nil
+}
+
+// trustCallers drops the caller named by a call unless it presents the peer
+// token, so only other nodes forwarding writes can audit them as someone
+// else's.
+func (c *serverConfig) trustCallers(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || (len(md.Get(callerPrincipalHeader)) == 0 && len(md.Get(callerPeerHeader)) == 0 && len(md.Get(peerTokenHeader)) == 0) {
+		return ctx, nil
+	}
+
+	var want string
+	if c.peerToken != nil {
+		want = c.peerToken()
+	}
+	tokens := md.Get(peerTokenHeader)
+	trusted := want != "" && len(tokens) == 1 && subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(want)) == 1
+
+	md = md.Copy()
+	md.Delete(peerTokenHeader)
+	if !trusted {
+		md.Delete(callerPrincipalHeader)
+		md.Delete(callerPeerHeader)
+	}
+	return metadata.NewIncomingContext(ctx, md), nil
+}
+
+// rateLimiter rejects calls beyond the limiter's rate instead of queueing them.
+type rateLimiter struct {
+	limiter *rate.Limiter
+}
+
+func (r *rateLimiter) Limit(context.Context) error {
+	if !r.limiter.Allow() {
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return nil
+}
+
+// newMetricsInterceptors records per-method call counts by status code and
+// the number of calls in flight. Latency histograms come from the otelgrpc
+// stats handler.
+func newMetricsInterceptors() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor, error) {
+	meter := otel.Meter(instrumentationName)
+
+	calls, err := meter.Int64Counter("frontend.rpc.calls",
+		otelmetric.WithDescription("Number of completed calls by method and status code."))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create calls counter: %w", err)
+	}
+
+	inFlight, err := meter.Int64UpDownCounter("frontend.rpc.in_flight",
+		otelmetric.WithDescription("Number of calls currently being handled."))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create in-flight counter: %w", err)
+	}
+
+	track := func(ctx context.Context, method string, call func() error) error {
+		methodAttr := attribute.String("rpc.method", method)
+		inFlight.Add(ctx, 1, otelmetric.WithAttributes(methodAttr))
+		err := call()
+		inFlight.Add(ctx, -1, otelmetric.WithAttributes(methodAttr))
+		calls.Add(ctx, 1, otelmetric.WithAttributes(
+			methodAttr,
+			attribute.String("rpc.grpc.status_code", status.Code(err).String()),
+		))
+		return err
+	}
+
+	unary := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var resp any
+		err := track(ctx, info.FullMethod, func() (err error) {
+			resp, err = handler(ctx, req)
+			return err
+		})
+		return resp, err
+	}
+
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return track(ss.Context(), info.FullMethod, func() error {
+			return handler(srv, ss)
+		})
+	}
+
+	return unary, stream, nil
+}
@@ -0,0 +1,176 @@
+package frontend
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// redactedValue replaces redacted string fields in logged payloads.
+const redactedValue = "REDACTED"
+
+// LogOptions controls what the logging interceptor records for each call.
+type LogOptions struct {
+	// SampleRates maps a full method name ("/pkg.Service/Method"), a service
+	// name ("pkg.Service") or "*" to the fraction of calls that are logged.
+	// Methods without a matching entry are always logged. Failed calls are
+	// always logged, even when sampled out.
+	SampleRates map[string]float64
+
+	// Payloads enables logging of request and response messages.
+	Payloads bool
+
+	// RedactFields lists proto field names whose values are masked in logged
+	// payloads, at any nesting depth.
+	RedactFields []string
+}
+
+// LogSettings holds LogOptions that can be replaced while the server runs.
+type LogSettings struct {
+	opts atomic.Pointer[LogOptions]
+}
+
+// NewLogSettings creates settings initialised with opts.
+func NewLogSettings(opts LogOptions) *LogSettings {
+	s := &LogSettings{}
+	s.Update(opts)
+	return s
+}
+
+// Update atomically replaces the current options.
+func (s *LogSettings) Update(opts LogOptions) {
+	s.opts.Store(&opts)
+}
+
+// Load returns the current options.
+func (s *LogSettings) Load() LogOptions {
+	return *s.opts.Load()
+}
+
+// sampled reports whether a call to fullMethod should be logged.
+func (o LogOptions) sampled(fullMethod string) bool {
+	rate, ok := o.SampleRates[fullMethod]
+	if !ok {
+		service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+		rate, ok = o.SampleRates[service]
+	}
+	if !ok {
+		rate, ok = o.SampleRates["*"]
+	}
+	if !ok || rate >= 1 {
+		return true
+	}
+
+	return rand.Float64() < rate
+}
+
+// redact returns a copy of msg with the configured fields masked.
+func (o LogOptions) redact(msg proto.Message) proto.Message {
+	if len(o.RedactFields) == 0 {
+		return msg
+	}
+
+	msg = proto.Clone(msg)
+	redactMessage(msg.ProtoReflect(), o.RedactFields)
+	return msg
+}
+
+func redactMessage(m protoreflect.Message, names []string) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case slices.Contains(names, string(fd.Name())):
+			if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+				m.Set(fd, protoreflect.ValueOfString(redactedValue))
+			} else {
+				m.Clear(fd)
+			}
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			list := v.List()
+			for i := range list.Len() {
+				redactMessage(list.Get(i).Message(), names)
+			}
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsMap():
+			redactMessage(v.Message(), names)
+		}
+		return true
+	})
+}
+
+// callLogger adapts an slog logger to the middleware's logger interface,
+// applying payload redaction from the current settings.
+func callLogger(logger *slog.Logger, settings *LogSettings) logging.Logger {
+	return logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
+		opts := settings.Load()
+		for i := 1; i < len(fields); i += 2 {
+			if m, ok := fields[i].(proto.Message); ok {
+				fields[i] = protojson.Format(opts.redact(m))
+			}
+		}
+		logger.Log(ctx, slog.Level(lvl), msg, fields...)
+	})
+}
+
+// newLoggingInterceptors builds unary and stream logging interceptors that
+// consult the settings on every call to decide on sampling and payloads.
+func newLoggingInterceptors(logger *slog.Logger, settings *LogSettings) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	l := callLogger(logger, settings)
+	payloadEvents := logging.WithLogOnEvents(logging.StartCall, logging.FinishCall, logging.PayloadReceived, logging.PayloadSent)
+
+	plainUnary := logging.UnaryServerInterceptor(l)
+	payloadUnary := logging.UnaryServerInterceptor(l, payloadEvents)
+	plainStream := logging.StreamServerInterceptor(l)
+	payloadStream := logging.StreamServerInterceptor(l, payloadEvents)
+
+	unary := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		opts := settings.Load()
+		if !opts.sampled(info.FullMethod) {
+			resp, err := handler(ctx, req)
+			logSampledOutError(ctx, logger, info.FullMethod, err)
+			return resp, err
+		}
+		if opts.Payloads {
+			return payloadUnary(ctx, req, info, handler)
+		}
+		return plainUnary(ctx, req, info, handler)
+	}
+
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		opts := settings.Load()
+		if !opts.sampled(info.FullMethod) {
+			err := handler(srv, ss)
+			logSampledOutError(ss.Context(), logger, info.FullMethod, err)
+			return err
+		}
+		if opts.Payloads {
+			return payloadStream(srv, ss, info, handler)
+		}
+		return plainStream(srv, ss, info, handler)
+	}
+
+	return unary, stream
+}
+
+// logSampledOutError keeps failures visible for calls skipped by sampling.
+func logSampledOutError(ctx context.Context, logger *slog.Logger, fullMethod string, err error) {
+	if err == nil {
+		return
+	}
+
+	code := status.Code(err)
+	lvl := logging.DefaultServerCodeToLevel(code)
+	logger.Log(ctx, slog.Level(lvl), "finished call",
+		"grpc.method", fullMethod,
+		"grpc.code", code.String(),
+		"grpc.error", err,
+	)
+}
@@ -0,0 +1,152 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+// NamespaceHeader is the request metadata naming the namespace whose keys a
+// call reads and writes. Calls without it use the default namespace.
+const NamespaceHeader = "namespace"
+
+// namespaceOf returns the namespace named by the call in ctx.
+func namespaceOf(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, NamespaceHeader); len(values) > 0 {
+		return values[0]
+	}
+	return sqlbackend.DefaultNamespace
+}
+
+// forwardNamespace names the namespace of the call in ctx on calls made with
+// the returned context, for writes forwarded to another server.
+func forwardNamespace(ctx context.Context) context.Context {
+	namespace := namespaceOf(ctx)
+	if namespace == sqlbackend.DefaultNamespace {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, NamespaceHeader, namespace)
+}
+
+// forward prepares ctx for a write forwarded to another server, which applies
+// it in the same namespace and audits it as the original caller's.
+func forward(ctx context.Context) context.Context {
+	return forwardCaller(forwardNamespace(ctx))
+}
+
+// namespaced returns the backend of a namespace, or an error if the backend
+// has no namespaces other than the default one.
+func namespaced(backend sqlbackend.Backend, namespace string) (sqlbackend.Backend, error) {
+	if namespace == sqlbackend.DefaultNamespace {
+		return backend, nil
+	}
+	ns, ok := backend.(sqlbackend.Namespaces)
+	if !ok {
+		return nil, errors.New("backend does not support namespaces")
+	}
+	return ns.Namespace(namespace), nil
+}
+
+// store returns the backend of the call's namespace, which checkNamespace
+// made sure the backend supports.
+func (h *handler) store(ctx context.Context) sqlbackend.Backend {
+	backend, err := namespaced(h.backend, namespaceOf(ctx))
+	if err != nil {
+		return h.backend
+	}
+	return backend
+}
+
+// checkNamespace rejects calls naming a malformed namespace or, unless this
+// server is a follower, one that doesn't exist. Followers serve namespaces as
+// their changes arrive, so they can't tell a namespace that's yet to be
+// written from a mistyped one.
+func (h *handler) checkNamespace(ctx context.Context) error {
+	namespace := namespaceOf(ctx)
+	if namespace == sqlbackend.DefaultNamespace {
+		return nil
+	}
+	if err := sqlbackend.ValidateNamespace(namespace); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	ns, ok := h.backend.(sqlbackend.Namespaces)
+	if !ok {
+		return status.Error(codes.Unimplemented, "backend does not support namespaces")
+	}
+	if h.follower != nil {
+		return nil
+	}
+
+	// Cluster nodes may not have applied the namespace's creation yet
+	if err := h.linearize(ctx); err != nil {
+		return err
+	}
+	_, err := ns.GetNamespace(ctx, namespace)
+	switch {
+	case errors.Is(err, sqlbackend.ErrNamespaceNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+// namespaceInterceptor checks the namespace of calls to the frontend service
+// before they're handled.
+func (h *handler) namespaceInterceptor() interceptor {
+	prefix := "/" + frontendpb.FrontendService_ServiceDesc.ServiceName + "/"
+	unary := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if strings.HasPrefix(info.FullMethod, prefix) {
+			if err := h.checkNamespace(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, prefix) {
+			if err := h.checkNamespace(ss.Context()); err != nil {
+				return err
+			}
+		}
+		return handler(srv, ss)
+	}
+	return interceptor{"namespace", unary, stream}
+}
+
+// namespaceError maps an error of a namespace change to a status error.
+func namespaceError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, sqlbackend.ErrInvalidNamespace):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, sqlbackend.ErrNamespaceNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, sqlbackend.ErrNamespaceExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, sqlbackend.ErrNamespaceNotEmpty):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// writeError maps an error of a backend write to a status error.
+func writeError(err error) error {
+	switch {
+	case errors.Is(err, sqlbackend.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, sqlbackend.ErrNamespaceNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,471 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+	replicationpb "github.com/dynoinc/gh-go/proto/replication/v1"
+)
+
+// ReplicationLagHeader is the response header in which followers report how
+// far their reads may lag behind the primary, in seconds.
+const ReplicationLagHeader = "replication-lag"
+
+const (
+	// replicationBatch is the maximum number of changes sent at once.
+	replicationBatch = 1000
+	// replicationHeartbeat is how often an idle primary tells followers its
+	// latest sequence number.
+	replicationHeartbeat = time.Second
+	// copyChunkSize is the size of the chunks of backups sent to followers.
+	copyChunkSize = 1 << 20
+)
+
+// ReplicationConfig makes a server a follower of a primary: it applies the
+// primary's change log asynchronously and serves reads from its own copy.
+// Promoting a follower is manual: restart it without replication.
+type ReplicationConfig struct {
+	// Primary is the gRPC target of the primary.
+	Primary string
+	// ForwardWrites forwards writes to the primary instead of rejecting them
+	// with FailedPrecondition. Forwarded writes become visible on the
+	// follower once replicated.
+	ForwardWrites bool
+	// AuthToken, if set, returns the bearer token presented to the primary.
+	AuthToken func() string
+	// PeerToken, if set, returns the primary's peer token, so it audits
+	// forwarded writes as their callers' rather than the follower's.
+	PeerToken func() string
+	// DialOptions configure the connection to the primary, which uses
+	// insecure credentials unless overridden.
+	DialOptions []grpc.DialOption
+}
+
+// WithReplication runs the server as a follower. The backend must implement
+// sqlbackend.ChangeLog, and sqlbackend.Backupper to start over from a copy of
+// the primary when changes it needs were trimmed from the primary's log.
+func WithReplication(config ReplicationConfig) ServerOption {
+	return func(c *serverConfig) {
+		c.replication = &config
+	}
+}
+
+// replicationServer streams the change log to followers. Any server whose
+// backend keeps a change log serves it, so followers can be chained.
+type replicationServer struct {
+	replicationpb.UnimplementedReplicationServiceServer
+
+	log     sqlbackend.ChangeLog
+	backups sqlbackend.Backupper // nil if the backend can't be copied
+	changes *changeHub
+}
+
+func (r *replicationServer) StreamChanges(
+	req *replicationpb.StreamChangesRequest,
+	stream grpc.ServerStreamingServer[replicationpb.StreamChangesResponse],
+) error {
+	ctx := stream.Context()
+
+	// Committed changes are published to the hub, which wakes the stream up
+	w := r.changes.subscribeAll()
+	defer func() { r.changes.unsubscribe(w) }()
+
+	heartbeat := time.NewTicker(replicationHeartbeat)
+	defer heartbeat.Stop()
+
+	after := req.GetAfterSeq()
+	idle := true // sends the latest sequence number right away
+	for {
+		last, err := r.log.LastSeq(ctx)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		changes, err := r.log.Changes(ctx, after, replicationBatch)
+		if errors.Is(err, sqlbackend.ErrChangesTrimmed) {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		if len(changes) > 0 || idle {
+			batch := make([]*replicationpb.Change, 0, len(changes))
+			for _, change := range changes {
+				batch = append(batch, replicationpb.Change_builder{
+					Seq:         change.Seq,
+					Namespace:   change.Namespace,
+					Key:         change.Key,
+					Value:       change.Value,
+					Deleted:     change.Deleted,
+					CommittedAt: change.CommittedAt.UnixNano(),
+				}.Build())
+				after = change.Seq
+			}
+
+			// Changes committed since reading last are sent next time
+			resp := replicationpb.StreamChangesResponse_builder{Changes: batch, LastSeq: max(last, after)}.Build()
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		}
+
+		idle = false
+		if len(changes) == replicationBatch {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-w.changes:
+			drain(w.changes)
+		case <-w.dropped:
+			w = r.changes.subscribeAll()
+		case <-heartbeat.C:
+			idle = true
+		}
+	}
+}
+
+func (r *replicationServer) CopyDatabase(
+	_ *replicationpb.CopyDatabaseRequest,
+	stream grpc.ServerStreamingServer[replicationpb.CopyDatabaseResponse],
+) error {
+	if r.backups == nil {
+		return status.Error(codes.Unimplemented, "backend can't be backed up")
+	}
+
+	dir, err := os.MkdirTemp("", "gh-go-copy-")
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "copy.db")
+	if _, err := r.backups.Backup(stream.Context(), path); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	metadata, err := os.ReadFile(sqlbackend.MetadataPath(path))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer f.Close()
+
+	buf := make([]byte, copyChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 || metadata != nil {
+			resp := replicationpb.CopyDatabaseResponse_builder{Metadata: metadata, Data: buf[:n]}.Build()
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+			metadata = nil
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+}
+
+// drain discards buffered changes, which only serve as wake-ups.
+func drain(changes chan change) {
+	for {
+		select {
+		case <-changes:
+		default:
+			return
+		}
+	}
+}
+
+// follower applies a primary's change log to the local backend.
+type follower struct {
+	log     sqlbackend.ChangeLog
+	changes *changeHub
+	logger  *slog.Logger
+
+	conn          *grpc.ClientConn
+	primary       frontendpb.FrontendServiceClient
+	forwardWrites bool
+
+	mu         sync.Mutex
+	caughtUp   bool      // as of the last message from the primary
+	caughtUpAt time.Time // when the follower was last known to be caught up
+	lastHeard  time.Time
+}
+
+func newFollower(config *ReplicationConfig, log sqlbackend.ChangeLog, changes *changeHub, logger *slog.Logger) (*follower, error) {
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if config.AuthToken != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearerToken(config.AuthToken)))
+	}
+	if config.PeerToken != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(peerToken(config.PeerToken)))
+	}
+	dialOpts = append(dialOpts, config.DialOptions...)
+
+	conn, err := grpc.NewClient(config.Primary, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to primary: %w", err)
+	}
+
+	return &follower{
+		log:           log,
+		changes:       changes,
+		logger:        logger,
+		conn:          conn,
+		primary:       frontendpb.NewFrontendServiceClient(conn),
+		forwardWrites: config.ForwardWrites,
+		caughtUpAt:    time.Now(),
+	}, nil
+}
+
+// run replicates until ctx is done, reconnecting with backoff.
+func (f *follower) run(ctx context.Context) {
+	const minBackoff, maxBackoff = 100 * time.Millisecond, 10 * time.Second
+
+	backoff := minBackoff
+	for {
+		start := time.Now()
+		err := f.replicate(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		f.mu.Lock()
+		f.caughtUp = false
+		if f.lastHeard.After(start) {
+			backoff = minBackoff
+		}
+		f.mu.Unlock()
+
+		f.logger.WarnContext(ctx, "replication stream failed", "error", err, "retry_in", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+// replicate streams changes following the local log's last one until the
+// stream fails.
+func (f *follower) replicate(ctx context.Context) error {
+	after, err := f.log.LastSeq(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read last sequence number: %w", err)
+	}
+
+	stream, err := replicationpb.NewReplicationServiceClient(f.conn).StreamChanges(ctx,
+		replicationpb.StreamChangesRequest_builder{AfterSeq: after}.Build())
+	if err != nil {
+		return err
+	}
+
+	for first := true; ; first = false {
+		resp, err := stream.Recv()
+		if status.Code(err) == codes.OutOfRange {
+			// The primary trimmed changes the follower needs, so it starts
+			// over from a copy
+			f.logger.WarnContext(ctx, "changes to replicate were trimmed; copying the primary", "seq", after, "error", err)
+			if err := f.copyPrimary(ctx); err != nil {
+				return fmt.Errorf("failed to copy primary: %w", err)
+			}
+			return f.replicate(ctx)
+		}
+		if err != nil {
+			return err
+		}
+
+		if first && resp.GetLastSeq() < after {
+			f.logger.ErrorContext(ctx, "follower is ahead of the primary; its data may have diverged",
+				"seq", after, "primary_seq", resp.GetLastSeq())
+		}
+
+		changes := make([]sqlbackend.Change, 0, len(resp.GetChanges()))
+		for _, c := range resp.GetChanges() {
+			changes = append(changes, sqlbackend.Change{
+				Seq:         c.GetSeq(),
+				Namespace:   c.GetNamespace(),
+				Key:         c.GetKey(),
+				Value:       c.GetValue(),
+				Deleted:     c.GetDeleted(),
+				CommittedAt: time.Unix(0, c.GetCommittedAt()),
+			})
+		}
+		if len(changes) > 0 {
+			if err := f.log.Apply(ctx, changes); err != nil {
+				return fmt.Errorf("failed to apply changes: %w", err)
+			}
+			for _, c := range changes {
+				f.changes.publish(change{namespace: c.Namespace, key: c.Key, value: c.Value, deleted: c.Deleted})
+			}
+			after = changes[len(changes)-1].Seq
+		}
+
+		now := time.Now()
+		f.mu.Lock()
+		f.lastHeard = now
+		f.caughtUp = after >= resp.GetLastSeq()
+		if f.caughtUp {
+			f.caughtUpAt = now
+		}
+		f.mu.Unlock()
+	}
+}
+
+// copyPrimary replaces the local data with a backup of the primary's.
+func (f *follower) copyPrimary(ctx context.Context) error {
+	backups, ok := f.log.(sqlbackend.Backupper)
+	if !ok {
+		return fmt.Errorf("backend can't restore a copy")
+	}
+
+	dir, err := os.MkdirTemp("", "gh-go-copy-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "copy.db")
+	if err := f.receiveCopy(ctx, path); err != nil {
+		return err
+	}
+	if _, err := backups.Restore(ctx, path); err != nil {
+		return fmt.Errorf("failed to restore copy: %w", err)
+	}
+	return nil
+}
+
+// receiveCopy writes a backup streamed by the primary to path, and its
+// metadata next to it.
+func (f *follower) receiveCopy(ctx context.Context, path string) error {
+	stream, err := replicationpb.NewReplicationServiceClient(f.conn).CopyDatabase(ctx, &replicationpb.CopyDatabaseRequest{})
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return out.Close()
+		}
+		if err != nil {
+			return err
+		}
+
+		if metadata := resp.GetMetadata(); len(metadata) > 0 {
+			if err := os.WriteFile(sqlbackend.MetadataPath(path), metadata, 0o600); err != nil {
+				return err
+			}
+		}
+		if _, err := out.Write(resp.GetData()); err != nil {
+			return err
+		}
+	}
+}
+
+// lag returns how far reads may lag behind the primary: zero while caught up
+// and in touch with the primary, otherwise the time since the follower was
+// last known to be caught up.
+func (f *follower) lag() time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.caughtUp && time.Since(f.lastHeard) < 2*replicationHeartbeat {
+		return 0
+	}
+	return time.Since(f.caughtUpAt)
+}
+
+// writable rejects writes unless they are forwarded to the primary.
+func (f *follower) writable() error {
+	if !f.forwardWrites {
+		return status.Error(codes.FailedPrecondition, "server is a read-only follower; write to the primary")
+	}
+	return nil
+}
+
+// reportLag sends a follower's replication lag in the response header.
+func (h *handler) reportLag(setHeader func(metadata.MD) error) {
+	if h.follower == nil {
+		return
+	}
+	lag := strconv.FormatFloat(h.follower.lag().Seconds(), 'f', 3, 64)
+	setHeader(metadata.Pairs(ReplicationLagHeader, lag))
+}
+
+// startFollower starts replicating and returns a function stopping it.
+func startFollower(f *follower) (func(), error) {
+	gauge, err := otel.Meter(instrumentationName).Float64ObservableGauge("frontend.replication.lag",
+		otelmetric.WithDescription("Time since the follower was last caught up with the primary."),
+		otelmetric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	registration, err := otel.Meter(instrumentationName).RegisterCallback(func(_ context.Context, o otelmetric.Observer) error {
+		o.ObserveFloat64(gauge, f.lag().Seconds())
+		return nil
+	}, gauge)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Go(func() { f.run(ctx) })
+
+	return func() {
+		cancel()
+		wg.Wait()
+		registration.Unregister()
+		f.conn.Close()
+	}, nil
+}
+
+// bearerToken presents a token to the primary.
+type bearerToken func() string
+
+func (t bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	token := t()
+	if token == "" {
+		return nil, nil
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+func (t bearerToken) RequireTransportSecurity() bool {
+	return false
+}
@@ -5,8 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 
-	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
-	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -21,8 +20,11 @@ import (
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
+	"github.com/dynoinc/gh-go/internal/audit"
 	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	clusterpb "github.com/dynoinc/gh-go/proto/cluster/v1"
 	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+	replicationpb "github.com/dynoinc/gh-go/proto/replication/v1"
 )
 
 // ServerOption configures the server.
@@ -30,6 +32,15 @@ type ServerOption func(*serverConfig)
 
 type serverConfig struct {
 	noopTelemetry bool
+	logger        *slog.Logger
+	logSettings   *LogSettings
+	authToken     func() string
+	peerToken     func() string
+	rateLimiter   *rate.Limiter
+	healthServer  *health.Server
+	replication   *ReplicationConfig
+	cluster       *ClusterConfig
+	auditLog      *audit.Log
 }
 
 // WithNoopTelemetry disables OTLP exporters and uses noop telemetry providers.
@@ -40,10 +51,74 @@ func WithNoopTelemetry() ServerOption {
 	}
 }
 
+// WithLogger sets the logger used for call logging. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) ServerOption {
+	return func(c *serverConfig) {
+		c.logger = logger
+	}
+}
+
+// WithLogSettings sets the call logging settings. The settings may be updated
+// while the server runs to change sampling and payload logging.
+func WithLogSettings(settings *LogSettings) ServerOption {
+	return func(c *serverConfig) {
+		c.logSettings = settings
+	}
+}
+
+// WithAuthToken requires callers to present the token as a bearer token in
+// the "authorization" metadata. Health and reflection services stay open.
+func WithAuthToken(token string) ServerOption {
+	return WithAuthTokenFunc(func() string { return token })
+}
+
+// WithAuthTokenFunc is like WithAuthToken but reads the token on every call,
+// so it can be rotated while the server runs. An empty token disables auth.
+func WithAuthTokenFunc(token func() string) ServerOption {
+	return func(c *serverConfig) {
+		c.authToken = token
+	}
+}
+
+// WithPeerToken trusts calls presenting the token in the "gh-go-peer-token"
+// metadata to name the caller of the writes they forward, which are then
+// audited as the caller's. Other calls are audited as their own.
+func WithPeerToken(token string) ServerOption {
+	return WithPeerTokenFunc(func() string { return token })
+}
+
+// WithPeerTokenFunc is like WithPeerToken but reads the token on every call,
+// so it can be rotated while the server runs. An empty token trusts no call.
+func WithPeerTokenFunc(token func() string) ServerOption {
+	return func(c *serverConfig) {
+		c.peerToken = token
+	}
+}
+
+// WithRateLimiter rejects calls with ResourceExhausted once the limiter runs
+// out of tokens. The limiter may be adjusted while the server runs.
+func WithRateLimiter(limiter *rate.Limiter) ServerOption {
+	return func(c *serverConfig) {
+		c.rateLimiter = limiter
+	}
+}
+
+// WithHealthServer registers the given health server instead of a new one,
+// so the caller can change the serving status, e.g. to drain the server.
+func WithHealthServer(healthServer *health.Server) ServerOption {
+	return func(c *serverConfig) {
+		c.healthServer = healthServer
+	}
+}
+
 // NewServer creates a new gRPC server with health checks, reflection, and OpenTelemetry instrumentation.
-// The returned cleanup function must be called during shutdown to flush telemetry exporters.
+// The returned cleanup function must be called during shutdown to stop replication or the cluster node and
+// flush telemetry exporters.
 func NewServer(ctx context.Context, backend sqlbackend.Backend, opts ...ServerOption) (*grpc.Server, func(), error) {
-	cfg := &serverConfig{}
+	cfg := &serverConfig{
+		logger:      slog.Default(),
+		logSettings: NewLogSettings(LogOptions{}),
+	}
 	for _, opt := range opts {
 		opt(cfg)
 	}
@@ -58,24 +133,92 @@ func NewServer(ctx context.Context, backend sqlbackend.Backend, opts ...ServerOp
 		cleanup = cleanupFn
 	}
 
-	// Create gRPC server with middleware chain
-	server := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(
-			recovery.UnaryServerInterceptor(),
-			logging.UnaryServerInterceptor(
-				logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
-					slog.Log(ctx, slog.Level(lvl), msg, fields...)
-				}),
-			),
-		),
+	chain, err := cfg.interceptors()
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	// Calls are checked for their namespace last, once they're authorized
+	h := newHandler(backend)
+	h.audit = cfg.auditLog
+	chain = append(chain, h.namespaceInterceptor())
+
+	// Create gRPC server with the same middleware for unary and stream calls
+	server := grpc.NewServer(append(
+		cfg.serverOptions(chain),
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
-	)
+	)...)
+
+	if cfg.replication != nil && cfg.cluster != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("a server cannot both replicate from a primary and be a cluster node")
+	}
 
 	// Register the main service
-	frontendpb.RegisterFrontendServiceServer(server, New(backend))
+	frontendpb.RegisterFrontendServiceServer(server, h)
+
+	// Serve the change log to followers, and follow a primary if configured
+	changeLog, ok := backend.(sqlbackend.ChangeLog)
+	if ok {
+		backups, _ := backend.(sqlbackend.Backupper)
+		replicationpb.RegisterReplicationServiceServer(server, &replicationServer{log: changeLog, backups: backups, changes: h.changes})
+	}
+	if cfg.replication != nil {
+		if !ok {
+			cleanup()
+			return nil, nil, fmt.Errorf("replication requires a backend with a change log")
+		}
+
+		f, err := newFollower(cfg.replication, changeLog, h.changes, cfg.logger)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		stopFollower, err := startFollower(f)
+		if err != nil {
+			f.conn.Close()
+			cleanup()
+			return nil, nil, fmt.Errorf("failed to start replication: %w", err)
+		}
+		h.follower = f
+
+		otelCleanup := cleanup
+		cleanup = func() {
+			stopFollower()
+			otelCleanup()
+		}
+	}
+
+	// Commit writes through Raft if running as a cluster node
+	if cfg.cluster != nil {
+		c, err := newCluster(cfg.cluster, backend, h.changes, cfg.logger)
+		if err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("failed to start cluster node: %w", err)
+		}
+		clusterpb.RegisterClusterServiceServer(server, c)
+		c.audited = h.audited
+		h.cluster = c
+		if ns := cfg.cluster.Namespaces; ns != nil {
+			ns.mu.Lock()
+			ns.backend, _ = backend.(sqlbackend.Namespaces)
+			ns.cluster = c
+			ns.mu.Unlock()
+		}
+
+		otelCleanup := cleanup
+		cleanup = func() {
+			c.close()
+			otelCleanup()
+		}
+	}
 
 	// Register health check service
-	healthServer := health.NewServer()
+	healthServer := cfg.healthServer
+	if healthServer == nil {
+		healthServer = health.NewServer()
+	}
 	healthServer.SetServingStatus(frontendpb.FrontendService_ServiceDesc.ServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
 	grpc_health_v1.RegisterHealthServer(server, healthServer)
 
@@ -0,0 +1,83 @@
+package frontend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+// snapshotGet reads keys as of revision, or the latest revision if zero,
+// returning the values of the keys found and the revision read. Backends
+// without snapshots only serve the latest revision, reported as zero.
+func (h *handler) snapshotGet(ctx context.Context, keys []int64, revision int64) (map[int64]string, int64, error) {
+	if revision < 0 {
+		return nil, 0, status.Errorf(codes.InvalidArgument, "revision must not be negative, got %d", revision)
+	}
+	if s, ok := h.store(ctx).(sqlbackend.Snapshots); ok {
+		values, revision, err := s.SnapshotGet(ctx, keys, revision)
+		if err != nil {
+			return nil, 0, snapshotError(err)
+		}
+		return values, revision, nil
+	}
+	if revision != 0 {
+		return nil, 0, status.Error(codes.Unimplemented, "backend does not serve snapshots")
+	}
+
+	values := make(map[int64]string, len(keys))
+	for _, key := range keys {
+		value, err := h.store(ctx).Get(ctx, key)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, 0, status.Error(codes.Internal, err.Error())
+		}
+		values[key] = value
+	}
+	return values, 0, nil
+}
+
+// snapshotScan scans like snapshotGet reads.
+func (h *handler) snapshotScan(
+	ctx context.Context,
+	first, last int64,
+	limit int,
+	revision int64,
+) ([]sqlbackend.KeyValue, int64, error) {
+	if revision < 0 {
+		return nil, 0, status.Errorf(codes.InvalidArgument, "revision must not be negative, got %d", revision)
+	}
+	if s, ok := h.store(ctx).(sqlbackend.Snapshots); ok {
+		entries, revision, err := s.SnapshotScan(ctx, first, last, limit, revision)
+		if err != nil {
+			return nil, 0, snapshotError(err)
+		}
+		return entries, revision, nil
+	}
+	if revision != 0 {
+		return nil, 0, status.Error(codes.Unimplemented, "backend does not serve snapshots")
+	}
+
+	entries, err := h.store(ctx).Scan(ctx, first, last, limit)
+	if err != nil {
+		return nil, 0, status.Error(codes.Internal, err.Error())
+	}
+	return entries, 0, nil
+}
+
+func snapshotError(err error) error {
+	switch {
+	case errors.Is(err, sqlbackend.ErrCompacted):
+		return status.Errorf(codes.OutOfRange, "snapshot is no longer available: %v", err)
+	case errors.Is(err, sqlbackend.ErrFutureRevision):
+		return status.Error(codes.OutOfRange, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,359 @@
+package frontend
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protodelim"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+const (
+	// defaultChunkSize is the number of entries per transaction or response
+	// of Import and Export, unless the request sets one.
+	defaultChunkSize = 500
+	// maxChunkSize bounds the chunk size requests may set.
+	maxChunkSize = 10000
+	// maxEntrySize bounds the encoded size of a single imported entry.
+	maxEntrySize = 16 << 20
+)
+
+// chunkSize returns the chunk size requested, or the default if zero.
+func chunkSize(requested int64) (int, error) {
+	if requested < 0 || requested > maxChunkSize {
+		return 0, status.Errorf(codes.InvalidArgument, "chunk_size must be between 0 and %d, got %d", maxChunkSize, requested)
+	}
+	if requested == 0 {
+		return defaultChunkSize, nil
+	}
+	return int(requested), nil
+}
+
+func (h *handler) Import(stream grpc.ClientStreamingServer[frontendpb.ImportRequest, frontendpb.ImportResponse]) error {
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return stream.SendAndClose(&frontendpb.ImportResponse{})
+	}
+	if err != nil {
+		return err
+	}
+
+	size, err := chunkSize(first.GetChunkSize())
+	if err != nil {
+		return err
+	}
+	r := &importReader{stream: stream, data: first.GetData()}
+	dec, err := newDecoder(first.GetFormat(), r)
+	if err != nil {
+		return err
+	}
+
+	var chunk []*frontendpb.PutRequest
+	var entries, chunks int64
+	store := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		if !first.GetDryRun() {
+			req := frontendpb.BatchPutRequest_builder{Entries: chunk}.Build()
+			if _, err := h.BatchPut(stream.Context(), req); err != nil {
+				s := status.Convert(err)
+				return status.Errorf(s.Code(), "failed to store entries after %d imported: %s", entries, s.Message())
+			}
+			chunks++
+		}
+		entries += int64(len(chunk))
+		chunk = nil
+		return nil
+	}
+
+	for {
+		entry, err := dec.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if r.err != nil {
+			return r.err
+		}
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v (%d entries imported before it)", err, entries)
+		}
+
+		chunk = append(chunk, frontendpb.PutRequest_builder{Key: entry.Key, Value: entry.Value}.Build())
+		if len(chunk) >= size {
+			if err := store(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := store(); err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(frontendpb.ImportResponse_builder{Entries: entries, Chunks: chunks}.Build())
+}
+
+func (h *handler) Export(
+	req *frontendpb.ExportRequest,
+	stream grpc.ServerStreamingServer[frontendpb.ExportResponse],
+) error {
+	h.reportLag(stream.SetHeader)
+	if err := h.linearize(stream.Context()); err != nil {
+		return err
+	}
+
+	size, err := chunkSize(req.GetChunkSize())
+	if err != nil {
+		return err
+	}
+	if _, err := newEncoder(req.GetFormat(), io.Discard); err != nil {
+		return err
+	}
+
+	first, last := req.GetStartKey(), int64(math.MaxInt64)
+	if req.HasEndKey() {
+		if req.GetEndKey() <= first {
+			return nil
+		}
+		last = req.GetEndKey() - 1
+	}
+
+	var entries int64
+	for {
+		page, err := h.store(stream.Context()).Scan(stream.Context(), first, last, size)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		if len(page) == 0 {
+			return nil
+		}
+
+		var buf bytes.Buffer
+		enc, _ := newEncoder(req.GetFormat(), &buf)
+		for _, entry := range page {
+			if err := enc.encode(entry); err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+		if err := enc.flush(); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		end := page[len(page)-1].Key
+		entries += int64(len(page))
+		if err := stream.Send(frontendpb.ExportResponse_builder{
+			Data:    buf.Bytes(),
+			LastKey: end,
+			Entries: entries,
+		}.Build()); err != nil {
+			return err
+		}
+
+		if len(page) < size || end == last {
+			return nil
+		}
+		first = end + 1
+	}
+}
+
+// importReader reads the data of an Import stream, keeping the error that
+// failed receiving it apart from errors decoding it.
+type importReader struct {
+	stream grpc.ClientStreamingServer[frontendpb.ImportRequest, frontendpb.ImportResponse]
+	data   []byte
+	err    error
+}
+
+func (r *importReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		req, err := r.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+		if err != nil {
+			r.err = err
+			return 0, err
+		}
+		r.data = req.GetData()
+	}
+
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+// decoder reads entries in one of the formats, returning io.EOF after the
+// last one. Errors locate the entry they occurred at.
+type decoder interface {
+	next() (sqlbackend.KeyValue, error)
+}
+
+func newDecoder(format frontendpb.Format, r io.Reader) (decoder, error) {
+	switch format {
+	case frontendpb.Format_FORMAT_JSONL:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, maxEntrySize)
+		return &jsonlDecoder{scanner: scanner}, nil
+	case frontendpb.Format_FORMAT_CSV:
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = 2
+		cr.ReuseRecord = true
+		return &csvDecoder{r: cr}, nil
+	case frontendpb.Format_FORMAT_DELIMITED_PROTO:
+		return &protoDecoder{r: bufio.NewReader(r)}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported format %v", format)
+	}
+}
+
+type jsonlDecoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (d *jsonlDecoder) next() (sqlbackend.KeyValue, error) {
+	for d.scanner.Scan() {
+		d.line++
+		if len(bytes.TrimSpace(d.scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var entry struct {
+			Key   *int64 `json:"key"`
+			Value string `json:"value"`
+		}
+		if err := json.Unmarshal(d.scanner.Bytes(), &entry); err != nil {
+			return sqlbackend.KeyValue{}, fmt.Errorf("line %d: %w", d.line, err)
+		}
+		if entry.Key == nil {
+			return sqlbackend.KeyValue{}, fmt.Errorf("line %d: missing key", d.line)
+		}
+		return sqlbackend.KeyValue{Key: *entry.Key, Value: entry.Value}, nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		return sqlbackend.KeyValue{}, fmt.Errorf("line %d: %w", d.line+1, err)
+	}
+	return sqlbackend.KeyValue{}, io.EOF
+}
+
+type csvDecoder struct {
+	r       *csv.Reader
+	records int
+}
+
+func (d *csvDecoder) next() (sqlbackend.KeyValue, error) {
+	for {
+		record, err := d.r.Read()
+		if errors.Is(err, io.EOF) {
+			return sqlbackend.KeyValue{}, io.EOF
+		}
+		if err != nil {
+			return sqlbackend.KeyValue{}, err // locates itself
+		}
+		d.records++
+
+		line, _ := d.r.FieldPos(0)
+		if d.records == 1 && record[0] == "key" && record[1] == "value" {
+			continue // header
+		}
+		key, err := strconv.ParseInt(record[0], 10, 64)
+		if err != nil {
+			return sqlbackend.KeyValue{}, fmt.Errorf("line %d: invalid key %q", line, record[0])
+		}
+		if !utf8.ValidString(record[1]) {
+			return sqlbackend.KeyValue{}, fmt.Errorf("line %d: value is not valid UTF-8", line)
+		}
+		return sqlbackend.KeyValue{Key: key, Value: record[1]}, nil
+	}
+}
+
+type protoDecoder struct {
+	r       *bufio.Reader
+	entries int
+}
+
+func (d *protoDecoder) next() (sqlbackend.KeyValue, error) {
+	var req frontendpb.PutRequest
+	err := protodelim.UnmarshalOptions{MaxSize: maxEntrySize}.UnmarshalFrom(d.r, &req)
+	if errors.Is(err, io.EOF) {
+		return sqlbackend.KeyValue{}, io.EOF
+	}
+	d.entries++
+	if err != nil {
+		return sqlbackend.KeyValue{}, fmt.Errorf("entry %d: %w", d.entries, err)
+	}
+	return sqlbackend.KeyValue{Key: req.GetKey(), Value: req.GetValue()}, nil
+}
+
+// encoder writes entries in one of the formats.
+type encoder interface {
+	encode(entry sqlbackend.KeyValue) error
+	flush() error
+}
+
+func newEncoder(format frontendpb.Format, w io.Writer) (encoder, error) {
+	switch format {
+	case frontendpb.Format_FORMAT_JSONL:
+		return &jsonlEncoder{enc: json.NewEncoder(w)}, nil
+	case frontendpb.Format_FORMAT_CSV:
+		return &csvEncoder{w: csv.NewWriter(w)}, nil
+	case frontendpb.Format_FORMAT_DELIMITED_PROTO:
+		return &protoEncoder{w: w}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported format %v", format)
+	}
+}
+
+type jsonlEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *jsonlEncoder) encode(entry sqlbackend.KeyValue) error {
+	return e.enc.Encode(struct {
+		Key   int64  `json:"key"`
+		Value string `json:"value"`
+	}{entry.Key, entry.Value})
+}
+
+func (e *jsonlEncoder) flush() error {
+	return nil
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) encode(entry sqlbackend.KeyValue) error {
+	return e.w.Write([]string{strconv.FormatInt(entry.Key, 10), entry.Value})
+}
+
+func (e *csvEncoder) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type protoEncoder struct {
+	w io.Writer
+}
+
+func (e *protoEncoder) encode(entry sqlbackend.KeyValue) error {
+	_, err := protodelim.MarshalTo(e.w, frontendpb.PutRequest_builder{Key: entry.Key, Value: entry.Value}.Build())
+	return err
+}
+
+func (e *protoEncoder) flush() error {
+	return nil
+}
@@ -0,0 +1,134 @@
+package frontend
+
+import (
+	"slices"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+// watchBuffer is the number of changes a watcher may fall behind by before it
+// is disconnected.
+const watchBuffer = 1024
+
+type change struct {
+	namespace string
+	key       int64
+	value     string
+	deleted   bool
+}
+
+// watcher is a single Watch stream's subscription.
+type watcher struct {
+	all       bool // watches every namespace
+	namespace string
+	keys      []int64
+	changes   chan change
+	dropped   chan struct{} // closed when the hub drops the watcher
+	err       error         // why the watcher was dropped
+}
+
+// changeHub fans committed changes out to watchers. Publishing never blocks:
+// a watcher whose buffer is full is dropped instead.
+type changeHub struct {
+	mu       sync.Mutex
+	watchers map[*watcher]struct{}
+}
+
+func newChangeHub() *changeHub {
+	return &changeHub{watchers: make(map[*watcher]struct{})}
+}
+
+// subscribe watches the keys of a namespace, or all its keys if none are
+// given.
+func (h *changeHub) subscribe(namespace string, keys []int64) *watcher {
+	return h.add(&watcher{namespace: namespace, keys: keys})
+}
+
+// subscribeAll watches every key of every namespace.
+func (h *changeHub) subscribeAll() *watcher {
+	return h.add(&watcher{all: true})
+}
+
+func (h *changeHub) add(w *watcher) *watcher {
+	w.changes = make(chan change, watchBuffer)
+	w.dropped = make(chan struct{})
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.watchers[w] = struct{}{}
+	return w
+}
+
+func (h *changeHub) unsubscribe(w *watcher) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.watchers, w)
+}
+
+func (h *changeHub) publish(c change) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for w := range h.watchers {
+		if !w.all && (c.namespace != w.namespace || len(w.keys) > 0 && !slices.Contains(w.keys, c.key)) {
+			continue
+		}
+
+		select {
+		case w.changes <- c:
+		default:
+			h.drop(w, status.Error(codes.ResourceExhausted, "watcher fell behind"))
+		}
+	}
+}
+
+// reset drops every watcher, as all data was replaced without publishing the
+// changes.
+func (h *changeHub) reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for w := range h.watchers {
+		h.drop(w, status.Error(codes.Aborted, "data was restored from a backup"))
+	}
+}
+
+// drop ends a watcher's subscription with err. h.mu must be held.
+func (h *changeHub) drop(w *watcher, err error) {
+	w.err = err
+	close(w.dropped)
+	delete(h.watchers, w)
+}
+
+func (h *handler) Watch(
+	req *frontendpb.WatchRequest,
+	stream grpc.ServerStreamingServer[frontendpb.WatchResponse],
+) error {
+	w := h.changes.subscribe(namespaceOf(stream.Context()), req.GetKeys())
+	defer h.changes.unsubscribe(w)
+
+	// Tell the client the subscription is in place, so changes it makes from
+	// now on are guaranteed to be streamed
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-w.dropped:
+			return w.err
+		case c := <-w.changes:
+			resp := frontendpb.WatchResponse_builder{Key: c.key, Value: c.value, Deleted: c.deleted}.Build()
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package listen opens the network listeners the frontend serves on.
+//
+// Addresses are written as URLs: "tcp://host:port" (or just "host:port") for
+// TCP and "unix:///path/to/socket" for Unix domain sockets, matching the
+// target syntax accepted by gRPC clients.
+package listen
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// listenFDsStart is the first file descriptor passed by systemd socket
+// activation (SD_LISTEN_FDS_START).
+const listenFDsStart = 3
+
+// Listen opens a listener for each address. Unix sockets are created with the
+// given file mode; a stale socket file left behind by a previous run is
+// removed first, unless a server still accepts connections on it. On error,
+// listeners opened so far are closed.
+func Listen(addrs []string, socketMode fs.FileMode) ([]net.Listener, error) {
+	var listeners []net.Listener
+	for _, addr := range addrs {
+		lis, err := listenOne(addr, socketMode)
+		if err != nil {
+			closeAll(listeners)
+			return nil, err
+		}
+		listeners = append(listeners, lis)
+	}
+
+	return listeners, nil
+}
+
+func listenOne(addr string, socketMode fs.FileMode) (net.Listener, error) {
+	if path, ok := strings.CutPrefix(addr, "unix://"); ok {
+		return listenUnix(path, socketMode)
+	}
+
+	hostPort := strings.TrimPrefix(addr, "tcp://")
+	lis, err := net.Listen("tcp", hostPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return lis, nil
+}
+
+func listenUnix(path string, socketMode fs.FileMode) (net.Listener, error) {
+	if path == "" {
+		return nil, errors.New("unix socket path is empty")
+	}
+
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode().Type() != fs.ModeSocket {
+			return nil, fmt.Errorf("refusing to replace %s: not a socket", path)
+		}
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("refusing to replace %s: a server is listening on it", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+		}
+	}
+
+	// Create the socket accessible to the owner only, so no one can connect
+	// before its mode is set. The umask is process wide, so files created
+	// meanwhile only get stricter permissions.
+	old := umask(0o177)
+	lis, err := net.Listen("unix", path)
+	umask(old)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on unix socket %s: %w", path, err)
+	}
+
+	if err := os.Chmod(path, socketMode); err != nil {
+		lis.Close()
+		return nil, fmt.Errorf("failed to set permissions on %s: %w", path, err)
+	}
+
+	return lis, nil
+}
+
+// Activated returns the listeners passed in by systemd socket activation, or
+// none if the process was not socket activated. The activation environment
+// variables are cleared so child processes don't inherit them.
+func Activated() ([]net.Listener, error) {
+	defer func() {
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+	}()
+
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n <= 0 {
+		return nil, nil
+	}
+
+	var listeners []net.Listener
+	for fd := listenFDsStart; fd < listenFDsStart+n; fd++ {
+		// FileListener duplicates the descriptor with close-on-exec set, so the
+		// inherited one can be closed right away
+		f := os.NewFile(uintptr(fd), "LISTEN_FD_"+strconv.Itoa(fd))
+		lis, err := net.FileListener(f)
+		f.Close()
+		if err != nil {
+			closeAll(listeners)
+			return nil, fmt.Errorf("failed to use socket activated fd %d: %w", fd, err)
+		}
+		listeners = append(listeners, lis)
+	}
+
+	return listeners, nil
+}
+
+// Addr formats a listener's address in the same URL syntax Listen accepts.
+func Addr(lis net.Listener) string {
+	return lis.Addr().Network() + "://" + lis.Addr().String()
+}
+
+func closeAll(listeners []net.Listener) {
+	for _, lis := range listeners {
+		lis.Close()
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package listen
+
+// umask is a no-op where file modes don't apply to sockets.
+func umask(int) int {
+	return 0
+}
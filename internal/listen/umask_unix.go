@@ -0,0 +1,10 @@
+//go:build unix
+
+package listen
+
+import "syscall"
+
+// umask sets the process umask, returning the previous one.
+func umask(mask int) int {
+	return syscall.Umask(mask)
+}
@@ -3,6 +3,9 @@ package sqlbackend
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"sync"
+	"time"
 
 	_ "modernc.org/sqlite"
 
@@ -12,47 +15,323 @@ import (
 type Backend interface {
 	Put(ctx context.Context, key int64, value string) error
 	Get(ctx context.Context, key int64) (string, error)
+	// Delete removes a key, reporting whether it existed.
+	Delete(ctx context.Context, key int64) (bool, error)
+	// Scan returns up to limit entries with keys from first to last
+	// (inclusive), in key order.
+	Scan(ctx context.Context, first, last int64, limit int) ([]KeyValue, error)
+	// BatchPut applies all entries atomically, in order.
+	BatchPut(ctx context.Context, entries []KeyValue) error
 	Close(ctx context.Context) error
 }
 
+// KeyValue is a single entry of a batch.
+type KeyValue struct {
+	Key   int64
+	Value string
+}
+
+// sqliteBackend reads and writes the keys of a namespace of a store.
 type sqliteBackend struct {
+	*store
+	namespace string
+}
+
+// store is the database shared by the backends of all namespaces.
+type store struct {
 	db *sql.DB
 	q  *sqlgen.Queries
+
+	// journal is nil unless enabled. writeMu is held from the start of a
+	// write until it's journaled, so the journal is in commit order.
+	journal *journal
+	writeMu sync.Mutex
+
+	// stopCompaction stops compacting history and trimming the change log,
+	// and compacted is closed once both have stopped.
+	stopCompaction chan struct{}
+	compacted      chan struct{}
+
+	restoreMu sync.Mutex
+	onRestore []func()
+
+	retainMu sync.Mutex
+	lowWater []*func() int64
+}
+
+type backendConfig struct {
+	journalDir  string
+	segmentSize int64
+
+	retention          HistoryRetention
+	changeRetention    ChangeLogRetention
+	compactionInterval time.Duration
+}
+
+// Option configures the backend.
+type Option func(*backendConfig)
+
+// WithJournal appends every committed write to a journal in dir, syncing it
+// before the write returns. Replaying the journal onto a backup with Recover
+// restores the data as of any later point in time.
+func WithJournal(dir string) Option {
+	return func(c *backendConfig) {
+		c.journalDir = dir
+	}
+}
+
+// WithJournalSegmentSize sets the size after which the journal moves on to a
+// new segment file. The default is DefaultSegmentSize.
+func WithJournalSegmentSize(size int64) Option {
+	return func(c *backendConfig) {
+		c.segmentSize = size
+	}
 }
 
-func New(ctx context.Context) (Backend, error) {
+// WithHistoryRetention sets how many and how old versions of each key are
+// kept. By default DefaultHistoryVersions versions are kept regardless of age.
+func WithHistoryRetention(retention HistoryRetention) Option {
+	return func(c *backendConfig) {
+		c.retention = retention
+	}
+}
+
+// WithChangeLogRetention sets how many and how old changes are kept in the
+// change log. By default DefaultChangeLogChanges changes are kept regardless
+// of age.
+func WithChangeLogRetention(retention ChangeLogRetention) Option {
+	return func(c *backendConfig) {
+		c.changeRetention = retention
+	}
+}
+
+// WithCompactionInterval sets how often history and changes beyond their
+// retention are trimmed in the background. The default is
+// DefaultCompactionInterval.
+func WithCompactionInterval(interval time.Duration) Option {
+	return func(c *backendConfig) {
+		c.compactionInterval = interval
+	}
+}
+
+func New(ctx context.Context, opts ...Option) (Backend, error) {
+	cfg := backendConfig{
+		segmentSize:        DefaultSegmentSize,
+		retention:          HistoryRetention{Versions: DefaultHistoryVersions},
+		changeRetention:    ChangeLogRetention{Changes: DefaultChangeLogChanges},
+		compactionInterval: DefaultCompactionInterval,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	db, err := sql.Open("sqlite", ":memory:")
 	if err != nil {
 		return nil, err
 	}
+	// Every connection to ":memory:" opens a separate, empty database
+	db.SetMaxOpenConns(1)
 
 	// Apply migrations to set up the database schema
 	if err := runMigrations(db); err != nil {
 		return nil, err
 	}
 
-	return &sqliteBackend{
-		db: db,
-		q:  sqlgen.New(db),
-	}, nil
+	q, err := sqlgen.Prepare(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &sqliteBackend{store: &store{
+		db:             db,
+		q:              q,
+		stopCompaction: make(chan struct{}),
+		compacted:      make(chan struct{}),
+	}}
+	if cfg.journalDir != "" {
+		if s.journal, err = openJournal(cfg.journalDir, cfg.segmentSize); err != nil {
+			return nil, err
+		}
+		// Continue numbering changes after the journaled ones, so they
+		// stay unique across restarts
+		if err := raiseSequence(ctx, db, s.journal.lastSeq); err != nil {
+			return nil, err
+		}
+	}
+
+	go func() {
+		defer close(s.compacted)
+		var wg sync.WaitGroup
+		wg.Go(func() { s.compactHistory(cfg.retention, cfg.compactionInterval, s.stopCompaction) })
+		wg.Go(func() { s.trimChangeLog(cfg.changeRetention, cfg.compactionInterval, s.stopCompaction) })
+		wg.Wait()
+	}()
+	return s, nil
 }
 
 func (s *sqliteBackend) Put(ctx context.Context, key int64, value string) error {
-	_, err := s.q.Put(ctx, sqlgen.PutParams{
-		Key:   key,
-		Value: value,
+	return s.BatchPut(ctx, []KeyValue{{Key: key, Value: value}})
+}
+
+func (s *sqliteBackend) BatchPut(ctx context.Context, entries []KeyValue) error {
+	return s.write(ctx, func(q *sqlgen.Queries) ([]JournalRecord, error) {
+		before, err := usage(ctx, q, s.namespace)
+		if err != nil {
+			return nil, err
+		}
+		// Inserting would recreate a namespace deleted since the caller
+		// checked it exists, without its quota
+		if before == nil && s.namespace != DefaultNamespace {
+			return nil, fmt.Errorf("%w: %q", ErrNamespaceNotFound, s.namespace)
+		}
+
+		now := time.Now()
+		records := make([]JournalRecord, 0, len(entries))
+		for _, entry := range entries {
+			if _, err := q.Put(ctx, sqlgen.PutParams{
+				Namespace: s.namespace,
+				Key:       entry.Key,
+				Value:     entry.Value,
+			}); err != nil {
+				return nil, err
+			}
+			seq, err := q.AppendChange(ctx, sqlgen.AppendChangeParams{
+				Namespace:   s.namespace,
+				Key:         entry.Key,
+				Value:       entry.Value,
+				CommittedAt: now.UnixNano(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			change := Change{Seq: seq, Namespace: s.namespace, Key: entry.Key, Value: entry.Value, CommittedAt: now}
+			if err := addVersion(ctx, q, change); err != nil {
+				return nil, err
+			}
+			records = append(records, JournalRecord{Change: change})
+		}
+
+		if err := checkQuota(ctx, q, before); err != nil {
+			return nil, err
+		}
+		return records, nil
 	})
-	return err
 }
 
 func (s *sqliteBackend) Get(ctx context.Context, key int64) (string, error) {
-	get, err := s.q.Get(ctx, key)
+	get, err := s.q.Get(ctx, sqlgen.GetParams{Namespace: s.namespace, Key: key})
 	if err != nil {
 		return "", err
 	}
 	return get.Value, nil
 }
 
+func (s *sqliteBackend) Delete(ctx context.Context, key int64) (bool, error) {
+	var found bool
+	err := s.write(ctx, func(q *sqlgen.Queries) ([]JournalRecord, error) {
+		deleted, err := q.Delete(ctx, sqlgen.DeleteParams{Namespace: s.namespace, Key: key})
+		if err != nil || deleted == 0 {
+			return nil, err
+		}
+
+		found = true
+		now := time.Now()
+		seq, err := q.AppendChange(ctx, sqlgen.AppendChangeParams{
+			Namespace:   s.namespace,
+			Key:         key,
+			Deleted:     true,
+			CommittedAt: now.UnixNano(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		change := Change{Seq: seq, Namespace: s.namespace, Key: key, Deleted: true, CommittedAt: now}
+		if err := addVersion(ctx, q, change); err != nil {
+			return nil, err
+		}
+		return []JournalRecord{{Change: change}}, nil
+	})
+	return found, err
+}
+
+func (s *sqliteBackend) Scan(ctx context.Context, first, last int64, limit int) ([]KeyValue, error) {
+	rows, err := s.q.Scan(ctx, sqlgen.ScanParams{
+		Namespace: s.namespace,
+		FirstKey:  first,
+		LastKey:   last,
+		MaxRows:   int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]KeyValue, len(rows))
+	for i, row := range rows {
+		entries[i] = KeyValue{Key: row.Key, Value: row.Value}
+	}
+	return entries, nil
+}
+
+// write runs fn in a transaction like inTx, then journals the records fn
+// returns. A write that fails to be journaled was committed, but the error is
+// returned as the journal no longer covers it.
+func (s *store) write(ctx context.Context, fn func(q *sqlgen.Queries) ([]JournalRecord, error)) error {
+	if s.journal == nil {
+		return s.inTx(ctx, func(q *sqlgen.Queries) error {
+			_, err := fn(q)
+			return err
+		})
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if err := s.journal.failed(); err != nil {
+		return err
+	}
+	var records []JournalRecord
+	if err := s.inTx(ctx, func(q *sqlgen.Queries) error {
+		var err error
+		records, err = fn(q)
+		return err
+	}); err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	if err := s.journal.append(records); err != nil {
+		return fmt.Errorf("failed to journal write: %w", err)
+	}
+	return nil
+}
+
+// inTx runs fn in a transaction, committing it if fn succeeds.
+func (s *store) inTx(ctx context.Context, fn func(q *sqlgen.Queries) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(s.q.WithTx(tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Close closes the store, and so the backends of all namespaces.
 func (s *sqliteBackend) Close(context.Context) error {
+	close(s.stopCompaction)
+	<-s.compacted
+
+	if err := s.q.Close(); err != nil {
+		return err
+	}
+	if s.journal != nil {
+		if err := s.journal.close(); err != nil {
+			return err
+		}
+	}
 	return s.db.Close()
 }
@@ -0,0 +1,258 @@
+package sqlbackend
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+var (
+	// ErrChecksumMismatch is returned when restoring a backup that doesn't
+	// match the checksum in its metadata.
+	ErrChecksumMismatch = errors.New("backup checksum mismatch")
+	// ErrSchemaMismatch is returned when restoring a backup whose schema
+	// version differs from the database's.
+	ErrSchemaMismatch = errors.New("backup schema version mismatch")
+)
+
+// BackupInfo describes a backup. It is stored as JSON next to the backup, in
+// a file named by MetadataPath.
+type BackupInfo struct {
+	SHA256        string    `json:"sha256"`
+	Size          int64     `json:"size"`
+	SchemaVersion uint64    `json:"schema_version"`
+	LastSeq       int64     `json:"last_seq"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// MetadataPath returns the path of the metadata of the backup at path.
+func MetadataPath(path string) string {
+	return path + ".json"
+}
+
+// Backupper is implemented by backends that can back up and restore their
+// database while serving.
+type Backupper interface {
+	// Backup writes a consistent copy of the database to path, which must
+	// not exist, and its metadata to MetadataPath(path).
+	Backup(ctx context.Context, path string) (BackupInfo, error)
+	// Restore replaces all data with the backup at path, after checking it
+	// against its metadata and the schema version, then calls the functions
+	// registered with OnRestore. Followers and cluster nodes diverge unless
+	// restored from the same backup. A journal records the restore, so
+	// recovery doesn't replay earlier changes onto the restored data.
+	Restore(ctx context.Context, path string) (BackupInfo, error)
+	// OnRestore registers fn to be called after every restore, as the
+	// changes it makes aren't published like those of writes.
+	OnRestore(fn func())
+}
+
+func (s *sqliteBackend) Backup(ctx context.Context, path string) (BackupInfo, error) {
+	if _, err := os.Stat(path); err == nil {
+		return BackupInfo{}, fmt.Errorf("failed to back up to %s: %w", path, os.ErrExist)
+	}
+
+	// Writes are held off while the connection is in use, so the metadata
+	// matches the copy
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return BackupInfo{}, err
+	}
+	defer conn.Close()
+
+	info := BackupInfo{CreatedAt: time.Now()}
+	if info.SchemaVersion, err = schemaVersion(ctx, conn, "main"); err != nil {
+		return BackupInfo{}, err
+	}
+	if info.LastSeq, err = sequence(ctx, conn); err != nil {
+		return BackupInfo{}, err
+	}
+	if _, err := conn.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return BackupInfo{}, fmt.Errorf("failed to back up database: %w", err)
+	}
+
+	if info.SHA256, info.Size, err = checksum(path); err != nil {
+		return BackupInfo{}, err
+	}
+	if err := writeMetadata(path, info); err != nil {
+		return BackupInfo{}, err
+	}
+	return info, nil
+}
+
+func (s *sqliteBackend) Restore(ctx context.Context, path string) (BackupInfo, error) {
+	info, err := verifyBackup(path)
+	if err != nil {
+		return BackupInfo{}, err
+	}
+
+	if s.journal != nil {
+		s.writeMu.Lock()
+		defer s.writeMu.Unlock()
+
+		if err := s.journal.failed(); err != nil {
+			return BackupInfo{}, err
+		}
+	}
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return BackupInfo{}, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS backup", path); err != nil {
+		return BackupInfo{}, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "DETACH DATABASE backup")
+
+	current, err := schemaVersion(ctx, conn, "main")
+	if err != nil {
+		return BackupInfo{}, err
+	}
+	version, err := schemaVersion(ctx, conn, "backup")
+	if err != nil {
+		return BackupInfo{}, err
+	}
+	if version != current {
+		return BackupInfo{}, fmt.Errorf("%w: backup has version %d, database has %d", ErrSchemaMismatch, version, current)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return BackupInfo{}, err
+	}
+	defer tx.Rollback()
+
+	// The namespaces' counts are kept by triggers, so they're reset before
+	// the keys are copied
+	for _, stmt := range []string{
+		"DELETE FROM main.keyvalue",
+		"DELETE FROM main.namespaces",
+		"INSERT INTO main.namespaces SELECT * FROM backup.namespaces",
+		"UPDATE main.namespaces SET keys = 0, bytes = 0",
+		"INSERT INTO main.keyvalue SELECT * FROM backup.keyvalue",
+		"DELETE FROM main.changelog",
+		"INSERT INTO main.changelog SELECT * FROM backup.changelog",
+		"DELETE FROM main.history",
+		"INSERT INTO main.history SELECT * FROM backup.history",
+	} {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return BackupInfo{}, fmt.Errorf("failed to restore backup: %w", err)
+		}
+	}
+
+	// Changes before the restore can't be replayed onto the restored data,
+	// which the journal marks under a number of its own
+	var seq int64
+	if s.journal != nil {
+		if seq, err = sequence(ctx, tx); err != nil {
+			return BackupInfo{}, err
+		}
+		seq++
+		if err := raiseSequence(ctx, tx, seq); err != nil {
+			return BackupInfo{}, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return BackupInfo{}, fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	if s.journal != nil {
+		marker := JournalRecord{Change: Change{Seq: seq, CommittedAt: time.Now()}, Restored: true}
+		if err := s.journal.append([]JournalRecord{marker}); err != nil {
+			return BackupInfo{}, fmt.Errorf("failed to journal restore: %w", err)
+		}
+	}
+
+	s.restoreMu.Lock()
+	defer s.restoreMu.Unlock()
+	for _, fn := range s.onRestore {
+		fn()
+	}
+	return info, nil
+}
+
+func (s *sqliteBackend) OnRestore(fn func()) {
+	s.restoreMu.Lock()
+	defer s.restoreMu.Unlock()
+	s.onRestore = append(s.onRestore, fn)
+}
+
+// verifyBackup reads the metadata of the backup at path and checks the
+// backup against it.
+func verifyBackup(path string) (BackupInfo, error) {
+	data, err := os.ReadFile(MetadataPath(path))
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("failed to read backup metadata: %w", err)
+	}
+	var info BackupInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return BackupInfo{}, fmt.Errorf("failed to decode backup metadata: %w", err)
+	}
+
+	sum, size, err := checksum(path)
+	if err != nil {
+		return BackupInfo{}, err
+	}
+	if sum != info.SHA256 || size != info.Size {
+		return BackupInfo{}, fmt.Errorf("%w: %s has checksum %s, metadata says %s", ErrChecksumMismatch, path, sum, info.SHA256)
+	}
+	return info, nil
+}
+
+// schemaVersion returns the migration version of the schema of the named
+// database, which must not be left dirty by a failed migration.
+func schemaVersion(ctx context.Context, conn *sql.Conn, database string) (uint64, error) {
+	var version uint64
+	var dirty bool
+	err := conn.QueryRowContext(ctx, fmt.Sprintf("SELECT version, dirty FROM %s.schema_migrations", database)).Scan(&version, &dirty)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema version of %s: %w", database, err)
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w: schema of %s is dirty at version %d", ErrSchemaMismatch, database, version)
+	}
+	return version, nil
+}
+
+// checksum returns the hex-encoded SHA-256 checksum and size of a file.
+func checksum(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read backup: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// writeMetadata writes the metadata of a backup, replacing it atomically.
+func writeMetadata(path string, info BackupInfo) error {
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := MetadataPath(path) + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write backup metadata: %w", err)
+	}
+	if err := os.Rename(tmp, MetadataPath(path)); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write backup metadata: %w", err)
+	}
+	return nil
+}
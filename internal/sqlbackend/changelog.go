@@ -0,0 +1,241 @@
+package sqlbackend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend/sqlgen"
+)
+
+// DefaultChangeLogChanges is the number of changes kept in the change log
+// unless configured otherwise.
+const DefaultChangeLogChanges = 100_000
+
+// ErrChangesTrimmed is returned when reading changes that were trimmed from the
+// change log. Readers that need them start over from a copy of the data.
+var ErrChangesTrimmed = errors.New("changes were trimmed from the change log")
+
+// ChangeLogRetention bounds the changes kept in the change log. The latest
+// change is always kept, so the log's last sequence number survives trimming.
+type ChangeLogRetention struct {
+	// Changes is the number of changes kept; zero keeps all.
+	Changes int
+	// MaxAge is how long changes are kept; zero keeps them regardless of age.
+	MaxAge time.Duration
+}
+
+// Change is a committed write, numbered by its position in the change log.
+type Change struct {
+	Seq         int64
+	Namespace   string
+	Key         int64
+	Value       string
+	Deleted     bool
+	CommittedAt time.Time
+}
+
+// ChangeLog is implemented by backends recording every committed write in
+// commit order, so the writes can be replayed on a replica. The log covers the
+// writes to every namespace.
+type ChangeLog interface {
+	// Changes returns up to limit changes following seq, in order. It
+	// returns ErrChangesTrimmed if some of them were trimmed.
+	Changes(ctx context.Context, after int64, limit int) ([]Change, error)
+	// LastSeq returns the number of the latest change, or zero if none.
+	LastSeq(ctx context.Context) (int64, error)
+	// Apply atomically applies changes read from another backend's log and
+	// records them in this backend's log under the same numbers. Changes not
+	// after LastSeq were applied before and are skipped.
+	Apply(ctx context.Context, changes []Change) error
+	// TrimChanges trims changes beyond the retention, except those a reader
+	// registered with Retain still needs, returning how many it trimmed.
+	TrimChanges(ctx context.Context, retention ChangeLogRetention) (int64, error)
+	// Retain keeps the changes following the sequence number lowWater
+	// returns, the low-water mark of a reader, from being trimmed until
+	// release is called.
+	Retain(lowWater func() int64) (release func())
+}
+
+// trimChanges deletes the changes beyond the retention, other than the latest,
+// that follow no low-water mark.
+const trimChanges = `DELETE FROM changelog
+WHERE seq <= ? AND seq < (SELECT MAX(seq) FROM changelog)
+	AND (seq <= COALESCE((SELECT seq FROM changelog ORDER BY seq DESC LIMIT 1 OFFSET ?), 0)
+		OR committed_at < ?)`
+
+func (s *sqliteBackend) Changes(ctx context.Context, after int64, limit int) ([]Change, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	// Changes may be missing between after and the oldest one kept
+	first, err := q.FirstSeq(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if first > after+1 {
+		return nil, fmt.Errorf("%w: changes following %d start at %d", ErrChangesTrimmed, after, first)
+	}
+
+	rows, err := q.Changes(ctx, sqlgen.ChangesParams{
+		AfterSeq: after,
+		MaxRows:  int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]Change, len(rows))
+	for i, row := range rows {
+		changes[i] = Change{
+			Seq:         row.Seq,
+			Namespace:   row.Namespace,
+			Key:         row.Key,
+			Value:       row.Value,
+			Deleted:     row.Deleted,
+			CommittedAt: time.Unix(0, row.CommittedAt),
+		}
+	}
+	return changes, tx.Commit()
+}
+
+func (s *sqliteBackend) LastSeq(ctx context.Context) (int64, error) {
+	return s.q.LastSeq(ctx)
+}
+
+func (s *sqliteBackend) Apply(ctx context.Context, changes []Change) error {
+	return s.write(ctx, func(q *sqlgen.Queries) ([]JournalRecord, error) {
+		last, err := q.LastSeq(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var records []JournalRecord
+
+		for _, change := range changes {
+			if change.Seq <= last {
+				continue
+			}
+
+			if change.Deleted {
+				_, err = q.Delete(ctx, sqlgen.DeleteParams{Namespace: change.Namespace, Key: change.Key})
+			} else {
+				_, err = q.Put(ctx, sqlgen.PutParams{Namespace: change.Namespace, Key: change.Key, Value: change.Value})
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if err := q.InsertChange(ctx, sqlgen.InsertChangeParams{
+				Seq:         change.Seq,
+				Namespace:   change.Namespace,
+				Key:         change.Key,
+				Value:       change.Value,
+				Deleted:     change.Deleted,
+				CommittedAt: change.CommittedAt.UnixNano(),
+			}); err != nil {
+				return nil, err
+			}
+			if err := addVersion(ctx, q, change); err != nil {
+				return nil, err
+			}
+			records = append(records, JournalRecord{Change: change})
+			last = change.Seq
+		}
+		return records, nil
+	})
+}
+
+func (s *sqliteBackend) TrimChanges(ctx context.Context, retention ChangeLogRetention) (int64, error) {
+	changes := int64(math.MaxInt64)
+	if retention.Changes > 0 {
+		changes = int64(retention.Changes)
+	}
+	cutoff := int64(math.MinInt64)
+	if retention.MaxAge > 0 {
+		cutoff = time.Now().Add(-retention.MaxAge).UnixNano()
+	}
+
+	lowWater := int64(math.MaxInt64)
+	s.retainMu.Lock()
+	for _, fn := range s.lowWater {
+		lowWater = min(lowWater, (*fn)())
+	}
+	s.retainMu.Unlock()
+
+	result, err := s.db.ExecContext(ctx, trimChanges, lowWater, changes, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to trim change log: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (s *sqliteBackend) Retain(lowWater func() int64) func() {
+	s.retainMu.Lock()
+	defer s.retainMu.Unlock()
+	s.lowWater = append(s.lowWater, &lowWater)
+	return func() {
+		s.retainMu.Lock()
+		defer s.retainMu.Unlock()
+		s.lowWater = slices.DeleteFunc(s.lowWater, func(fn *func() int64) bool { return fn == &lowWater })
+	}
+}
+
+// trimChangeLog trims the change log every interval until stop is closed.
+func (s *sqliteBackend) trimChangeLog(retention ChangeLogRetention, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		trimmed, err := s.TrimChanges(context.Background(), retention)
+		if err != nil {
+			slog.Warn("failed to trim change log", "error", err)
+		} else if trimmed > 0 {
+			slog.Debug("trimmed change log", "changes", trimmed)
+		}
+	}
+}
+
+// sequence returns the number of the latest change ever recorded, which
+// unlike LastSeq doesn't go back when the change log is replaced by a restore.
+func sequence(ctx context.Context, db sqlgen.DBTX) (int64, error) {
+	var seq int64
+	err := db.QueryRowContext(ctx, "SELECT COALESCE(MAX(seq), 0) FROM sqlite_sequence WHERE name = 'changelog'").Scan(&seq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read sequence number: %w", err)
+	}
+	return seq, nil
+}
+
+// raiseSequence makes changes be numbered after seq, if they aren't already.
+func raiseSequence(ctx context.Context, db sqlgen.DBTX, seq int64) error {
+	current, err := sequence(ctx, db)
+	if err != nil || current >= seq {
+		return err
+	}
+
+	for _, stmt := range []string{
+		"DELETE FROM sqlite_sequence WHERE name = 'changelog'",
+		"INSERT INTO sqlite_sequence (name, seq) VALUES ('changelog', ?)",
+	} {
+		if _, err := db.ExecContext(ctx, stmt, seq); err != nil {
+			return fmt.Errorf("failed to set sequence number: %w", err)
+		}
+	}
+	return nil
+}
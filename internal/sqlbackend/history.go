@@ -0,0 +1,217 @@
+package sqlbackend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend/sqlgen"
+)
+
+const (
+	// DefaultHistoryVersions is the number of versions kept per key unless
+	// configured otherwise.
+	DefaultHistoryVersions = 10
+	// DefaultCompactionInterval is how often history is compacted unless
+	// configured otherwise.
+	DefaultCompactionInterval = time.Minute
+)
+
+// ErrCompacted is returned when reading a version that compaction trimmed.
+var ErrCompacted = errors.New("version was compacted")
+
+// Version is a value a key had, as written by the change numbered Revision.
+type Version struct {
+	Revision    int64
+	Value       string
+	Deleted     bool
+	CommittedAt time.Time
+}
+
+// HistoryRetention bounds the versions kept per key. The latest version of a
+// key is always kept.
+type HistoryRetention struct {
+	// Versions is the number of versions kept per key; zero keeps all.
+	Versions int
+	// MaxAge is how long versions are kept; zero keeps them regardless of
+	// age.
+	MaxAge time.Duration
+}
+
+// Versioned is implemented by backends keeping past versions of keys.
+type Versioned interface {
+	// GetVersion returns the version of key current as of revision and time,
+	// where zero means the latest. It returns sql.ErrNoRows if the key had
+	// no version then, and ErrCompacted if it was trimmed.
+	GetVersion(ctx context.Context, key, revision int64, at time.Time) (Version, error)
+	// History returns up to limit versions of key older than revision before,
+	// newest first, and whether older ones were compacted.
+	History(ctx context.Context, key, before int64, limit int) ([]Version, bool, error)
+	// CompactHistory trims versions beyond the retention, returning how many
+	// it trimmed.
+	CompactHistory(ctx context.Context, retention HistoryRetention) (int64, error)
+}
+
+const (
+	// markCompacted turns the newest version of each key that's beyond the
+	// retention into a compacted row.
+	markCompacted = `UPDATE history SET compacted = TRUE, value = ''
+	WHERE (namespace, key, revision) IN (
+		SELECT namespace, key, MAX(revision) FROM (
+			SELECT namespace, key, revision, committed_at,
+				ROW_NUMBER() OVER (PARTITION BY namespace, key ORDER BY revision DESC) AS n
+			FROM history WHERE NOT compacted
+		)
+		WHERE n > 1 AND (n > ? OR committed_at < ?)
+		GROUP BY namespace, key
+	)`
+	// deleteCompacted deletes the versions older than a compacted row.
+	deleteCompacted = `DELETE FROM history
+	WHERE revision < (
+		SELECT MAX(h.revision) FROM history AS h
+		WHERE h.namespace = history.namespace AND h.key = history.key AND h.compacted
+	)`
+)
+
+// addVersion records a committed change in the history of its key.
+func addVersion(ctx context.Context, q *sqlgen.Queries, change Change) error {
+	return q.AddVersion(ctx, sqlgen.AddVersionParams{
+		Namespace:   change.Namespace,
+		Key:         change.Key,
+		Revision:    change.Seq,
+		Value:       change.Value,
+		Deleted:     change.Deleted,
+		CommittedAt: change.CommittedAt.UnixNano(),
+	})
+}
+
+func (s *sqliteBackend) GetVersion(ctx context.Context, key, revision int64, at time.Time) (Version, error) {
+	params := sqlgen.GetVersionParams{
+		Namespace:      s.namespace,
+		Key:            key,
+		MaxRevision:    revision,
+		MaxCommittedAt: at.UnixNano(),
+	}
+	if revision == 0 {
+		params.MaxRevision = math.MaxInt64
+	}
+	if at.IsZero() {
+		params.MaxCommittedAt = math.MaxInt64
+	}
+
+	return getVersion(ctx, s.q, params)
+}
+
+// getVersion reads a version like GetVersion, with q.
+func getVersion(ctx context.Context, q *sqlgen.Queries, params sqlgen.GetVersionParams) (Version, error) {
+	row, err := q.GetVersion(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Compaction deletes all versions older than a compacted row
+		compacted, compactedErr := q.IsCompacted(ctx, sqlgen.IsCompactedParams{Namespace: params.Namespace, Key: params.Key})
+		if compactedErr != nil {
+			return Version{}, compactedErr
+		}
+		if compacted != 0 {
+			return Version{}, ErrCompacted
+		}
+	}
+	if err != nil {
+		return Version{}, err
+	}
+	if row.Compacted {
+		return Version{}, ErrCompacted
+	}
+	return versionOf(row), nil
+}
+
+func (s *sqliteBackend) History(ctx context.Context, key, before int64, limit int) ([]Version, bool, error) {
+	if before == 0 {
+		before = math.MaxInt64
+	}
+	rows, err := s.q.Versions(ctx, sqlgen.VersionsParams{
+		Namespace:      s.namespace,
+		Key:            key,
+		BeforeRevision: before,
+		MaxRows:        int64(limit),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	versions := make([]Version, 0, len(rows))
+	for _, row := range rows {
+		if row.Compacted {
+			return versions, true, nil
+		}
+		versions = append(versions, versionOf(row))
+	}
+	return versions, false, nil
+}
+
+func (s *sqliteBackend) CompactHistory(ctx context.Context, retention HistoryRetention) (int64, error) {
+	versions := int64(math.MaxInt64)
+	if retention.Versions > 0 {
+		versions = int64(retention.Versions)
+	}
+	cutoff := int64(math.MinInt64)
+	if retention.MaxAge > 0 {
+		cutoff = time.Now().Add(-retention.MaxAge).UnixNano()
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	marked, err := tx.ExecContext(ctx, markCompacted, versions, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compact history: %w", err)
+	}
+	deleted, err := tx.ExecContext(ctx, deleteCompacted)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compact history: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to compact history: %w", err)
+	}
+
+	// Compacted rows replace the newest version they trim
+	n, _ := marked.RowsAffected()
+	m, _ := deleted.RowsAffected()
+	return n + m, nil
+}
+
+// compactHistory compacts history every interval until stop is closed.
+func (s *sqliteBackend) compactHistory(retention HistoryRetention, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		trimmed, err := s.CompactHistory(context.Background(), retention)
+		if err != nil {
+			slog.Warn("failed to compact history", "error", err)
+		} else if trimmed > 0 {
+			slog.Debug("compacted history", "versions", trimmed)
+		}
+	}
+}
+
+func versionOf(row sqlgen.History) Version {
+	return Version{
+		Revision:    row.Revision,
+		Value:       row.Value,
+		Deleted:     row.Deleted,
+		CommittedAt: time.Unix(0, row.CommittedAt),
+	}
+}
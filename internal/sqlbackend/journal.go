@@ -0,0 +1,455 @@
+package sqlbackend
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSegmentSize is the size after which the journal moves on to a
+	// new segment file.
+	DefaultSegmentSize = 64 << 20
+
+	// segmentExt is the extension of segment files, which are named by the
+	// sequence number following the previous segment's last one.
+	segmentExt = ".journal"
+	// recordHeaderSize is the size of the length and checksum preceding every
+	// record's payload.
+	recordHeaderSize = 8
+	// maxRecordSize bounds the payload size accepted when reading.
+	maxRecordSize = 1 << 30
+)
+
+// ErrJournalCorrupt is returned when reading a journal record that fails its
+// checksum or can't be decoded, other than at the end of the journal.
+var ErrJournalCorrupt = errors.New("journal is corrupt")
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// JournalRecord is an entry of the journal: a committed change, or a marker
+// that all data was replaced by restoring a backup.
+type JournalRecord struct {
+	Change
+	// Restored marks a restore, which is numbered like a change so backups
+	// tell whether they were made before or after it.
+	Restored bool
+}
+
+type recordType byte
+
+const (
+	recordPut recordType = iota + 1
+	recordDelete
+	recordRestore
+)
+
+// recordNamespaced is set in the type of records of changes outside the
+// default namespace, which carry the namespace after the key.
+const recordNamespaced recordType = 0x80
+
+// journal appends committed changes to segment files, syncing them before
+// writes are acknowledged. Once an append fails, all later ones fail too, as
+// a gap would make the journal silently incomplete.
+type journal struct {
+	dir         string
+	segmentSize int64
+
+	mu      sync.Mutex
+	f       *os.File // nil until the first segment is created
+	size    int64
+	lastSeq int64
+	err     error
+}
+
+// openJournal opens the journal in dir, creating dir if needed. A record cut
+// short at the end of the last segment, as left by a crash during an append,
+// is truncated; a bad record followed by others fails with ErrJournalCorrupt.
+func openJournal(dir string, segmentSize int64) (*journal, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	segments, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &journal{dir: dir, segmentSize: segmentSize}
+	if len(segments) == 0 {
+		return j, nil
+	}
+
+	last := segments[len(segments)-1]
+	f, err := os.OpenFile(last.path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal segment: %w", err)
+	}
+
+	j.lastSeq = last.firstSeq - 1
+	r := bufio.NewReader(f)
+	for {
+		record, n, err := readRecord(r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			torn, tornErr := tornTail(f, j.size)
+			if tornErr != nil || !torn {
+				f.Close()
+				return nil, fmt.Errorf("%w: %s at offset %d: %v", ErrJournalCorrupt, last.path, j.size, cmp.Or(tornErr, err))
+			}
+			break
+		}
+		j.size += n
+		j.lastSeq = record.Seq
+	}
+
+	if err := f.Truncate(j.size); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to truncate journal segment: %w", err)
+	}
+	if _, err := f.Seek(j.size, io.SeekStart); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to seek journal segment: %w", err)
+	}
+	j.f = f
+	return j, nil
+}
+
+// append writes records and syncs them to disk.
+func (j *journal) append(records []JournalRecord) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.err != nil {
+		return j.err
+	}
+	if err := j.write(records); err != nil {
+		j.err = fmt.Errorf("journal failed, writes are rejected until restart: %w", err)
+		return j.err
+	}
+	return nil
+}
+
+// failed returns the error that failed an earlier append, if any.
+func (j *journal) failed() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.err
+}
+
+func (j *journal) write(records []JournalRecord) error {
+	if j.f == nil || j.size >= j.segmentSize {
+		if err := j.rotate(); err != nil {
+			return err
+		}
+	}
+
+	var buf []byte
+	for _, record := range records {
+		buf = appendRecord(buf, record)
+	}
+	if _, err := j.f.Write(buf); err != nil {
+		return err
+	}
+	if err := j.f.Sync(); err != nil {
+		return err
+	}
+
+	j.size += int64(len(buf))
+	j.lastSeq = max(j.lastSeq, records[len(records)-1].Seq)
+	return nil
+}
+
+// rotate closes the current segment and starts a new one.
+func (j *journal) rotate() error {
+	if j.f != nil {
+		if err := j.f.Close(); err != nil {
+			return err
+		}
+		j.f = nil
+	}
+
+	name := fmt.Sprintf("%020d%s", j.lastSeq+1, segmentExt)
+	f, err := os.OpenFile(filepath.Join(j.dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	// Sync the directory so the new segment survives a crash
+	dir, err := os.Open(j.dir)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	defer dir.Close()
+	if err := dir.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	j.f, j.size = f, 0
+	return nil
+}
+
+func (j *journal) close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.f == nil {
+		return nil
+	}
+	return j.f.Close()
+}
+
+// ReadJournal iterates over the records of the journal in dir, in the order
+// they were written. A record cut short at the end of the journal ends it;
+// corruption anywhere else yields ErrJournalCorrupt.
+func ReadJournal(dir string) iter.Seq2[JournalRecord, error] {
+	return func(yield func(JournalRecord, error) bool) {
+		segments, err := listSegments(dir)
+		if err != nil {
+			yield(JournalRecord{}, err)
+			return
+		}
+
+		for i, segment := range segments {
+			last := i == len(segments)-1
+			if !readSegment(segment.path, last, yield) {
+				return
+			}
+		}
+	}
+}
+
+// readSegment yields the records of a segment, returning false if iteration
+// should stop.
+func readSegment(path string, last bool, yield func(JournalRecord, error) bool) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		yield(JournalRecord{}, fmt.Errorf("failed to open journal segment: %w", err))
+		return false
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	var offset int64
+	for {
+		record, n, err := readRecord(r)
+		switch {
+		case errors.Is(err, io.EOF):
+			return true
+		case err != nil:
+			if last {
+				torn, tornErr := tornTail(f, offset)
+				if torn {
+					// A crash cut the last append short
+					return false
+				}
+				err = cmp.Or(tornErr, err)
+			}
+			yield(JournalRecord{}, fmt.Errorf("%w: %s at offset %d: %v", ErrJournalCorrupt, path, offset, err))
+			return false
+		}
+
+		if !yield(record, nil) {
+			return false
+		}
+		offset += n
+	}
+}
+
+// tornTail reports whether the bad record at offset in f was left by a crash
+// during an append: it runs to the end of the file, or the rest of the file
+// is zeros, as file systems may leave after a crash. A bad record with data
+// after it is corruption instead.
+func tornTail(f *os.File, offset int64) (bool, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+	rest := info.Size() - offset
+	if rest < recordHeaderSize {
+		return true, nil
+	}
+
+	var header [recordHeaderSize]byte
+	if _, err := f.ReadAt(header[:], offset); err != nil {
+		return false, err
+	}
+	if recordHeaderSize+int64(binary.BigEndian.Uint32(header[:4])) >= rest {
+		return true, nil
+	}
+
+	buf := make([]byte, 32<<10)
+	tail := io.NewSectionReader(f, offset, rest)
+	for {
+		n, err := tail.Read(buf)
+		if slices.ContainsFunc(buf[:n], func(b byte) bool { return b != 0 }) {
+			return false, nil
+		}
+		if errors.Is(err, io.EOF) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+type segment struct {
+	path     string
+	firstSeq int64
+}
+
+// listSegments returns the segments in dir in the order they were written.
+func listSegments(dir string) ([]segment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal directory: %w", err)
+	}
+
+	var segments []segment
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), segmentExt)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		firstSeq, err := strconv.ParseInt(name, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected journal segment %s", entry.Name())
+		}
+		segments = append(segments, segment{path: filepath.Join(dir, entry.Name()), firstSeq: firstSeq})
+	}
+
+	slices.SortFunc(segments, func(a, b segment) int { return cmp.Compare(a.firstSeq, b.firstSeq) })
+	return segments, nil
+}
+
+// appendRecord appends the encoding of a record: the payload's length and
+// CRC-32C checksum, then the payload of type, sequence number, commit time,
+// key, namespace if not the default, and value.
+func appendRecord(buf []byte, record JournalRecord) []byte {
+	typ := recordPut
+	switch {
+	case record.Restored:
+		typ = recordRestore
+	case record.Deleted:
+		typ = recordDelete
+	}
+	if record.Namespace != DefaultNamespace {
+		typ |= recordNamespaced
+	}
+
+	payload := make([]byte, 0, 32+len(record.Namespace)+len(record.Value))
+	payload = append(payload, byte(typ))
+	payload = binary.AppendVarint(payload, record.Seq)
+	payload = binary.AppendVarint(payload, record.CommittedAt.UnixNano())
+	payload = binary.AppendVarint(payload, record.Key)
+	if typ&recordNamespaced != 0 {
+		payload = binary.AppendUvarint(payload, uint64(len(record.Namespace)))
+		payload = append(payload, record.Namespace...)
+	}
+	payload = binary.AppendUvarint(payload, uint64(len(record.Value)))
+	payload = append(payload, record.Value...)
+
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(payload)))
+	buf = binary.BigEndian.AppendUint32(buf, crc32.Checksum(payload, castagnoli))
+	return append(buf, payload...)
+}
+
+// readRecord reads a record and returns it with its encoded size. It returns
+// io.EOF only if no bytes are left.
+func readRecord(r *bufio.Reader) (JournalRecord, int64, error) {
+	var header [recordHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return JournalRecord{}, 0, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:4])
+	if size > maxRecordSize {
+		return JournalRecord{}, 0, fmt.Errorf("record of %d bytes is too large", size)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return JournalRecord{}, 0, io.ErrUnexpectedEOF
+	}
+	if crc32.Checksum(payload, castagnoli) != binary.BigEndian.Uint32(header[4:]) {
+		return JournalRecord{}, 0, errors.New("checksum mismatch")
+	}
+
+	record, err := decodeRecord(payload)
+	if err != nil {
+		return JournalRecord{}, 0, err
+	}
+	return record, recordHeaderSize + int64(size), nil
+}
+
+func decodeRecord(payload []byte) (JournalRecord, error) {
+	r := bytes.NewReader(payload)
+	typ, err := r.ReadByte()
+	if err != nil {
+		return JournalRecord{}, err
+	}
+
+	var record JournalRecord
+	var committedAt int64
+	var size uint64
+	readNamespace := func() error {
+		if recordType(typ)&recordNamespaced == 0 {
+			return nil
+		}
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return err
+		}
+		if n > uint64(r.Len()) {
+			return io.ErrUnexpectedEOF
+		}
+		namespace := make([]byte, n)
+		r.Read(namespace)
+		record.Namespace = string(namespace)
+		return nil
+	}
+	for _, read := range []func() error{
+		func() (err error) { record.Seq, err = binary.ReadVarint(r); return err },
+		func() (err error) { committedAt, err = binary.ReadVarint(r); return err },
+		func() (err error) { record.Key, err = binary.ReadVarint(r); return err },
+		readNamespace,
+		func() (err error) { size, err = binary.ReadUvarint(r); return err },
+	} {
+		if err := read(); err != nil {
+			return JournalRecord{}, fmt.Errorf("failed to decode record: %w", err)
+		}
+	}
+	if size != uint64(r.Len()) {
+		return JournalRecord{}, fmt.Errorf("record value has %d bytes, want %d", r.Len(), size)
+	}
+
+	record.Value = string(payload[len(payload)-r.Len():])
+	record.CommittedAt = time.Unix(0, committedAt)
+	switch recordType(typ) &^ recordNamespaced {
+	case recordPut:
+	case recordDelete:
+		record.Deleted = true
+	case recordRestore:
+		record.Restored = true
+	default:
+		return JournalRecord{}, fmt.Errorf("unknown record type %d", typ)
+	}
+	return record, nil
+}
@@ -0,0 +1,8 @@
+-- changelog records every committed write in commit order, for replication.
+CREATE TABLE changelog (
+  seq          INTEGER PRIMARY KEY AUTOINCREMENT,
+  key          INTEGER NOT NULL,
+  value        text    NOT NULL,
+  deleted      BOOLEAN NOT NULL,
+  committed_at INTEGER NOT NULL -- Unix nanoseconds
+);
@@ -0,0 +1,15 @@
+-- history keeps recent versions of every key, numbered by the revision (the
+-- change log sequence number) that wrote them. Compaction trims old versions,
+-- leaving a compacted row in place of the newest trimmed one.
+CREATE TABLE history (
+  key          INTEGER NOT NULL,
+  revision     INTEGER NOT NULL,
+  value        text    NOT NULL,
+  deleted      BOOLEAN NOT NULL,
+  committed_at INTEGER NOT NULL, -- Unix nanoseconds
+  compacted    BOOLEAN NOT NULL DEFAULT FALSE,
+  PRIMARY KEY (key, revision)
+) WITHOUT ROWID;
+
+INSERT INTO history (key, revision, value, deleted, committed_at)
+SELECT key, seq, value, deleted, committed_at FROM changelog;
@@ -0,0 +1,63 @@
+-- namespaces partition the key space. Existing keys move to the default
+-- namespace, named by the empty string. keys and bytes count the entries of a
+-- namespace and the size of their values, and are kept up to date by the
+-- triggers below; max_keys and max_bytes limit them, unless zero.
+CREATE TABLE namespaces (
+  name       text    PRIMARY KEY,
+  max_keys   INTEGER NOT NULL DEFAULT 0,
+  max_bytes  INTEGER NOT NULL DEFAULT 0,
+  keys       INTEGER NOT NULL DEFAULT 0,
+  bytes      INTEGER NOT NULL DEFAULT 0,
+  created_at INTEGER NOT NULL -- Unix nanoseconds
+) WITHOUT ROWID;
+
+CREATE TABLE keyvalue_namespaced (
+  namespace text    NOT NULL,
+  key       INTEGER NOT NULL,
+  value     text    NOT NULL,
+  PRIMARY KEY (namespace, key)
+) WITHOUT ROWID;
+INSERT INTO keyvalue_namespaced (namespace, key, value)
+SELECT '', key, value FROM keyvalue;
+DROP TABLE keyvalue;
+ALTER TABLE keyvalue_namespaced RENAME TO keyvalue;
+
+INSERT INTO namespaces (name, keys, bytes, created_at)
+SELECT '', COUNT(*), COALESCE(SUM(LENGTH(CAST(value AS BLOB))), 0), CAST(unixepoch('subsec') * 1e9 AS INTEGER)
+FROM keyvalue;
+
+-- Writes to a namespace that doesn't exist, such as those replicated from a
+-- primary, create it without limits.
+CREATE TRIGGER keyvalue_insert AFTER INSERT ON keyvalue BEGIN
+  INSERT OR IGNORE INTO namespaces (name, created_at)
+  VALUES (NEW.namespace, CAST(unixepoch('subsec') * 1e9 AS INTEGER));
+  UPDATE namespaces SET keys = keys + 1, bytes = bytes + LENGTH(CAST(NEW.value AS BLOB))
+  WHERE name = NEW.namespace;
+END;
+
+CREATE TRIGGER keyvalue_update AFTER UPDATE OF value ON keyvalue BEGIN
+  UPDATE namespaces SET bytes = bytes - LENGTH(CAST(OLD.value AS BLOB)) + LENGTH(CAST(NEW.value AS BLOB))
+  WHERE name = NEW.namespace;
+END;
+
+CREATE TRIGGER keyvalue_delete AFTER DELETE ON keyvalue BEGIN
+  UPDATE namespaces SET keys = keys - 1, bytes = bytes - LENGTH(CAST(OLD.value AS BLOB))
+  WHERE name = OLD.namespace;
+END;
+
+ALTER TABLE changelog ADD COLUMN namespace text NOT NULL DEFAULT '';
+
+CREATE TABLE history_namespaced (
+  namespace    text    NOT NULL,
+  key          INTEGER NOT NULL,
+  revision     INTEGER NOT NULL,
+  value        text    NOT NULL,
+  deleted      BOOLEAN NOT NULL,
+  committed_at INTEGER NOT NULL, -- Unix nanoseconds
+  compacted    BOOLEAN NOT NULL DEFAULT FALSE,
+  PRIMARY KEY (namespace, key, revision)
+) WITHOUT ROWID;
+INSERT INTO history_namespaced (namespace, key, revision, value, deleted, committed_at, compacted)
+SELECT '', key, revision, value, deleted, committed_at, compacted FROM history;
+DROP TABLE history;
+ALTER TABLE history_namespaced RENAME TO history;
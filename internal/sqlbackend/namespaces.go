@@ -0,0 +1,239 @@
+package sqlbackend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend/sqlgen"
+)
+
+// DefaultNamespace is the namespace of keys written without one. It always
+// exists.
+const DefaultNamespace = ""
+
+// maxNamespaceLength bounds the length of namespace names.
+const maxNamespaceLength = 64
+
+var (
+	// ErrInvalidNamespace is returned for malformed namespace names and
+	// changes the default namespace doesn't allow.
+	ErrInvalidNamespace = errors.New("invalid namespace")
+	// ErrNamespaceNotFound is returned for namespaces that don't exist.
+	ErrNamespaceNotFound = errors.New("namespace not found")
+	// ErrNamespaceExists is returned when creating a namespace that exists.
+	ErrNamespaceExists = errors.New("namespace already exists")
+	// ErrNamespaceNotEmpty is returned when deleting a namespace with keys.
+	ErrNamespaceNotEmpty = errors.New("namespace is not empty")
+	// ErrQuotaExceeded is returned by writes that would take a namespace
+	// beyond its quota. Writes shrinking a namespace are allowed even if it
+	// stays beyond its quota.
+	ErrQuotaExceeded = errors.New("namespace quota exceeded")
+)
+
+// Quota limits a namespace. Zero fields are unlimited.
+type Quota struct {
+	// MaxKeys is the number of keys the namespace may hold.
+	MaxKeys int64
+	// MaxBytes is the total size of the values the namespace may hold.
+	MaxBytes int64
+}
+
+// Namespace describes a namespace and its current usage.
+type Namespace struct {
+	Name  string
+	Quota Quota
+	// Keys is the number of keys in the namespace, and Bytes the total size
+	// of their values.
+	Keys      int64
+	Bytes     int64
+	CreatedAt time.Time
+}
+
+// Namespaces is implemented by backends partitioning keys into namespaces.
+// Puts into a namespace that doesn't exist fail with ErrNamespaceNotFound,
+// while changes applied from another backend's change log create it without a
+// quota, so they always apply.
+type Namespaces interface {
+	// Namespace returns a backend for the keys of the namespace name, sharing
+	// this backend's storage. Closing either closes both.
+	Namespace(name string) Backend
+	CreateNamespace(ctx context.Context, name string, quota Quota) (Namespace, error)
+	GetNamespace(ctx context.Context, name string) (Namespace, error)
+	// ListNamespaces returns all namespaces in name order.
+	ListNamespaces(ctx context.Context) ([]Namespace, error)
+	SetQuota(ctx context.Context, name string, quota Quota) (Namespace, error)
+	// DeleteNamespace deletes an empty namespace. Its history is kept until
+	// compacted.
+	DeleteNamespace(ctx context.Context, name string) error
+}
+
+// ValidateNamespace checks that name can name a namespace: up to 64 lowercase
+// letters, digits, '.', '_' and '-', starting with a letter or digit. The
+// empty name is the default namespace.
+func ValidateNamespace(name string) error {
+	if len(name) > maxNamespaceLength {
+		return fmt.Errorf("%w: name is longer than %d characters", ErrInvalidNamespace, maxNamespaceLength)
+	}
+	for i, c := range name {
+		switch {
+		case 'a' <= c && c <= 'z', '0' <= c && c <= '9':
+		case i > 0 && (c == '.' || c == '_' || c == '-'):
+		default:
+			return fmt.Errorf("%w: unexpected character %q in %q", ErrInvalidNamespace, c, name)
+		}
+	}
+	return nil
+}
+
+func (s *sqliteBackend) Namespace(name string) Backend {
+	return &sqliteBackend{store: s.store, namespace: name}
+}
+
+func (s *sqliteBackend) CreateNamespace(ctx context.Context, name string, quota Quota) (Namespace, error) {
+	if err := ValidateNamespace(name); err != nil {
+		return Namespace{}, err
+	}
+	if err := validateQuota(quota); err != nil {
+		return Namespace{}, err
+	}
+
+	var row sqlgen.Namespace
+	err := s.inTx(ctx, func(q *sqlgen.Queries) error {
+		if _, err := q.GetNamespace(ctx, name); err == nil {
+			return fmt.Errorf("%w: %q", ErrNamespaceExists, name)
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		var err error
+		row, err = q.CreateNamespace(ctx, sqlgen.CreateNamespaceParams{
+			Name:      name,
+			MaxKeys:   quota.MaxKeys,
+			MaxBytes:  quota.MaxBytes,
+			CreatedAt: time.Now().UnixNano(),
+		})
+		return err
+	})
+	if err != nil {
+		return Namespace{}, err
+	}
+	return namespaceOf(row), nil
+}
+
+func (s *sqliteBackend) GetNamespace(ctx context.Context, name string) (Namespace, error) {
+	row, err := s.q.GetNamespace(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Namespace{}, fmt.Errorf("%w: %q", ErrNamespaceNotFound, name)
+	}
+	if err != nil {
+		return Namespace{}, err
+	}
+	return namespaceOf(row), nil
+}
+
+func (s *sqliteBackend) ListNamespaces(ctx context.Context) ([]Namespace, error) {
+	rows, err := s.q.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]Namespace, len(rows))
+	for i, row := range rows {
+		namespaces[i] = namespaceOf(row)
+	}
+	return namespaces, nil
+}
+
+func (s *sqliteBackend) SetQuota(ctx context.Context, name string, quota Quota) (Namespace, error) {
+	if err := validateQuota(quota); err != nil {
+		return Namespace{}, err
+	}
+
+	row, err := s.q.SetQuota(ctx, sqlgen.SetQuotaParams{
+		Name:     name,
+		MaxKeys:  quota.MaxKeys,
+		MaxBytes: quota.MaxBytes,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return Namespace{}, fmt.Errorf("%w: %q", ErrNamespaceNotFound, name)
+	}
+	if err != nil {
+		return Namespace{}, err
+	}
+	return namespaceOf(row), nil
+}
+
+func (s *sqliteBackend) DeleteNamespace(ctx context.Context, name string) error {
+	if name == DefaultNamespace {
+		return fmt.Errorf("%w: the default namespace can't be deleted", ErrInvalidNamespace)
+	}
+
+	return s.inTx(ctx, func(q *sqlgen.Queries) error {
+		deleted, err := q.DeleteNamespace(ctx, name)
+		if err != nil || deleted > 0 {
+			return err
+		}
+
+		// Tell a namespace with keys from one that doesn't exist
+		row, err := q.GetNamespace(ctx, name)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %q", ErrNamespaceNotFound, name)
+		}
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %q has %d keys", ErrNamespaceNotEmpty, name, row.Keys)
+	})
+}
+
+// usage returns a namespace as read with q, or nil if it doesn't exist.
+func usage(ctx context.Context, q *sqlgen.Queries, name string) (*sqlgen.Namespace, error) {
+	row, err := q.GetNamespace(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// checkQuota returns ErrQuotaExceeded if a write with q took the namespace
+// before describes beyond its quota.
+func checkQuota(ctx context.Context, q *sqlgen.Queries, before *sqlgen.Namespace) error {
+	if before == nil || (before.MaxKeys == 0 && before.MaxBytes == 0) {
+		return nil
+	}
+
+	after, err := q.GetNamespace(ctx, before.Name)
+	if err != nil {
+		return err
+	}
+	switch {
+	case after.MaxKeys > 0 && after.Keys > after.MaxKeys && after.Keys > before.Keys:
+		return fmt.Errorf("%w: %q would have %d keys, more than its limit of %d", ErrQuotaExceeded, after.Name, after.Keys, after.MaxKeys)
+	case after.MaxBytes > 0 && after.Bytes > after.MaxBytes && after.Bytes > before.Bytes:
+		return fmt.Errorf("%w: %q would hold %d bytes, more than its limit of %d", ErrQuotaExceeded, after.Name, after.Bytes, after.MaxBytes)
+	}
+	return nil
+}
+
+func validateQuota(quota Quota) error {
+	if quota.MaxKeys < 0 || quota.MaxBytes < 0 {
+		return fmt.Errorf("%w: quota limits must not be negative", ErrInvalidNamespace)
+	}
+	return nil
+}
+
+func namespaceOf(row sqlgen.Namespace) Namespace {
+	return Namespace{
+		Name:      row.Name,
+		Quota:     Quota{MaxKeys: row.MaxKeys, MaxBytes: row.MaxBytes},
+		Keys:      row.Keys,
+		Bytes:     row.Bytes,
+		CreatedAt: time.Unix(0, row.CreatedAt),
+	}
+}
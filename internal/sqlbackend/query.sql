@@ -1,12 +1,124 @@
 -- name: Get :one
 SELECT * FROM keyvalue
-WHERE key = ? LIMIT 1;
+WHERE namespace = ? AND key = ? LIMIT 1;
 
 -- name: Put :one
 INSERT INTO keyvalue (
-    key, value
+    namespace, key, value
 ) VALUES (
-    ?, ?
-) ON CONFLICT(key) DO UPDATE SET
+    ?, ?, ?
+) ON CONFLICT(namespace, key) DO UPDATE SET
     value = excluded.value
 RETURNING *;
+
+-- name: Delete :execrows
+DELETE FROM keyvalue
+WHERE namespace = ? AND key = ?;
+
+-- name: Scan :many
+SELECT * FROM keyvalue
+WHERE namespace = sqlc.arg(namespace) AND key >= sqlc.arg(first_key) AND key <= sqlc.arg(last_key)
+ORDER BY key
+LIMIT sqlc.arg(max_rows);
+
+-- name: AppendChange :one
+INSERT INTO changelog (
+    namespace, key, value, deleted, committed_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+RETURNING seq;
+
+-- name: InsertChange :exec
+INSERT INTO changelog (
+    seq, namespace, key, value, deleted, committed_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+);
+
+-- name: Changes :many
+SELECT * FROM changelog
+WHERE seq > sqlc.arg(after_seq)
+ORDER BY seq
+LIMIT sqlc.arg(max_rows);
+
+-- name: LastSeq :one
+SELECT CAST(COALESCE(MAX(seq), 0) AS INTEGER) FROM changelog;
+
+-- name: FirstSeq :one
+SELECT CAST(COALESCE(MIN(seq), 0) AS INTEGER) FROM changelog;
+
+-- name: AddVersion :exec
+INSERT INTO history (
+    namespace, key, revision, value, deleted, committed_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetVersion :one
+SELECT * FROM history
+WHERE namespace = sqlc.arg(namespace) AND key = sqlc.arg(key)
+  AND revision <= sqlc.arg(max_revision)
+  AND committed_at <= sqlc.arg(max_committed_at)
+ORDER BY revision DESC
+LIMIT 1;
+
+-- name: Versions :many
+SELECT * FROM history
+WHERE namespace = sqlc.arg(namespace) AND key = sqlc.arg(key)
+  AND revision < sqlc.arg(before_revision)
+ORDER BY revision DESC
+LIMIT sqlc.arg(max_rows);
+
+-- name: IsCompacted :one
+SELECT EXISTS (
+    SELECT 1 FROM history WHERE namespace = ? AND key = ? AND compacted
+);
+
+-- name: ScanVersions :many
+SELECT h.* FROM history AS h
+WHERE h.namespace = sqlc.arg(namespace)
+  AND h.key >= sqlc.arg(first_key) AND h.key <= sqlc.arg(last_key)
+  AND h.revision = (
+    SELECT MAX(v.revision) FROM history AS v
+    WHERE v.namespace = h.namespace AND v.key = h.key AND v.revision <= sqlc.arg(revision)
+  )
+  AND NOT h.deleted AND NOT h.compacted
+ORDER BY h.key
+LIMIT sqlc.arg(max_rows);
+
+-- name: CompactedInRange :one
+SELECT EXISTS (
+    SELECT 1 FROM history AS c
+    WHERE c.compacted AND c.namespace = sqlc.arg(namespace)
+      AND c.key >= sqlc.arg(first_key) AND c.key <= sqlc.arg(last_key)
+      AND COALESCE((
+        SELECT MAX(v.revision) FROM history AS v
+        WHERE v.namespace = c.namespace AND v.key = c.key AND v.revision <= sqlc.arg(revision)
+      ), c.revision) = c.revision
+);
+
+-- name: CreateNamespace :one
+INSERT INTO namespaces (
+    name, max_keys, max_bytes, created_at
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetNamespace :one
+SELECT * FROM namespaces
+WHERE name = ? LIMIT 1;
+
+-- name: ListNamespaces :many
+SELECT * FROM namespaces
+ORDER BY name;
+
+-- name: SetQuota :one
+UPDATE namespaces SET max_keys = ?, max_bytes = ?
+WHERE name = ?
+RETURNING *;
+
+-- name: DeleteNamespace :execrows
+DELETE FROM namespaces
+WHERE name = ? AND keys = 0;
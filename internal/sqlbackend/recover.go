@@ -0,0 +1,179 @@
+package sqlbackend
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend/sqlgen"
+)
+
+// recoverBatchSize is the number of journal records replayed per transaction.
+const recoverBatchSize = 1000
+
+// RecoveryTarget selects the point in time to recover to. The zero value
+// recovers all journaled changes.
+type RecoveryTarget struct {
+	// Seq is the number of the last change to recover, if not zero.
+	Seq int64
+	// Time is the commit time of the last change to recover, if not zero.
+	Time time.Time
+}
+
+// includes reports whether a journal record is not after the target.
+func (t RecoveryTarget) includes(record JournalRecord) bool {
+	if t.Seq != 0 && record.Seq > t.Seq {
+		return false
+	}
+	return t.Time.IsZero() || !record.CommittedAt.After(t.Time)
+}
+
+// Recover writes a backup of the data as of target to out, which must not
+// exist, by replaying the changes journaled in journalDir onto the backup at
+// backupPath. The journal must cover all changes after the backup; the
+// output can then be restored like any other backup.
+func Recover(ctx context.Context, backupPath, journalDir, out string, target RecoveryTarget) (BackupInfo, error) {
+	info, err := verifyBackup(backupPath)
+	if err != nil {
+		return BackupInfo{}, err
+	}
+	if err := copyFile(backupPath, out); err != nil {
+		return BackupInfo{}, err
+	}
+
+	info.LastSeq, err = replay(ctx, out, journalDir, info.LastSeq, target)
+	if err != nil {
+		os.Remove(out)
+		return BackupInfo{}, err
+	}
+
+	info.CreatedAt = time.Now()
+	if info.SHA256, info.Size, err = checksum(out); err != nil {
+		return BackupInfo{}, err
+	}
+	if err := writeMetadata(out, info); err != nil {
+		return BackupInfo{}, err
+	}
+	return info, nil
+}
+
+// replay applies the journaled changes after seq up to target to the
+// database at path, returning the number of the last change applied.
+func replay(ctx context.Context, path, journalDir string, seq int64, target RecoveryTarget) (int64, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	version, err := schemaVersion(ctx, conn, "main")
+	conn.Close()
+	if err != nil {
+		return 0, err
+	}
+	latest, err := latestMigration()
+	if err != nil {
+		return 0, err
+	}
+	if version != latest {
+		return 0, fmt.Errorf("%w: backup has version %d, journal is replayed onto %d", ErrSchemaMismatch, version, latest)
+	}
+
+	// Replay through the backend, so changes are applied and numbered like
+	// on a follower
+	s := &sqliteBackend{store: &store{db: db, q: sqlgen.New(db)}}
+	var batch []Change
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.Apply(ctx, batch); err != nil {
+			return fmt.Errorf("failed to replay journal: %w", err)
+		}
+		seq, batch = batch[len(batch)-1].Seq, batch[:0]
+		return nil
+	}
+
+	for record, err := range ReadJournal(journalDir) {
+		if err != nil {
+			return 0, err
+		}
+		if record.Seq <= seq {
+			continue
+		}
+		if !target.includes(record) {
+			break
+		}
+		if next := seq + int64(len(batch)) + 1; record.Seq != next {
+			return 0, fmt.Errorf("journal is missing changes %d to %d", next, record.Seq-1)
+		}
+		if record.Restored {
+			return 0, fmt.Errorf("journal records a restore at sequence number %d; recover from a backup made after it", record.Seq)
+		}
+
+		batch = append(batch, record.Change)
+		if len(batch) == recoverBatchSize {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// latestMigration returns the schema version the embedded migrations lead to.
+func latestMigration() (uint64, error) {
+	entries, err := fs.ReadDir(migrationsFS, "migrations")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint64
+	for _, entry := range entries {
+		prefix, _, _ := strings.Cut(entry.Name(), "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unexpected migration %s", entry.Name())
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
+
+// copyFile copies src to dst, which must not exist.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dst, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return fmt.Errorf("failed to copy backup: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return fmt.Errorf("failed to copy backup: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package sqlbackend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/dynoinc/gh-go/internal/sqlbackend/sqlgen"
+)
+
+// ErrFutureRevision is returned when reading a snapshot at a revision that
+// hasn't been committed yet.
+var ErrFutureRevision = errors.New("revision has not been committed")
+
+// Snapshots is implemented by backends serving reads of several keys that are
+// consistent with each other, as of a single revision. Reads at a revision
+// see every change numbered up to it and none after, however many writes are
+// committed in between, until compaction trims the versions they read.
+type Snapshots interface {
+	// SnapshotGet returns the values of the keys that existed as of
+	// revision, or the latest revision if zero, and the revision read.
+	SnapshotGet(ctx context.Context, keys []int64, revision int64) (map[int64]string, int64, error)
+	// SnapshotScan is Scan as of revision, or the latest revision if zero,
+	// returning the revision read.
+	SnapshotScan(ctx context.Context, first, last int64, limit int, revision int64) ([]KeyValue, int64, error)
+}
+
+func (s *sqliteBackend) SnapshotGet(ctx context.Context, keys []int64, revision int64) (map[int64]string, int64, error) {
+	values := make(map[int64]string, len(keys))
+	revision, err := s.snapshot(ctx, revision, func(q *sqlgen.Queries, revision int64, latest bool) error {
+		for _, key := range keys {
+			if latest {
+				row, err := q.Get(ctx, sqlgen.GetParams{Namespace: s.namespace, Key: key})
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				values[key] = row.Value
+				continue
+			}
+
+			version, err := getVersion(ctx, q, sqlgen.GetVersionParams{
+				Namespace:      s.namespace,
+				Key:            key,
+				MaxRevision:    revision,
+				MaxCommittedAt: math.MaxInt64,
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if !version.Deleted {
+				values[key] = version.Value
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return values, revision, nil
+}
+
+func (s *sqliteBackend) SnapshotScan(ctx context.Context, first, last int64, limit int, revision int64) ([]KeyValue, int64, error) {
+	var entries []KeyValue
+	revision, err := s.snapshot(ctx, revision, func(q *sqlgen.Queries, revision int64, latest bool) error {
+		if latest {
+			rows, err := q.Scan(ctx, sqlgen.ScanParams{
+				Namespace: s.namespace,
+				FirstKey:  first,
+				LastKey:   last,
+				MaxRows:   int64(limit),
+			})
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				entries = append(entries, KeyValue{Key: row.Key, Value: row.Value})
+			}
+			return nil
+		}
+
+		rows, err := q.ScanVersions(ctx, sqlgen.ScanVersionsParams{
+			Namespace: s.namespace,
+			FirstKey:  first,
+			LastKey:   last,
+			Revision:  revision,
+			MaxRows:   int64(limit),
+		})
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			entries = append(entries, KeyValue{Key: row.Key, Value: row.Value})
+		}
+
+		// Keys whose version was trimmed are missing from the rows, so check
+		// the range they cover for compacted versions
+		end := last
+		if len(rows) == limit {
+			end = rows[len(rows)-1].Key
+		}
+		compacted, err := q.CompactedInRange(ctx, sqlgen.CompactedInRangeParams{
+			Namespace: s.namespace,
+			FirstKey:  first,
+			LastKey:   end,
+			Revision:  revision,
+		})
+		if err != nil {
+			return err
+		}
+		if compacted != 0 {
+			return ErrCompacted
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, revision, nil
+}
+
+// snapshot runs fn in a read transaction as of revision, or the latest
+// revision if zero, returning the revision. The latest revision is read from
+// the current entries rather than the history.
+func (s *sqliteBackend) snapshot(
+	ctx context.Context,
+	revision int64,
+	fn func(q *sqlgen.Queries, revision int64, latest bool) error,
+) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	current, err := sequence(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	if revision > current {
+		return 0, fmt.Errorf("%w: revision %d is after the latest revision %d", ErrFutureRevision, revision, current)
+	}
+	if revision == 0 {
+		revision = current
+	}
+
+	if err := fn(s.q.WithTx(tx), revision, revision == current); err != nil {
+		return 0, err
+	}
+	return revision, tx.Commit()
+}
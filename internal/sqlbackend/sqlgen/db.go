@@ -7,6 +7,7 @@ package sqlgen
 import (
 	"context"
 	"database/sql"
+	"fmt"
 )
 
 type DBTX interface {
@@ -20,12 +21,258 @@ func New(db DBTX) *Queries {
 	return &Queries{db: db}
 }
 
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.addVersionStmt, err = db.PrepareContext(ctx, addVersion); err != nil {
+		return nil, fmt.Errorf("error preparing query AddVersion: %w", err)
+	}
+	if q.appendChangeStmt, err = db.PrepareContext(ctx, appendChange); err != nil {
+		return nil, fmt.Errorf("error preparing query AppendChange: %w", err)
+	}
+	if q.changesStmt, err = db.PrepareContext(ctx, changes); err != nil {
+		return nil, fmt.Errorf("error preparing query Changes: %w", err)
+	}
+	if q.compactedInRangeStmt, err = db.PrepareContext(ctx, compactedInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query CompactedInRange: %w", err)
+	}
+	if q.createNamespaceStmt, err = db.PrepareContext(ctx, createNamespace); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateNamespace: %w", err)
+	}
+	if q.deleteStmt, err = db.PrepareContext(ctx, delete); err != nil {
+		return nil, fmt.Errorf("error preparing query Delete: %w", err)
+	}
+	if q.deleteNamespaceStmt, err = db.PrepareContext(ctx, deleteNamespace); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteNamespace: %w", err)
+	}
+	if q.firstSeqStmt, err = db.PrepareContext(ctx, firstSeq); err != nil {
+		return nil, fmt.Errorf("error preparing query FirstSeq: %w", err)
+	}
+	if q.getStmt, err = db.PrepareContext(ctx, get); err != nil {
+		return nil, fmt.Errorf("error preparing query Get: %w", err)
+	}
+	if q.getNamespaceStmt, err = db.PrepareContext(ctx, getNamespace); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNamespace: %w", err)
+	}
+	if q.getVersionStmt, err = db.PrepareContext(ctx, getVersion); err != nil {
+		return nil, fmt.Errorf("error preparing query GetVersion: %w", err)
+	}
+	if q.insertChangeStmt, err = db.PrepareContext(ctx, insertChange); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertChange: %w", err)
+	}
+	if q.isCompactedStmt, err = db.PrepareContext(ctx, isCompacted); err != nil {
+		return nil, fmt.Errorf("error preparing query IsCompacted: %w", err)
+	}
+	if q.lastSeqStmt, err = db.PrepareContext(ctx, lastSeq); err != nil {
+		return nil, fmt.Errorf("error preparing query LastSeq: %w", err)
+	}
+	if q.listNamespacesStmt, err = db.PrepareContext(ctx, listNamespaces); err != nil {
+		return nil, fmt.Errorf("error preparing query ListNamespaces: %w", err)
+	}
+	if q.putStmt, err = db.PrepareContext(ctx, put); err != nil {
+		return nil, fmt.Errorf("error preparing query Put: %w", err)
+	}
+	if q.scanStmt, err = db.PrepareContext(ctx, scan); err != nil {
+		return nil, fmt.Errorf("error preparing query Scan: %w", err)
+	}
+	if q.scanVersionsStmt, err = db.PrepareContext(ctx, scanVersions); err != nil {
+		return nil, fmt.Errorf("error preparing query ScanVersions: %w", err)
+	}
+	if q.setQuotaStmt, err = db.PrepareContext(ctx, setQuota); err != nil {
+		return nil, fmt.Errorf("error preparing query SetQuota: %w", err)
+	}
+	if q.versionsStmt, err = db.PrepareContext(ctx, versions); err != nil {
+		return nil, fmt.Errorf("error preparing query Versions: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.addVersionStmt != nil {
+		if cerr := q.addVersionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addVersionStmt: %w", cerr)
+		}
+	}
+	if q.appendChangeStmt != nil {
+		if cerr := q.appendChangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing appendChangeStmt: %w", cerr)
+		}
+	}
+	if q.changesStmt != nil {
+		if cerr := q.changesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing changesStmt: %w", cerr)
+		}
+	}
+	if q.compactedInRangeStmt != nil {
+		if cerr := q.compactedInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing compactedInRangeStmt: %w", cerr)
+		}
+	}
+	if q.createNamespaceStmt != nil {
+		if cerr := q.createNamespaceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createNamespaceStmt: %w", cerr)
+		}
+	}
+	if q.deleteStmt != nil {
+		if cerr := q.deleteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStmt: %w", cerr)
+		}
+	}
+	if q.deleteNamespaceStmt != nil {
+		if cerr := q.deleteNamespaceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteNamespaceStmt: %w", cerr)
+		}
+	}
+	if q.firstSeqStmt != nil {
+		if cerr := q.firstSeqStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing firstSeqStmt: %w", cerr)
+		}
+	}
+	if q.getStmt != nil {
+		if cerr := q.getStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStmt: %w", cerr)
+		}
+	}
+	if q.getNamespaceStmt != nil {
+		if cerr := q.getNamespaceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNamespaceStmt: %w", cerr)
+		}
+	}
+	if q.getVersionStmt != nil {
+		if cerr := q.getVersionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getVersionStmt: %w", cerr)
+		}
+	}
+	if q.insertChangeStmt != nil {
+		if cerr := q.insertChangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertChangeStmt: %w", cerr)
+		}
+	}
+	if q.isCompactedStmt != nil {
+		if cerr := q.isCompactedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isCompactedStmt: %w", cerr)
+		}
+	}
+	if q.lastSeqStmt != nil {
+		if cerr := q.lastSeqStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lastSeqStmt: %w", cerr)
+		}
+	}
+	if q.listNamespacesStmt != nil {
+		if cerr := q.listNamespacesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listNamespacesStmt: %w", cerr)
+		}
+	}
+	if q.putStmt != nil {
+		if cerr := q.putStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing putStmt: %w", cerr)
+		}
+	}
+	if q.scanStmt != nil {
+		if cerr := q.scanStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing scanStmt: %w", cerr)
+		}
+	}
+	if q.scanVersionsStmt != nil {
+		if cerr := q.scanVersionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing scanVersionsStmt: %w", cerr)
+		}
+	}
+	if q.setQuotaStmt != nil {
+		if cerr := q.setQuotaStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setQuotaStmt: %w", cerr)
+		}
+	}
+	if q.versionsStmt != nil {
+		if cerr := q.versionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing versionsStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
 type Queries struct {
-	db DBTX
+	db                   DBTX
+	tx                   *sql.Tx
+	addVersionStmt       *sql.Stmt
+	appendChangeStmt     *sql.Stmt
+	changesStmt          *sql.Stmt
+	compactedInRangeStmt *sql.Stmt
+	createNamespaceStmt  *sql.Stmt
+	deleteStmt           *sql.Stmt
+	deleteNamespaceStmt  *sql.Stmt
+	firstSeqStmt         *sql.Stmt
+	getStmt              *sql.Stmt
+	getNamespaceStmt     *sql.Stmt
+	getVersionStmt       *sql.Stmt
+	insertChangeStmt     *sql.Stmt
+	isCompactedStmt      *sql.Stmt
+	lastSeqStmt          *sql.Stmt
+	listNamespacesStmt   *sql.Stmt
+	putStmt              *sql.Stmt
+	scanStmt             *sql.Stmt
+	scanVersionsStmt     *sql.Stmt
+	setQuotaStmt         *sql.Stmt
+	versionsStmt         *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db: tx,
+		db:                   tx,
+		tx:                   tx,
+		addVersionStmt:       q.addVersionStmt,
+		appendChangeStmt:     q.appendChangeStmt,
+		changesStmt:          q.changesStmt,
+		compactedInRangeStmt: q.compactedInRangeStmt,
+		createNamespaceStmt:  q.createNamespaceStmt,
+		deleteStmt:           q.deleteStmt,
+		deleteNamespaceStmt:  q.deleteNamespaceStmt,
+		firstSeqStmt:         q.firstSeqStmt,
+		getStmt:              q.getStmt,
+		getNamespaceStmt:     q.getNamespaceStmt,
+		getVersionStmt:       q.getVersionStmt,
+		insertChangeStmt:     q.insertChangeStmt,
+		isCompactedStmt:      q.isCompactedStmt,
+		lastSeqStmt:          q.lastSeqStmt,
+		listNamespacesStmt:   q.listNamespacesStmt,
+		putStmt:              q.putStmt,
+		scanStmt:             q.scanStmt,
+		scanVersionsStmt:     q.scanVersionsStmt,
+		setQuotaStmt:         q.setQuotaStmt,
+		versionsStmt:         q.versionsStmt,
 	}
 }
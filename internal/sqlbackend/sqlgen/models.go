@@ -4,7 +4,36 @@
 
 package sqlgen
 
+type Changelog struct {
+	Seq         int64
+	Key         int64
+	Value       string
+	Deleted     bool
+	CommittedAt int64
+	Namespace   string
+}
+
+type History struct {
+	Namespace   string
+	Key         int64
+	Revision    int64
+	Value       string
+	Deleted     bool
+	CommittedAt int64
+	Compacted   bool
+}
+
 type Keyvalue struct {
-	Key   int64
-	Value string
+	Namespace string
+	Key       int64
+	Value     string
+}
+
+type Namespace struct {
+	Name      string
+	MaxKeys   int64
+	MaxBytes  int64
+	Keys      int64
+	Bytes     int64
+	CreatedAt int64
 }
@@ -9,36 +9,576 @@ import (
 	"context"
 )
 
+const addVersion = `-- name: AddVersion :exec
+INSERT INTO history (
+    namespace, key, revision, value, deleted, committed_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+`
+
+type AddVersionParams struct {
+	Namespace   string
+	Key         int64
+	Revision    int64
+	Value       string
+	Deleted     bool
+	CommittedAt int64
+}
+
+func (q *Queries) AddVersion(ctx context.Context, arg AddVersionParams) error {
+	_, err := q.exec(ctx, q.addVersionStmt, addVersion,
+		arg.Namespace,
+		arg.Key,
+		arg.Revision,
+		arg.Value,
+		arg.Deleted,
+		arg.CommittedAt,
+	)
+	return err
+}
+
+const appendChange = `-- name: AppendChange :one
+INSERT INTO changelog (
+    namespace, key, value, deleted, committed_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+RETURNING seq
+`
+
+type AppendChangeParams struct {
+	Namespace   string
+	Key         int64
+	Value       string
+	Deleted     bool
+	CommittedAt int64
+}
+
+func (q *Queries) AppendChange(ctx context.Context, arg AppendChangeParams) (int64, error) {
+	row := q.queryRow(ctx, q.appendChangeStmt, appendChange,
+		arg.Namespace,
+		arg.Key,
+		arg.Value,
+		arg.Deleted,
+		arg.CommittedAt,
+	)
+	var seq int64
+	err := row.Scan(&seq)
+	return seq, err
+}
+
+const changes = `-- name: Changes :many
+SELECT seq, "key", value, deleted, committed_at, namespace FROM changelog
+WHERE seq > ?1
+ORDER BY seq
+LIMIT ?2
+`
+
+type ChangesParams struct {
+	AfterSeq int64
+	MaxRows  int64
+}
+
+func (q *Queries) Changes(ctx context.Context, arg ChangesParams) ([]Changelog, error) {
+	rows, err := q.query(ctx, q.changesStmt, changes, arg.AfterSeq, arg.MaxRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Changelog
+	for rows.Next() {
+		var i Changelog
+		if err := rows.Scan(
+			&i.Seq,
+			&i.Key,
+			&i.Value,
+			&i.Deleted,
+			&i.CommittedAt,
+			&i.Namespace,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const compactedInRange = `-- name: CompactedInRange :one
+SELECT EXISTS (
+    SELECT 1 FROM history AS c
+    WHERE c.compacted AND c.namespace = ?1
+      AND c.key >= ?2 AND c.key <= ?3
+      AND COALESCE((
+        SELECT MAX(v.revision) FROM history AS v
+        WHERE v.namespace = c.namespace AND v.key = c.key AND v.revision <= ?4
+      ), c.revision) = c.revision
+)
+`
+
+type CompactedInRangeParams struct {
+	Namespace string
+	FirstKey  int64
+	LastKey   int64
+	Revision  int64
+}
+
+func (q *Queries) CompactedInRange(ctx context.Context, arg CompactedInRangeParams) (int64, error) {
+	row := q.queryRow(ctx, q.compactedInRangeStmt, compactedInRange,
+		arg.Namespace,
+		arg.FirstKey,
+		arg.LastKey,
+		arg.Revision,
+	)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const createNamespace = `-- name: CreateNamespace :one
+INSERT INTO namespaces (
+    name, max_keys, max_bytes, created_at
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING name, max_keys, max_bytes, keys, bytes, created_at
+`
+
+type CreateNamespaceParams struct {
+	Name      string
+	MaxKeys   int64
+	MaxBytes  int64
+	CreatedAt int64
+}
+
+func (q *Queries) CreateNamespace(ctx context.Context, arg CreateNamespaceParams) (Namespace, error) {
+	row := q.queryRow(ctx, q.createNamespaceStmt, createNamespace,
+		arg.Name,
+		arg.MaxKeys,
+		arg.MaxBytes,
+		arg.CreatedAt,
+	)
+	var i Namespace
+	err := row.Scan(
+		&i.Name,
+		&i.MaxKeys,
+		&i.MaxBytes,
+		&i.Keys,
+		&i.Bytes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const delete = `-- name: Delete :execrows
+DELETE FROM keyvalue
+WHERE namespace = ? AND key = ?
+`
+
+type DeleteParams struct {
+	Namespace string
+	Key       int64
+}
+
+func (q *Queries) Delete(ctx context.Context, arg DeleteParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteStmt, delete, arg.Namespace, arg.Key)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteNamespace = `-- name: DeleteNamespace :execrows
+DELETE FROM namespaces
+WHERE name = ? AND keys = 0
+`
+
+func (q *Queries) DeleteNamespace(ctx context.Context, name string) (int64, error) {
+	result, err := q.exec(ctx, q.deleteNamespaceStmt, deleteNamespace, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const firstSeq = `-- name: FirstSeq :one
+SELECT CAST(COALESCE(MIN(seq), 0) AS INTEGER) FROM changelog
+`
+
+func (q *Queries) FirstSeq(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.firstSeqStmt, firstSeq)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const get = `-- name: Get :one
-SELECT "key", value FROM keyvalue
-WHERE key = ? LIMIT 1
+SELECT namespace, "key", value FROM keyvalue
+WHERE namespace = ? AND key = ? LIMIT 1
 `
 
-func (q *Queries) Get(ctx context.Context, key int64) (Keyvalue, error) {
-	row := q.db.QueryRowContext(ctx, get, key)
+type GetParams struct {
+	Namespace string
+	Key       int64
+}
+
+func (q *Queries) Get(ctx context.Context, arg GetParams) (Keyvalue, error) {
+	row := q.queryRow(ctx, q.getStmt, get, arg.Namespace, arg.Key)
 	var i Keyvalue
-	err := row.Scan(&i.Key, &i.Value)
+	err := row.Scan(&i.Namespace, &i.Key, &i.Value)
+	return i, err
+}
+
+const getNamespace = `-- name: GetNamespace :one
+SELECT name, max_keys, max_bytes, keys, bytes, created_at FROM namespaces
+WHERE name = ? LIMIT 1
+`
+
+func (q *Queries) GetNamespace(ctx context.Context, name string) (Namespace, error) {
+	row := q.queryRow(ctx, q.getNamespaceStmt, getNamespace, name)
+	var i Namespace
+	err := row.Scan(
+		&i.Name,
+		&i.MaxKeys,
+		&i.MaxBytes,
+		&i.Keys,
+		&i.Bytes,
+		&i.CreatedAt,
+	)
 	return i, err
 }
 
+const getVersion = `-- name: GetVersion :one
+SELECT namespace, "key", revision, value, deleted, committed_at, compacted FROM history
+WHERE namespace = ?1 AND key = ?2
+  AND revision <= ?3
+  AND committed_at <= ?4
+ORDER BY revision DESC
+LIMIT 1
+`
+
+type GetVersionParams struct {
+	Namespace      string
+	Key            int64
+	MaxRevision    int64
+	MaxCommittedAt int64
+}
+
+func (q *Queries) GetVersion(ctx context.Context, arg GetVersionParams) (History, error) {
+	row := q.queryRow(ctx, q.getVersionStmt, getVersion,
+		arg.Namespace,
+		arg.Key,
+		arg.MaxRevision,
+		arg.MaxCommittedAt,
+	)
+	var i History
+	err := row.Scan(
+		&i.Namespace,
+		&i.Key,
+		&i.Revision,
+		&i.Value,
+		&i.Deleted,
+		&i.CommittedAt,
+		&i.Compacted,
+	)
+	return i, err
+}
+
+const insertChange = `-- name: InsertChange :exec
+INSERT INTO changelog (
+    seq, namespace, key, value, deleted, committed_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+`
+
+type InsertChangeParams struct {
+	Seq         int64
+	Namespace   string
+	Key         int64
+	Value       string
+	Deleted     bool
+	CommittedAt int64
+}
+
+func (q *Queries) InsertChange(ctx context.Context, arg InsertChangeParams) error {
+	_, err := q.exec(ctx, q.insertChangeStmt, insertChange,
+		arg.Seq,
+		arg.Namespace,
+		arg.Key,
+		arg.Value,
+		arg.Deleted,
+		arg.CommittedAt,
+	)
+	return err
+}
+
+const isCompacted = `-- name: IsCompacted :one
+SELECT EXISTS (
+    SELECT 1 FROM history WHERE namespace = ? AND key = ? AND compacted
+)
+`
+
+type IsCompactedParams struct {
+	Namespace string
+	Key       int64
+}
+
+func (q *Queries) IsCompacted(ctx context.Context, arg IsCompactedParams) (int64, error) {
+	row := q.queryRow(ctx, q.isCompactedStmt, isCompacted, arg.Namespace, arg.Key)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const lastSeq = `-- name: LastSeq :one
+SELECT CAST(COALESCE(MAX(seq), 0) AS INTEGER) FROM changelog
+`
+
+func (q *Queries) LastSeq(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.lastSeqStmt, lastSeq)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const listNamespaces = `-- name: ListNamespaces :many
+SELECT name, max_keys, max_bytes, keys, bytes, created_at FROM namespaces
+ORDER BY name
+`
+
+func (q *Queries) ListNamespaces(ctx context.Context) ([]Namespace, error) {
+	rows, err := q.query(ctx, q.listNamespacesStmt, listNamespaces)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Namespace
+	for rows.Next() {
+		var i Namespace
+		if err := rows.Scan(
+			&i.Name,
+			&i.MaxKeys,
+			&i.MaxBytes,
+			&i.Keys,
+			&i.Bytes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const put = `-- name: Put :one
 INSERT INTO keyvalue (
-    key, value
+    namespace, key, value
 ) VALUES (
-    ?, ?
-) ON CONFLICT(key) DO UPDATE SET
+    ?, ?, ?
+) ON CONFLICT(namespace, key) DO UPDATE SET
     value = excluded.value
-RETURNING "key", value
+RETURNING namespace, "key", value
 `
 
 type PutParams struct {
-	Key   int64
-	Value string
+	Namespace string
+	Key       int64
+	Value     string
 }
 
 func (q *Queries) Put(ctx context.Context, arg PutParams) (Keyvalue, error) {
-	row := q.db.QueryRowContext(ctx, put, arg.Key, arg.Value)
+	row := q.queryRow(ctx, q.putStmt, put, arg.Namespace, arg.Key, arg.Value)
 	var i Keyvalue
-	err := row.Scan(&i.Key, &i.Value)
+	err := row.Scan(&i.Namespace, &i.Key, &i.Value)
+	return i, err
+}
+
+const scan = `-- name: Scan :many
+SELECT namespace, "key", value FROM keyvalue
+WHERE namespace = ?1 AND key >= ?2 AND key <= ?3
+ORDER BY key
+LIMIT ?4
+`
+
+type ScanParams struct {
+	Namespace string
+	FirstKey  int64
+	LastKey   int64
+	MaxRows   int64
+}
+
+func (q *Queries) Scan(ctx context.Context, arg ScanParams) ([]Keyvalue, error) {
+	rows, err := q.query(ctx, q.scanStmt, scan,
+		arg.Namespace,
+		arg.FirstKey,
+		arg.LastKey,
+		arg.MaxRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Keyvalue
+	for rows.Next() {
+		var i Keyvalue
+		if err := rows.Scan(&i.Namespace, &i.Key, &i.Value); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const scanVersions = `-- name: ScanVersions :many
+SELECT h.namespace, h."key", h.revision, h.value, h.deleted, h.committed_at, h.compacted FROM history AS h
+WHERE h.namespace = ?1
+  AND h.key >= ?2 AND h.key <= ?3
+  AND h.revision = (
+    SELECT MAX(v.revision) FROM history AS v
+    WHERE v.namespace = h.namespace AND v.key = h.key AND v.revision <= ?4
+  )
+  AND NOT h.deleted AND NOT h.compacted
+ORDER BY h.key
+LIMIT ?5
+`
+
+type ScanVersionsParams struct {
+	Namespace string
+	FirstKey  int64
+	LastKey   int64
+	Revision  int64
+	MaxRows   int64
+}
+
+func (q *Queries) ScanVersions(ctx context.Context, arg ScanVersionsParams) ([]History, error) {
+	rows, err := q.query(ctx, q.scanVersionsStmt, scanVersions,
+		arg.Namespace,
+		arg.FirstKey,
+		arg.LastKey,
+		arg.Revision,
+		arg.MaxRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []History
+	for rows.Next() {
+		var i History
+		if err := rows.Scan(
+			&i.Namespace,
+			&i.Key,
+			&i.Revision,
+			&i.Value,
+			&i.Deleted,
+			&i.CommittedAt,
+			&i.Compacted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setQuota = `-- name: SetQuota :one
+UPDATE namespaces SET max_keys = ?, max_bytes = ?
+WHERE name = ?
+RETURNING name, max_keys, max_bytes, keys, bytes, created_at
+`
+
+type SetQuotaParams struct {
+	MaxKeys  int64
+	MaxBytes int64
+	Name     string
+}
+
+func (q *Queries) SetQuota(ctx context.Context, arg SetQuotaParams) (Namespace, error) {
+	row := q.queryRow(ctx, q.setQuotaStmt, setQuota, arg.MaxKeys, arg.MaxBytes, arg.Name)
+	var i Namespace
+	err := row.Scan(
+		&i.Name,
+		&i.MaxKeys,
+		&i.MaxBytes,
+		&i.Keys,
+		&i.Bytes,
+		&i.CreatedAt,
+	)
 	return i, err
 }
+
+const versions = `-- name: Versions :many
+SELECT namespace, "key", revision, value, deleted, committed_at, compacted FROM history
+WHERE namespace = ?1 AND key = ?2
+  AND revision < ?3
+ORDER BY revision DESC
+LIMIT ?4
+`
+
+type VersionsParams struct {
+	Namespace      string
+	Key            int64
+	BeforeRevision int64
+	MaxRows        int64
+}
+
+func (q *Queries) Versions(ctx context.Context, arg VersionsParams) ([]History, error) {
+	rows, err := q.query(ctx, q.versionsStmt, versions,
+		arg.Namespace,
+		arg.Key,
+		arg.BeforeRevision,
+		arg.MaxRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []History
+	for rows.Next() {
+		var i History
+		if err := rows.Scan(
+			&i.Namespace,
+			&i.Key,
+			&i.Revision,
+			&i.Value,
+			&i.Deleted,
+			&i.CommittedAt,
+			&i.Compacted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,135 @@
+package itest
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	channelzgrpc "google.golang.org/grpc/channelz/grpc_channelz_v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/internal/admin"
+	"github.com/dynoinc/gh-go/internal/config"
+)
+
+const testAdminToken = "admin-secret"
+
+// setupAdminServer starts the admin listener on a random local port and
+// returns its address
+func setupAdminServer(t *testing.T, level *slog.LevelVar) string {
+	cfg := config.Config{Port: 5051, AdminPort: 5052, AdminToken: testAdminToken}
+
+	handler, cleanup, err := admin.NewHandler(
+		admin.WithToken(testAdminToken),
+		admin.WithConfig(func() any { return cfg.Redacted() }),
+		admin.WithLevel(level),
+	)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := admin.NewServer(handler)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	t.Cleanup(func() {
+		require.NoError(t, server.Close())
+		cleanup()
+	})
+
+	return lis.Addr().String()
+}
+
+func adminRequest(t *testing.T, method, url, token string) (int, string) {
+	req, err := http.NewRequestWithContext(t.Context(), method, url, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(body)
+}
+
+func TestAdminRequiresToken(t *testing.T) {
+	_, _, err := admin.NewHandler()
+	require.Error(t, err)
+
+	addr := setupAdminServer(t, new(slog.LevelVar))
+
+	code, _ := adminRequest(t, http.MethodGet, "http://"+addr+"/debug/config", "")
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	code, _ = adminRequest(t, http.MethodGet, "http://"+addr+"/debug/config", "wrong")
+	require.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestAdminEndpoints(t *testing.T) {
+	level := new(slog.LevelVar)
+	addr := setupAdminServer(t, level)
+	base := "http://" + addr
+
+	// Config dump must not leak secrets
+	code, body := adminRequest(t, http.MethodGet, base+"/debug/config", testAdminToken)
+	require.Equal(t, http.StatusOK, code)
+	require.NotContains(t, body, testAdminToken)
+	var dumped config.Config
+	require.NoError(t, json.Unmarshal([]byte(body), &dumped))
+	require.Equal(t, 5051, dumped.Port)
+	require.Equal(t, "REDACTED", dumped.AdminToken)
+
+	code, body = adminRequest(t, http.MethodGet, base+"/debug/buildinfo", testAdminToken)
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, body, "go_version")
+
+	code, body = adminRequest(t, http.MethodGet, base+"/debug/pprof/", testAdminToken)
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, body, "goroutine")
+
+	// Change the log level at runtime
+	code, _ = adminRequest(t, http.MethodPut, base+"/debug/loglevel?level=debug", testAdminToken)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, slog.LevelDebug, level.Level())
+
+	code, body = adminRequest(t, http.MethodGet, base+"/debug/loglevel", testAdminToken)
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, strings.Contains(body, "DEBUG"))
+
+	code, _ = adminRequest(t, http.MethodPut, base+"/debug/loglevel?level=loud", testAdminToken)
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestAdminChannelz(t *testing.T) {
+	addr := setupAdminServer(t, new(slog.LevelVar))
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	cz := channelzgrpc.NewChannelzClient(conn)
+
+	// Calls without the admin token are rejected
+	_, err = cz.GetServers(t.Context(), &channelzgrpc.GetServersRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(t.Context(), "authorization", "Bearer "+testAdminToken)
+	_, err = cz.GetServers(ctx, &channelzgrpc.GetServersRequest{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,261 @@
+package itest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/admin"
+	"github.com/dynoinc/gh-go/internal/audit"
+	"github.com/dynoinc/gh-go/internal/frontend"
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+// setupAuditServer serves a frontend auditing writes to a log at path and an
+// admin listener querying it, returning their addresses.
+func setupAuditServer(t *testing.T, path string) (string, string) {
+	log, err := audit.Open(path, []byte("audit-key"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, log.Close()) })
+	addr := startTCPServer(t, frontend.WithAuthToken("secret"), frontend.WithAuditLog(log))
+
+	handler, adminCleanup, err := admin.NewHandler(admin.WithToken(testAdminToken), admin.WithAudit(log))
+	require.NoError(t, err)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := admin.NewServer(handler)
+	go server.Serve(lis)
+	t.Cleanup(func() {
+		require.NoError(t, server.Close())
+		adminCleanup()
+	})
+
+	return addr, lis.Addr().String()
+}
+
+func TestAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	addr, adminAddr := setupAuditServer(t, path)
+	run := func(stdin string, args ...string) string {
+		code, stdout, stderr := runCLI(t, stdin, append([]string{"-addr", addr, "-token", "secret"}, args...)...)
+		require.Equal(t, 0, code, stderr)
+		return stdout
+	}
+
+	run("", "put", "1", "one")
+	run("", "put", "1", "two")
+	run("", "delete", "1")
+	code, _, _ := runCLI(t, "", "-addr", addr, "-token", "secret", "delete", "1")
+	require.Equal(t, 1, code) // nothing changed, so nothing is audited
+	run(`{"key":2,"value":"a"}`+"\n"+`{"key":2,"value":"b"}`+"\n", "import")
+
+	queryAudit := func(args ...string) []audit.Entry {
+		code, stdout, stderr := runCLI(t, "", append([]string{"-admin-addr", adminAddr, "-admin-token", testAdminToken, "-o", "json", "audit"}, args...)...)
+		require.Equal(t, 0, code, stderr)
+		require.Contains(t, stderr, "head: entry 5")
+
+		var entries []audit.Entry
+		dec := json.NewDecoder(strings.NewReader(stdout))
+		for dec.More() {
+			var entry audit.Entry
+			require.NoError(t, dec.Decode(&entry))
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+
+	entries := queryAudit()
+	require.Len(t, entries, 5)
+	for i, entry := range entries {
+		require.Equal(t, int64(i+1), entry.Seq)
+		require.Equal(t, entries[0].Principal, entry.Principal)
+		require.NotEmpty(t, entry.Peer)
+	}
+	require.True(t, strings.HasPrefix(entries[0].Principal, "token:"))
+	require.NotContains(t, entries[0].Principal, "secret")
+
+	type change struct {
+		method           string
+		key              int64
+		oldHash, newHash string
+	}
+	var changes []change
+	for _, entry := range entries {
+		changes = append(changes, change{entry.Method, entry.Key, entry.OldHash, entry.NewHash})
+	}
+	require.Equal(t, []change{
+		{"/frontend.v1.FrontendService/Put", 1, "", audit.ValueHash("one")},
+		{"/frontend.v1.FrontendService/Put", 1, audit.ValueHash("one"), audit.ValueHash("two")},
+		{"/frontend.v1.FrontendService/Delete", 1, audit.ValueHash("two"), ""},
+		{"/frontend.v1.FrontendService/Import", 2, "", audit.ValueHash("a")},
+		{"/frontend.v1.FrontendService/Import", 2, audit.ValueHash("a"), audit.ValueHash("b")},
+	}, changes)
+
+	// Queries filter and page
+	entries = queryAudit("-key", "1", "-after", "1")
+	require.Len(t, entries, 2)
+	require.Equal(t, int64(2), entries[0].Seq)
+	entries = queryAudit("-method", "/frontend.v1.FrontendService/Import", "-limit", "1")
+	require.Len(t, entries, 1)
+	require.Equal(t, int64(4), entries[0].Seq)
+
+	// The file verifies with the key only
+	code, stdout, stderr := runCLI(t, "", "-o", "json", "audit-verify", "-key", "audit-key", path)
+	require.Equal(t, 0, code, stderr)
+	require.Contains(t, stdout, `"entries":5`)
+	code, _, stderr = runCLI(t, "", "audit-verify", "-key", "other", path)
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "entry 1 doesn't match its hash")
+}
+
+func TestAuditTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	log, err := audit.Open(path, nil)
+	require.NoError(t, err)
+	for key := range int64(3) {
+		require.NoError(t, log.Append(audit.Entry{Principal: "p", Method: "m", Key: key, NewHash: audit.ValueHash("v")}))
+	}
+	require.NoError(t, log.Close())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := bytes.SplitAfter(data, []byte("\n"))
+
+	tampered := filepath.Join(t.TempDir(), "tampered.log")
+	verify := func(data []byte) error {
+		require.NoError(t, os.WriteFile(tampered, data, 0o600))
+		_, err := audit.Verify(tampered, nil)
+		return err
+	}
+
+	// Changing, removing or reordering entries breaks the chain
+	changed := bytes.Replace(data, []byte(`"key":1`), []byte(`"key":7`), 1)
+	require.ErrorContains(t, verify(changed), "entry 2 doesn't match its hash")
+	require.ErrorIs(t, verify(bytes.Join([][]byte{lines[0], lines[2]}, nil)), audit.ErrTampered)
+	require.ErrorIs(t, verify(bytes.Join([][]byte{lines[1], lines[0], lines[2]}, nil)), audit.ErrTampered)
+	_, err = audit.Open(tampered, nil)
+	require.ErrorIs(t, err, audit.ErrTampered)
+
+	// An entry torn by a crash is dropped when the log is opened again
+	torn := append(bytes.Clone(data), []byte(`{"seq":4,"ti`)...)
+	require.NoError(t, verify(torn))
+	log, err = audit.Open(tampered, nil)
+	require.NoError(t, err)
+	require.NoError(t, log.Append(audit.Entry{Principal: "p", Method: "m", Key: 3}))
+	seq, head := log.Head()
+	require.NoError(t, log.Close())
+
+	last, err := audit.Verify(tampered, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), seq)
+	require.Equal(t, head, last.Hash)
+}
+
+// openAuditLog opens an audit log in a temporary directory.
+func openAuditLog(t *testing.T) *audit.Log {
+	log, err := audit.Open(filepath.Join(t.TempDir(), "audit.log"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, log.Close()) })
+	return log
+}
+
+// auditedChange is what an audit entry says about a forwarded write.
+type auditedChange struct {
+	principal, via string
+	key            int64
+	newHash        string
+}
+
+func auditedChanges(t *testing.T, log *audit.Log) []auditedChange {
+	entries, err := log.Query(audit.Filter{})
+	require.NoError(t, err)
+	var changes []auditedChange
+	for _, entry := range entries {
+		require.NotEmpty(t, entry.Peer)
+		changes = append(changes, auditedChange{entry.Principal, entry.Via, entry.Key, entry.NewHash})
+	}
+	return changes
+}
+
+func TestAuditForwardedWrites(t *testing.T) {
+	t.Run("replication", func(t *testing.T) {
+		primaryLog, followerLog := openAuditLog(t), openAuditLog(t)
+		primary := startReplicationNode(t, frontend.WithAuthToken("secret"), frontend.WithPeerToken("peer"), frontend.WithAuditLog(primaryLog))
+		follower := startReplicationNode(t, frontend.WithAuditLog(followerLog), frontend.WithReplication(frontend.ReplicationConfig{
+			Primary:       "passthrough:///primary",
+			ForwardWrites: true,
+			AuthToken:     func() string { return "secret" },
+			PeerToken:     func() string { return "peer" },
+			DialOptions: []grpc.DialOption{grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return primary.lis.DialContext(ctx)
+			})},
+		}))
+
+		// The primary audits the write as the caller's, made via the
+		// follower, which audits nothing
+		require.NoError(t, follower.client.Put(t.Context(), 1, "one"))
+		changes := auditedChanges(t, primaryLog)
+		require.Len(t, changes, 1)
+		require.Equal(t, auditedChange{"anonymous", changes[0].via, 1, audit.ValueHash("one")}, changes[0])
+		require.True(t, strings.HasPrefix(changes[0].via, "token:"))
+		require.Empty(t, auditedChanges(t, followerLog))
+	})
+
+	t.Run("cluster", func(t *testing.T) {
+		logs := []*audit.Log{openAuditLog(t), openAuditLog(t)}
+		tc := newTestCluster(t)
+		a := tc.start("a", true, frontend.WithAuditLog(logs[0]))
+		require.Equal(t, a.id, leader(t, a))
+		b := tc.join(a, "b", frontend.WithAuditLog(logs[1]))
+
+		// Only the leader audits writes, including those forwarded to it
+		require.NoError(t, b.client.BatchPut(t.Context(), []client.KeyValue{{Key: 1, Value: "one"}, {Key: 2, Value: "two"}}))
+		_, err := b.client.Delete(t.Context(), 2)
+		require.NoError(t, err)
+		require.Equal(t, []auditedChange{
+			{"anonymous", "anonymous", 1, audit.ValueHash("one")},
+			{"anonymous", "anonymous", 2, audit.ValueHash("two")},
+			{"anonymous", "anonymous", 2, ""},
+		}, auditedChanges(t, logs[0]))
+		require.Empty(t, auditedChanges(t, logs[1]))
+	})
+}
+
+func TestAuditIgnoresUntrustedCallers(t *testing.T) {
+	log := openAuditLog(t)
+	node := startReplicationNode(t, frontend.WithAuthToken("secret"), frontend.WithPeerToken("peer"), frontend.WithAuditLog(log))
+	put := func(key int64, md ...string) {
+		ctx := metadata.AppendToOutgoingContext(t.Context(), append([]string{"authorization", "Bearer secret"}, md...)...)
+		_, err := frontendpb.NewFrontendServiceClient(node.conn).Put(ctx, frontendpb.PutRequest_builder{Key: key, Value: "value"}.Build())
+		require.NoError(t, err)
+	}
+
+	// Callers without the peer token can't name someone else as the caller
+	put(1, "gh-go-caller-principal", "alice", "gh-go-caller-peer", "10.0.0.1:1234")
+	put(2, "gh-go-caller-principal", "alice", "gh-go-peer-token", "wrong")
+	put(3, "gh-go-caller-principal", "alice", "gh-go-peer-token", "peer")
+
+	entries, err := log.Query(audit.Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	for _, entry := range entries[:2] {
+		require.True(t, strings.HasPrefix(entry.Principal, "token:"), entry.Principal)
+		require.Empty(t, entry.Via)
+		require.NotEqual(t, "10.0.0.1:1234", entry.Peer)
+	}
+	require.Equal(t, "alice", entries[2].Principal)
+	require.Equal(t, entries[0].Principal, entries[2].Via)
+
+	entries, err = log.Query(audit.Filter{Principal: "alice"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
@@ -0,0 +1,170 @@
+package itest
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/admin"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+// setupBackupServer serves a frontend and an admin listener for its backend,
+// returning a client and the admin address.
+func setupBackupServer(t *testing.T, opts ...sqlbackend.Option) (*client.Client, string) {
+	backend, err := sqlbackend.New(t.Context(), opts...)
+	require.NoError(t, err)
+	c, cleanup := setupTestServer(t, backend)
+	t.Cleanup(cleanup)
+	return c, serveAdmin(t, admin.WithToken(testAdminToken), admin.WithBackups(backend.(sqlbackend.Backupper)))
+}
+
+// serveAdmin serves an admin listener until the test ends and returns its
+// address
+func serveAdmin(t *testing.T, opts ...admin.Option) string {
+	handler, adminCleanup, err := admin.NewHandler(opts...)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := admin.NewServer(handler)
+	go server.Serve(lis)
+	t.Cleanup(func() {
+		require.NoError(t, server.Close())
+		adminCleanup()
+	})
+
+	return lis.Addr().String()
+}
+
+func TestBackupRestore(t *testing.T) {
+	c, adminAddr := setupBackupServer(t)
+	runAdmin := func(args ...string) (int, string, string) {
+		return runCLI(t, "", append([]string{"-admin-addr", adminAddr, "-admin-token", testAdminToken, "-o", "json"}, args...)...)
+	}
+
+	require.NoError(t, c.Put(t.Context(), 1, "one"))
+	require.NoError(t, c.Put(t.Context(), 2, "two"))
+
+	path := filepath.Join(t.TempDir(), "backup.db")
+	code, stdout, stderr := runAdmin("backup", path)
+	require.Equal(t, 0, code, stderr)
+
+	// The metadata is returned and stored next to the backup
+	var info sqlbackend.BackupInfo
+	require.NoError(t, json.Unmarshal([]byte(stdout), &info))
+	require.Equal(t, uint64(4), info.SchemaVersion)
+	require.Equal(t, int64(2), info.LastSeq)
+	require.Equal(t, fileChecksum(t, path), info.SHA256)
+
+	data, err := os.ReadFile(sqlbackend.MetadataPath(path))
+	require.NoError(t, err)
+	var stored sqlbackend.BackupInfo
+	require.NoError(t, json.Unmarshal(data, &stored))
+	require.Equal(t, info.SHA256, stored.SHA256)
+
+	// Backups don't overwrite files and need the admin token
+	code, _, stderr = runAdmin("backup", path)
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "AlreadyExists")
+	code, _, _ = runCLI(t, "", "-admin-addr", adminAddr, "backup", filepath.Join(t.TempDir(), "other.db"))
+	require.Equal(t, 2, code)
+	code, _, stderr = runAdmin("backup", "relative.db")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "must be absolute")
+
+	// Restoring replaces all data
+	require.NoError(t, c.Put(t.Context(), 3, "three"))
+	_, err = c.Delete(t.Context(), 1)
+	require.NoError(t, err)
+	code, _, stderr = runAdmin("restore", path)
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, []client.KeyValue{{Key: 1, Value: "one"}, {Key: 2, Value: "two"}}, entries(t, c))
+
+	// Corrupted backups and backups of another schema version are rejected
+	require.NoError(t, c.Put(t.Context(), 3, "three"))
+	corrupted := copyBackup(t, path)
+	require.NoError(t, os.WriteFile(corrupted, append(readFile(t, corrupted), 0), 0o600))
+	code, _, stderr = runAdmin("restore", corrupted)
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "checksum mismatch")
+
+	migrated := copyBackup(t, path)
+	db, err := sql.Open("sqlite", migrated)
+	require.NoError(t, err)
+	_, err = db.Exec("UPDATE schema_migrations SET version = 99")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	info.SHA256, info.Size = fileChecksum(t, migrated), int64(len(readFile(t, migrated)))
+	data, err = json.Marshal(info)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(sqlbackend.MetadataPath(migrated), data, 0o600))
+	code, _, stderr = runAdmin("restore", migrated)
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "schema version mismatch")
+
+	require.Len(t, entries(t, c), 3)
+}
+
+func TestRestoreEndsWatches(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+	addr := serveTCP(t, backend)
+	adminAddr := serveAdmin(t, admin.WithToken(testAdminToken), admin.WithBackups(backend.(sqlbackend.Backupper)))
+	c, err := client.New(client.WithTarget(addr), client.WithInsecure(), client.WithCache(client.CacheConfig{Size: 10, TTL: time.Hour}))
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.Put(t.Context(), 1, "before"))
+	path := filepath.Join(t.TempDir(), "backup.db")
+	code, _, stderr := runCLI(t, "", "-admin-addr", adminAddr, "-admin-token", testAdminToken, "backup", path)
+	require.Equal(t, 0, code, stderr)
+
+	// Wait until the cache serves the value, which shows once writes
+	// bypassing the server go unnoticed
+	require.Eventually(t, func() bool {
+		require.NoError(t, c.Put(t.Context(), 1, "after"))
+		_, err := c.Get(t.Context(), 1)
+		require.NoError(t, err)
+		require.NoError(t, backend.Put(t.Context(), 1, "unpublished"))
+		value, err := c.Get(t.Context(), 1)
+		return err == nil && value == "after"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Restoring ends the cache's watch stream, so it drops its entries
+	code, _, stderr = runCLI(t, "", "-admin-addr", adminAddr, "-admin-token", testAdminToken, "restore", path)
+	require.Equal(t, 0, code, stderr)
+	require.Eventually(t, func() bool {
+		value, err := c.Get(t.Context(), 1)
+		return err == nil && value == "before"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// copyBackup copies a backup and its metadata to a new path.
+func copyBackup(t *testing.T, path string) string {
+	dst := filepath.Join(t.TempDir(), filepath.Base(path))
+	for _, name := range [][2]string{{path, dst}, {sqlbackend.MetadataPath(path), sqlbackend.MetadataPath(dst)}} {
+		require.NoError(t, os.WriteFile(name[1], readFile(t, name[0]), 0o600))
+	}
+	return dst
+}
+
+func readFile(t *testing.T, path string) []byte {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return data
+}
+
+func fileChecksum(t *testing.T, path string) string {
+	sum := sha256.Sum256(readFile(t, path))
+	return hex.EncodeToString(sum[:])
+}
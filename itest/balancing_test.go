@@ -0,0 +1,302 @@
+package itest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/frontend"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+// replica is an in-process frontend whose Get calls are counted and can be
+// slowed down or failed.
+type replica struct {
+	health *health.Server
+	gets   atomic.Int64
+	delay  atomic.Int64 // nanoseconds
+	fail   atomic.Bool
+}
+
+func (r *replica) setServing(serving bool) {
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if !serving {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	r.health.SetServingStatus(frontendpb.FrontendService_ServiceDesc.ServiceName, status)
+}
+
+type replicaBackend struct {
+	sqlbackend.Backend
+	r *replica
+}
+
+func (b replicaBackend) Get(ctx context.Context, key int64) (string, error) {
+	b.r.gets.Add(1)
+	time.Sleep(time.Duration(b.r.delay.Load()))
+	if b.r.fail.Load() {
+		return "", errors.New("replica failed")
+	}
+	return b.Backend.Get(ctx, key)
+}
+
+// setupReplicas serves a replica per address over bufconn and returns a
+// dialer connecting to them.
+func setupReplicas(t *testing.T, addrs ...string) (map[string]*replica, client.Option) {
+	replicas := make(map[string]*replica)
+	listeners := make(map[string]*bufconn.Listener)
+	for _, addr := range addrs {
+		backend, err := sqlbackend.New(t.Context())
+		require.NoError(t, err)
+
+		r := &replica{health: health.NewServer()}
+		s, otelCleanup, err := frontend.NewServer(t.Context(), replicaBackend{Backend: backend, r: r},
+			frontend.WithNoopTelemetry(), frontend.WithHealthServer(r.health))
+		require.NoError(t, err)
+
+		lis := bufconn.Listen(1024 * 1024)
+		go s.Serve(lis)
+		t.Cleanup(func() {
+			s.Stop()
+			require.NoError(t, backend.Close(context.Background()))
+			otelCleanup()
+		})
+		replicas[addr] = r
+		listeners[addr] = lis
+	}
+
+	return replicas, client.WithDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		lis, ok := listeners[addr]
+		if !ok {
+			return nil, fmt.Errorf("unknown replica %q", addr)
+		}
+		return lis.DialContext(ctx)
+	})
+}
+
+// spread makes n sequential Get calls and returns how many each replica got.
+func spread(t *testing.T, c *client.Client, replicas map[string]*replica, n int) map[string]int64 {
+	for _, r := range replicas {
+		r.gets.Store(0)
+	}
+	for range n {
+		c.Get(t.Context(), 1)
+	}
+
+	counts := make(map[string]int64)
+	for addr, r := range replicas {
+		counts[addr] = r.gets.Load()
+	}
+	return counts
+}
+
+// waitForAll waits until calls reach every replica, as endpoints only get
+// calls once connected.
+func waitForAll(t *testing.T, c *client.Client, replicas map[string]*replica) {
+	require.Eventually(t, func() bool {
+		for _, count := range spread(t, c, replicas, 3*len(replicas)) {
+			if count == 0 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLoadBalancingRoundRobin(t *testing.T) {
+	replicas, dialer := setupReplicas(t, "a:5051", "b:5051", "c:5051")
+	c, err := client.New(client.WithEndpoints("a:5051", "b:5051", "c:5051"), dialer)
+	require.NoError(t, err)
+	defer c.Close()
+
+	waitForAll(t, c, replicas)
+	require.Equal(t, map[string]int64{"a:5051": 10, "b:5051": 10, "c:5051": 10}, spread(t, c, replicas, 30))
+
+	// Unhealthy endpoints get no calls until they recover
+	replicas["b:5051"].setServing(false)
+	require.Eventually(t, func() bool {
+		return spread(t, c, replicas, 10)["b:5051"] == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	replicas["b:5051"].setServing(true)
+	waitForAll(t, c, replicas)
+}
+
+func TestLoadBalancingOutlierEjection(t *testing.T) {
+	replicas, dialer := setupReplicas(t, "a:5051", "b:5051", "c:5051")
+	config := client.DefaultLoadBalancingConfig()
+	config.EjectAfter = 3
+	config.EjectionTime = 300 * time.Millisecond
+
+	c, err := client.New(
+		client.WithEndpoints("a:5051", "b:5051", "c:5051"),
+		client.WithLoadBalancing(config),
+		client.WithoutRetries(),
+		dialer,
+	)
+	require.NoError(t, err)
+	defer c.Close()
+	waitForAll(t, c, replicas)
+
+	// The failing endpoint is ejected after 3 failures in a row
+	replicas["c:5051"].fail.Store(true)
+	require.Equal(t, int64(3), spread(t, c, replicas, 9)["c:5051"])
+	require.Zero(t, spread(t, c, replicas, 30)["c:5051"])
+
+	// Ejection stops once half of the endpoints are ejected
+	replicas["a:5051"].fail.Store(true)
+	replicas["b:5051"].fail.Store(true)
+	spread(t, c, replicas, 30)
+	counts := spread(t, c, replicas, 30)
+	require.Zero(t, counts["c:5051"])
+	require.Positive(t, counts["a:5051"]+counts["b:5051"])
+
+	// Ejected endpoints return after the ejection time
+	for _, r := range replicas {
+		r.fail.Store(false)
+	}
+	time.Sleep(config.EjectionTime)
+	waitForAll(t, c, replicas)
+}
+
+func TestLoadBalancingLeastRequest(t *testing.T) {
+	replicas, dialer := setupReplicas(t, "a:5051", "b:5051", "c:5051")
+	config := client.DefaultLoadBalancingConfig()
+	config.Policy = client.LeastRequest
+
+	c, err := client.New(client.WithEndpoints("a:5051", "b:5051", "c:5051"), client.WithLoadBalancing(config), dialer)
+	require.NoError(t, err)
+	defer c.Close()
+	waitForAll(t, c, replicas)
+
+	// Calls avoid the slow endpoint while it has calls in flight
+	replicas["a:5051"].delay.Store(int64(20 * time.Millisecond))
+	for _, r := range replicas {
+		r.gets.Store(0)
+	}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 25 {
+				c.Get(t.Context(), 1)
+			}
+		})
+	}
+	wg.Wait()
+
+	slow := replicas["a:5051"].gets.Load()
+	require.Less(t, 2*slow, replicas["b:5051"].gets.Load())
+	require.Less(t, 2*slow, replicas["c:5051"].gets.Load())
+}
+
+func TestEndpointsFile(t *testing.T) {
+	replicas, dialer := setupReplicas(t, "a:5051", "b:5051")
+	path := filepath.Join(t.TempDir(), "endpoints")
+	require.NoError(t, os.WriteFile(path, []byte("# frontends\na:5051\n\n"), 0o600))
+
+	c, err := client.New(client.WithEndpointsFile(path), client.WithDiscoveryInterval(20*time.Millisecond), dialer)
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.Eventually(t, func() bool {
+		return spread(t, c, replicas, 4)["a:5051"] == 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Endpoints follow changes of the file
+	require.NoError(t, os.WriteFile(path, []byte("b:5051 # moved\n"), 0o600))
+	require.Eventually(t, func() bool {
+		return spread(t, c, replicas, 4)["b:5051"] == 4
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestDNSSRV(t *testing.T) {
+	replicas, dialer := setupReplicas(t, "a.test:5051", "b.test:5051", "c.test:5051")
+	dns := serveSRV(t, []dnsmessage.SRVResource{
+		{Priority: 10, Weight: 1, Port: 5051, Target: dnsmessage.MustNewName("a.test.")},
+		{Priority: 10, Weight: 1, Port: 5051, Target: dnsmessage.MustNewName("b.test.")},
+		{Priority: 20, Weight: 1, Port: 5051, Target: dnsmessage.MustNewName("c.test.")},
+	})
+
+	c, err := client.New(client.WithDNSSRV("_grpc._tcp.frontend.test", dns), dialer)
+	require.NoError(t, err)
+	defer c.Close()
+
+	// Only the records of the lowest priority are used
+	require.Eventually(t, func() bool {
+		counts := spread(t, c, replicas, 10)
+		return counts["a.test:5051"] == 5 && counts["b.test:5051"] == 5
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// serveSRV runs a DNS server answering SRV queries with records and returns a
+// resolver using it.
+func serveSRV(t *testing.T, records []dnsmessage.SRVResource) *net.Resolver {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var p dnsmessage.Parser
+			header, err := p.Start(buf[:n])
+			if err != nil {
+				continue
+			}
+			question, err := p.Question()
+			if err != nil {
+				continue
+			}
+
+			header.Response = true
+			header.Authoritative = true
+			if question.Type != dnsmessage.TypeSRV {
+				header.RCode = dnsmessage.RCodeNameError
+			}
+			b := dnsmessage.NewBuilder(nil, header)
+			b.StartQuestions()
+			b.Question(question)
+			b.StartAnswers()
+			if question.Type == dnsmessage.TypeSRV {
+				for _, record := range records {
+					b.SRVResource(dnsmessage.ResourceHeader{
+						Name:  question.Name,
+						Class: dnsmessage.ClassINET,
+						TTL:   60,
+					}, record)
+				}
+			}
+			if msg, err := b.Finish(); err == nil {
+				conn.WriteTo(msg, addr)
+			}
+		}
+	}()
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", conn.LocalAddr().String())
+		},
+	}
+}
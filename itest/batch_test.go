@@ -0,0 +1,99 @@
+package itest
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/client"
+)
+
+func TestClientBatching(t *testing.T) {
+	flaky := &flakyServer{}
+	newClient := setupFlakyServer(t, flaky)
+	c := newClient(client.WithoutRetries(), client.WithBatching(client.BatchConfig{
+		Linger:   50 * time.Millisecond,
+		MaxBatch: 10,
+	}))
+
+	// Concurrent calls are coalesced, each caller getting its own result
+	var wg sync.WaitGroup
+	for key := range int64(10) {
+		wg.Go(func() {
+			require.NoError(t, c.Put(t.Context(), key, fmt.Sprint("value", key)))
+		})
+	}
+	wg.Wait()
+	require.Equal(t, int32(0), flaky.putCalls.Load())
+	require.Equal(t, int32(1), flaky.batchCalls.Load())
+
+	for key := range int64(12) {
+		wg.Go(func() {
+			value, err := c.Get(t.Context(), key)
+			if key >= 10 {
+				require.Equal(t, codes.NotFound, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprint("value", key), value)
+		})
+	}
+	wg.Wait()
+	require.Equal(t, int32(0), flaky.getCalls.Load())
+	require.Equal(t, int32(3), flaky.batchCalls.Load())
+
+	// A single call is sent once the linger time is up
+	start := time.Now()
+	require.NoError(t, c.Put(t.Context(), 100, "value"))
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// A failed batch fails every call in it
+	flaky.failures.Store(1)
+	var failed atomic.Int32
+	for key := range int64(3) {
+		wg.Go(func() {
+			if _, err := c.Get(t.Context(), key); status.Code(err) == codes.Unavailable {
+				failed.Add(1)
+			}
+		})
+	}
+	wg.Wait()
+	require.Equal(t, int32(3), failed.Load())
+}
+
+func BenchmarkClientPut(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []client.Option
+	}{
+		{"unbatched", nil},
+		{"batched", []client.Option{client.WithBatching(client.BatchConfig{
+			Linger:   time.Millisecond,
+			MaxBatch: 100,
+		})}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			// Simulate a network round trip, which batching amortizes
+			newClient := setupFlakyServer(b, &flakyServer{latency: time.Millisecond})
+			c := newClient(bc.opts...)
+
+			// Many concurrent callers, as batching only pays off under load
+			var key atomic.Int64
+			b.SetParallelism(64)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if err := c.Put(b.Context(), key.Add(1), "value"); err != nil {
+						b.Error(err)
+					}
+				}
+			})
+		})
+	}
+}
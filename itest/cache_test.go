@@ -0,0 +1,106 @@
+package itest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/client"
+)
+
+var testCache = client.CacheConfig{
+	Size:        2,
+	TTL:         time.Minute,
+	NegativeTTL: time.Minute,
+}
+
+// cached reports whether a Get of key is served without calling the server
+func cached(t *testing.T, c *client.Client, flaky *flakyServer, key int64) bool {
+	before := flaky.getCalls.Load()
+	_, _ = c.Get(t.Context(), key)
+	return flaky.getCalls.Load() == before
+}
+
+func TestClientCache(t *testing.T) {
+	flaky := &flakyServer{}
+	newClient := setupFlakyServer(t, flaky)
+	c := newClient(client.WithCache(testCache))
+	writer := newClient()
+
+	require.NoError(t, writer.Put(t.Context(), 1, "one"))
+
+	// Once the invalidation stream is up, repeated reads are cached
+	require.Eventually(t, func() bool { return cached(t, c, flaky, 1) }, time.Second, 10*time.Millisecond)
+	value, err := c.Get(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "one", value)
+
+	// Changes by other clients invalidate the entry
+	require.NoError(t, writer.Put(t.Context(), 1, "uno"))
+	require.Eventually(t, func() bool {
+		value, err := c.Get(t.Context(), 1)
+		return err == nil && value == "uno"
+	}, time.Second, 10*time.Millisecond)
+
+	// NotFound is cached too, until the key is written
+	_, err = c.Get(t.Context(), 2)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.True(t, cached(t, c, flaky, 2))
+	_, err = c.Get(t.Context(), 2)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	require.NoError(t, c.Put(t.Context(), 2, "two"))
+	value, err = c.Get(t.Context(), 2)
+	require.NoError(t, err)
+	require.Equal(t, "two", value)
+
+	// The least recently used key is evicted. The change of key 2 is still
+	// streamed back, so it may take another read to be cached again.
+	require.Eventually(t, func() bool { return cached(t, c, flaky, 2) }, time.Second, 10*time.Millisecond)
+	require.True(t, cached(t, c, flaky, 1))
+	_, _ = c.Get(t.Context(), 3)
+	require.False(t, cached(t, c, flaky, 2))
+}
+
+func TestClientCacheCoalescesMisses(t *testing.T) {
+	flaky := &flakyServer{slowFirst: 200 * time.Millisecond}
+	newClient := setupFlakyServer(t, flaky)
+	c := newClient(client.WithCache(testCache))
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			_, err := c.Get(t.Context(), 1)
+			require.Equal(t, codes.NotFound, status.Code(err))
+		})
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), flaky.getCalls.Load())
+}
+
+func TestClientCacheFillsUnderWrites(t *testing.T) {
+	flaky := &flakyServer{getLatency: 50 * time.Millisecond}
+	newClient := setupFlakyServer(t, flaky)
+	c := newClient(client.WithCache(testCache))
+	writer := newClient()
+	require.NoError(t, writer.Put(t.Context(), 1, "one"))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+	wg.Go(func() {
+		for ctx.Err() == nil {
+			_ = writer.Put(ctx, 2, "two")
+		}
+	})
+
+	// Changes of other keys streamed while key 1 is read don't keep it from
+	// being cached
+	require.Eventually(t, func() bool { return cached(t, c, flaky, 1) }, 2*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,257 @@
+package itest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dynoinc/gh-go/internal/cdc"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	cdcpb "github.com/dynoinc/gh-go/proto/cdc/v1"
+)
+
+// capturedChange is a change as received by a test sink.
+type capturedChange struct {
+	Seq     int64  `json:"seq"`
+	Key     int64  `json:"key"`
+	Value   string `json:"value"`
+	Deleted bool   `json:"deleted"`
+}
+
+// runPipeline runs a pipeline until the returned function is called.
+func runPipeline(t *testing.T, p *cdc.Pipeline) func() {
+	ctx, cancel := context.WithCancel(t.Context())
+	var wg sync.WaitGroup
+	wg.Go(func() { p.Run(ctx) })
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func writeChanges(t *testing.T, backend sqlbackend.Backend) {
+	require.NoError(t, backend.Put(t.Context(), 1, "a"))
+	require.NoError(t, backend.BatchPut(t.Context(), []sqlbackend.KeyValue{{Key: 2, Value: "b"}, {Key: 3, Value: "c"}}))
+	_, err := backend.Delete(t.Context(), 1)
+	require.NoError(t, err)
+	require.NoError(t, backend.Put(t.Context(), 2, "d"))
+}
+
+var wantChanges = []capturedChange{
+	{Seq: 1, Key: 1, Value: "a"},
+	{Seq: 2, Key: 2, Value: "b"},
+	{Seq: 3, Key: 3, Value: "c"},
+	{Seq: 4, Key: 1, Deleted: true},
+	{Seq: 5, Key: 2, Value: "d"},
+}
+
+func TestCDCWebhook(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+	defer backend.Close(t.Context())
+	log := backend.(sqlbackend.ChangeLog)
+
+	// The webhook fails its first requests, which are retried
+	var mu sync.Mutex
+	var received []capturedChange
+	var firstSeqs, auths []string
+	failures := 2
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		auths = append(auths, r.Header.Get("Authorization"))
+		if failures > 0 {
+			failures--
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+			return
+		}
+
+		var body struct {
+			Changes []capturedChange `json:"changes"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		received = append(received, body.Changes...)
+		firstSeqs = append(firstSeqs, r.Header.Get(cdc.FirstSeqHeader))
+	}))
+	defer srv.Close()
+
+	offsets := t.TempDir()
+	newPipeline := func() *cdc.Pipeline {
+		sink := cdc.NewWebhookSink(srv.URL, func() string { return "secret" })
+		p, err := cdc.New(t.Context(), "webhook", log, sink, offsets, cdc.WithBatchSize(2), cdc.WithPollInterval(10*time.Millisecond))
+		require.NoError(t, err)
+		return p
+	}
+	// Wait on the offset rather than on the changes received, which the
+	// webhook records before the pipeline sees its response
+	deliveredUpTo := func(p *cdc.Pipeline, seq int64) func() bool {
+		return func() bool { return p.Offset() == seq }
+	}
+
+	writeChanges(t, backend)
+	p := newPipeline()
+	stop := runPipeline(t, p)
+	require.Eventually(t, deliveredUpTo(p, 5), 5*time.Second, 10*time.Millisecond)
+	stop()
+	require.Equal(t, int64(5), p.Offset())
+
+	mu.Lock()
+	require.Equal(t, wantChanges, received)
+	require.Equal(t, []string{"1", "3", "5"}, firstSeqs)
+	require.Equal(t, []string{"Bearer secret"}, slices.Compact(auths))
+	received, firstSeqs = nil, nil
+	mu.Unlock()
+
+	// A restarted pipeline resumes after the changes delivered
+	require.NoError(t, backend.Put(t.Context(), 4, "e"))
+	p = newPipeline()
+	require.Equal(t, int64(5), p.Offset())
+	stop = runPipeline(t, p)
+	require.Eventually(t, deliveredUpTo(p, 6), 5*time.Second, 10*time.Millisecond)
+	stop()
+
+	// A log numbered anew, as an in-memory one without a journal is after a
+	// restart, would have its first changes skipped, so it's refused
+	fresh, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+	defer fresh.Close(t.Context())
+	_, err = cdc.New(t.Context(), "webhook", fresh.(sqlbackend.ChangeLog), cdc.NewWebhookSink(srv.URL, nil), offsets)
+	require.ErrorContains(t, err, "offset 6 of sink \"webhook\" is beyond the last change 0")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []capturedChange{{Seq: 6, Key: 4, Value: "e"}}, received)
+}
+
+func TestCDCFileSink(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+	defer backend.Close(t.Context())
+
+	// Each file fills up after a single batch
+	dir := t.TempDir()
+	sink, err := cdc.NewFileSink(dir, 1)
+	require.NoError(t, err)
+	p, err := cdc.New(t.Context(), "file", backend.(sqlbackend.ChangeLog), sink, t.TempDir(),
+		cdc.WithBatchSize(2), cdc.WithPollInterval(10*time.Millisecond))
+	require.NoError(t, err)
+
+	writeChanges(t, backend)
+	stop := runPipeline(t, p)
+	require.Eventually(t, func() bool { return p.Offset() == 5 }, 5*time.Second, 10*time.Millisecond)
+	stop()
+	require.NoError(t, sink.Close())
+
+	names, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "changes-00000000000000000001.jsonl"),
+		filepath.Join(dir, "changes-00000000000000000003.jsonl"),
+		filepath.Join(dir, "changes-00000000000000000005.jsonl"),
+	}, names)
+
+	var got []capturedChange
+	for _, name := range names {
+		f, err := os.Open(name)
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var change capturedChange
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &change))
+			got = append(got, change)
+		}
+		require.NoError(t, scanner.Err())
+		f.Close()
+	}
+	require.Equal(t, wantChanges, got)
+}
+
+func TestCDCTrimmedOffset(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+	defer backend.Close(t.Context())
+	log := backend.(sqlbackend.ChangeLog)
+	writeChanges(t, backend)
+	_, err = log.TrimChanges(t.Context(), sqlbackend.ChangeLogRetention{Changes: 1})
+	require.NoError(t, err)
+
+	// Sinks behind the change log fail to start, and don't keep it from
+	// being trimmed further
+	offsets := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(offsets, "file.offset"), []byte("1\n"), 0o644))
+	sink, err := cdc.NewFileSink(t.TempDir(), 1)
+	require.NoError(t, err)
+	defer sink.Close()
+	_, err = cdc.New(t.Context(), "file", log, sink, offsets)
+	require.ErrorIs(t, err, sqlbackend.ErrChangesTrimmed)
+
+	require.NoError(t, backend.Put(t.Context(), 10, "f"))
+	require.NoError(t, backend.Put(t.Context(), 11, "g"))
+	trimmed, err := log.TrimChanges(t.Context(), sqlbackend.ChangeLogRetention{Changes: 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), trimmed)
+}
+
+// changeSink records the changes delivered to it over gRPC.
+type changeSink struct {
+	cdcpb.UnimplementedChangeSinkServiceServer
+
+	mu      sync.Mutex
+	changes []capturedChange
+}
+
+func (s *changeSink) Deliver(_ context.Context, req *cdcpb.DeliverRequest) (*cdcpb.DeliverResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range req.GetChanges() {
+		s.changes = append(s.changes, capturedChange{Seq: c.GetSeq(), Key: c.GetKey(), Value: c.GetValue(), Deleted: c.GetDeleted()})
+	}
+	return &cdcpb.DeliverResponse{}, nil
+}
+
+func TestCDCGRPCSink(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+	defer backend.Close(t.Context())
+
+	lis := bufconn.Listen(1024 * 1024)
+	receiver := &changeSink{}
+	s := grpc.NewServer()
+	cdcpb.RegisterChangeSinkServiceServer(s, receiver)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	sink, err := cdc.NewGRPCSink("passthrough:///bufnet", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	require.NoError(t, err)
+	defer sink.Close()
+	p, err := cdc.New(t.Context(), "grpc", backend.(sqlbackend.ChangeLog), sink, t.TempDir(), cdc.WithPollInterval(10*time.Millisecond))
+	require.NoError(t, err)
+
+	writeChanges(t, backend)
+	stop := runPipeline(t, p)
+	require.Eventually(t, func() bool { return p.Offset() == 5 }, 5*time.Second, 10*time.Millisecond)
+	stop()
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	require.Equal(t, wantChanges, receiver.changes)
+}
@@ -0,0 +1,123 @@
+package itest
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynoinc/gh-go/internal/cli"
+	"github.com/dynoinc/gh-go/internal/frontend"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+// startTCPServer serves the frontend on a local TCP port and returns its
+// address
+func startTCPServer(t *testing.T, opts ...frontend.ServerOption) string {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+	return serveTCP(t, backend, opts...)
+}
+
+// serveTCP serves backend on a TCP listener until the test ends, closing the
+// backend after
+func serveTCP(t *testing.T, backend sqlbackend.Backend, opts ...frontend.ServerOption) string {
+	opts = append([]frontend.ServerOption{frontend.WithNoopTelemetry()}, opts...)
+	s, otelCleanup, err := frontend.NewServer(t.Context(), backend, opts...)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			t.Logf("Server exited: %v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		s.Stop()
+		require.NoError(t, backend.Close(context.Background()))
+		otelCleanup()
+	})
+
+	return lis.Addr().String()
+}
+
+// runCLI runs ghgo and returns its exit code, stdout and stderr
+func runCLI(t *testing.T, stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := cli.Run(t.Context(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCLI(t *testing.T) {
+	addr := startTCPServer(t)
+
+	code, _, _ := runCLI(t, "", "-addr", addr, "put", "1", "one")
+	require.Equal(t, 0, code)
+	code, _, _ = runCLI(t, "two\n", "-addr", addr, "put", "2", "-")
+	require.Equal(t, 0, code)
+
+	code, stdout, _ := runCLI(t, "", "-addr", addr, "get", "1")
+	require.Equal(t, 0, code)
+	require.Equal(t, "KEY  VALUE\n1    one\n", stdout)
+
+	code, stdout, _ = runCLI(t, "", "-addr", addr, "-o", "json", "scan")
+	require.Equal(t, 0, code)
+	require.Equal(t, `{"key":1,"value":"one"}`+"\n"+`{"key":2,"value":"two\n"}`+"\n", stdout)
+
+	code, stdout, _ = runCLI(t, "", "-addr", addr, "-o", "json", "scan", "-from", "2")
+	require.Equal(t, 0, code)
+	require.Equal(t, `{"key":2,"value":"two\n"}`+"\n", stdout)
+
+	// Missing keys exit with 1, other errors with 2
+	code, _, _ = runCLI(t, "", "-addr", addr, "delete", "1")
+	require.Equal(t, 0, code)
+	code, _, stderr := runCLI(t, "", "-addr", addr, "get", "1")
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr, "key 1: not found")
+	code, _, _ = runCLI(t, "", "-addr", addr, "delete", "1")
+	require.Equal(t, 1, code)
+
+	code, _, stderr = runCLI(t, "", "-addr", addr, "get", "one")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, `invalid key "one"`)
+	code, _, _ = runCLI(t, "", "-addr", addr, "frobnicate")
+	require.Equal(t, 2, code)
+	code, _, _ = runCLI(t, "", "-addr", addr, "scan", "-bogus")
+	require.Equal(t, 2, code)
+}
+
+func TestCLIImportExport(t *testing.T) {
+	addr := startTCPServer(t)
+
+	input := `{"key":3,"value":"c"}
+{"key":1,"value":"a"}
+
+{"key":2,"value":"b"}
+{"key":1,"value":"a2"}
+`
+	code, _, stderr := runCLI(t, input, "-addr", addr, "import", "-batch", "2")
+	require.Equal(t, 0, code, stderr)
+	require.Contains(t, stderr, "imported 4 entries")
+
+	code, stdout, stderr := runCLI(t, "", "-addr", addr, "export", "-to", "3")
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, `{"key":1,"value":"a2"}`+"\n"+`{"key":2,"value":"b"}`+"\n", stdout)
+
+	code, _, stderr = runCLI(t, "not json\n", "-addr", addr, "import")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "line 1")
+}
+
+func TestCLIAuth(t *testing.T) {
+	addr := startTCPServer(t, frontend.WithAuthToken("secret"))
+
+	code, _, _ := runCLI(t, "", "-addr", addr, "put", "1", "one")
+	require.Equal(t, 2, code)
+
+	code, _, stderr := runCLI(t, "", "-addr", addr, "-token", "secret", "put", "1", "one")
+	require.Equal(t, 0, code, stderr)
+}
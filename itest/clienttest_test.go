@@ -0,0 +1,117 @@
+package itest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/client/clienttest"
+)
+
+func TestClientTestServer(t *testing.T) {
+	c, srv := clienttest.NewTestServer(t, clienttest.WithClientOptions(client.WithoutRetries()))
+
+	require.NoError(t, c.Put(t.Context(), 1, "value"))
+	value, err := c.Get(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+	require.Equal(t, []clienttest.Call{
+		{Op: clienttest.OpPut, Key: 1, Value: "value"},
+		{Op: clienttest.OpGet, Key: 1},
+	}, srv.Calls())
+
+	// Injected errors surface as the frontend maps them
+	srv.Reset()
+	injected := errors.New("disk on fire")
+	srv.FailNext(clienttest.OpGet, 1, injected)
+	_, err = c.Get(t.Context(), 1)
+	require.Equal(t, codes.Internal, status.Code(err))
+	_, err = c.Get(t.Context(), 1)
+	require.NoError(t, err)
+	require.ErrorIs(t, srv.Calls()[0].Err, injected)
+
+	srv.SetError(clienttest.OpGet, clienttest.ErrNotFound)
+	_, err = c.Get(t.Context(), 1)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	srv.SetError(clienttest.OpGet, nil)
+
+	// Injected latency is visible to clients, including additional ones
+	srv.SetLatency(clienttest.OpPut, 50*time.Millisecond)
+	other := srv.NewClient(client.WithCallTimeout(10 * time.Millisecond))
+	err = other.Put(t.Context(), 2, "value")
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestClientTestServerNamespacesAndRevisions(t *testing.T) {
+	c, srv := clienttest.NewTestServer(t, clienttest.WithClientOptions(client.WithoutRetries()))
+	_, err := srv.CreateNamespace(t.Context(), "team-a", clienttest.Quota{})
+	require.NoError(t, err)
+	teamA := srv.NewClient(client.WithoutRetries(), client.WithNamespace("team-a"))
+
+	// Calls are recorded with their namespace
+	require.NoError(t, teamA.Put(t.Context(), 1, "one"))
+	require.NoError(t, c.Put(t.Context(), 1, "default"))
+	require.NoError(t, teamA.Put(t.Context(), 1, "uno"))
+	require.Equal(t, []clienttest.Call{
+		{Op: clienttest.OpPut, Namespace: "team-a", Key: 1, Value: "one"},
+		{Op: clienttest.OpPut, Key: 1, Value: "default"},
+		{Op: clienttest.OpPut, Namespace: "team-a", Key: 1, Value: "uno"},
+	}, srv.Calls())
+	_, err = srv.NewClient(client.WithNamespace("team-b")).Get(t.Context(), 1)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Past versions are served, and faults are injected into their reads
+	versions, _, err := teamA.History(t.Context(), 1)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	value, err := teamA.Get(t.Context(), 1, client.GetAtRevision(versions[1].Revision))
+	require.NoError(t, err)
+	require.Equal(t, "one", value)
+
+	srv.Reset()
+	srv.FailNext(clienttest.OpHistory, 1, errors.New("disk on fire"))
+	_, _, err = teamA.History(t.Context(), 1)
+	require.Equal(t, codes.Internal, status.Code(err))
+	srv.FailNext(clienttest.OpGet, 1, clienttest.ErrNotFound)
+	_, err = teamA.Get(t.Context(), 1, client.GetAtRevision(versions[1].Revision))
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Equal(t, []clienttest.Call{
+		{Op: clienttest.OpHistory, Namespace: "team-a", Key: 1, Value: "100", Err: srv.Calls()[0].Err},
+		{Op: clienttest.OpGet, Namespace: "team-a", Key: 1, Err: clienttest.ErrNotFound},
+	}, srv.Calls())
+
+	// Snapshots read as of their revision
+	snapshot, err := teamA.Snapshot(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, teamA.Put(t.Context(), 2, "two"))
+	values, err := snapshot.BatchGet(t.Context(), 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]string{1: "uno"}, values)
+
+	srv.SetError(clienttest.OpGet, clienttest.ErrNotFound)
+	values, err = snapshot.BatchGet(t.Context(), 1, 2)
+	require.NoError(t, err)
+	require.Empty(t, values)
+
+	// Quotas are enforced, and namespaces report their usage
+	srv.Reset()
+	ns, err := srv.SetQuota(t.Context(), "team-a", clienttest.Quota{MaxKeys: 2})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), ns.Keys)
+	err = teamA.Put(t.Context(), 3, "three")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	require.ErrorIs(t, srv.DeleteNamespace(t.Context(), "team-a"), clienttest.ErrNamespaceNotEmpty)
+	for _, key := range []int64{1, 2} {
+		_, err := teamA.Delete(t.Context(), key)
+		require.NoError(t, err)
+	}
+	require.NoError(t, srv.DeleteNamespace(t.Context(), "team-a"))
+	_, err = srv.GetNamespace(t.Context(), "team-a")
+	require.ErrorIs(t, err, clienttest.ErrNamespaceNotFound)
+}
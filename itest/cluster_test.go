@@ -0,0 +1,279 @@
+package itest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/frontend"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	clusterpb "github.com/dynoinc/gh-go/proto/cluster/v1"
+)
+
+// testCluster runs Raft cluster nodes in process, connecting both their gRPC
+// servers and Raft transports over bufconn.
+type testCluster struct {
+	t         *testing.T
+	mu        sync.Mutex
+	listeners map[string]*bufconn.Listener // by node ID or Raft address
+	nodes     map[string]*clusterNode
+}
+
+type clusterNode struct {
+	id         string
+	client     *client.Client
+	cluster    clusterpb.ClusterServiceClient
+	namespaces *frontend.ClusterNamespaces
+	stop       func()
+}
+
+func newTestCluster(t *testing.T) *testCluster {
+	return &testCluster{t: t, listeners: make(map[string]*bufconn.Listener), nodes: make(map[string]*clusterNode)}
+}
+
+func (tc *testCluster) dial(ctx context.Context, addr string) (net.Conn, error) {
+	tc.mu.Lock()
+	lis, ok := tc.listeners[addr]
+	tc.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown address %q", addr)
+	}
+	return lis.DialContext(ctx)
+}
+
+func (tc *testCluster) listen(addr string) *bufconn.Listener {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	lis := bufconn.Listen(1024 * 1024)
+	tc.listeners[addr] = lis
+	return lis
+}
+
+// start runs the named node, bootstrapping a cluster of it if bootstrap is
+// set, and waits for it to serve.
+func (tc *testCluster) start(name string, bootstrap bool, opts ...frontend.ServerOption) *clusterNode {
+	t := tc.t
+	id := "passthrough:///" + name
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+
+	namespaces := &frontend.ClusterNamespaces{}
+	opts = append([]frontend.ServerOption{
+		frontend.WithNoopTelemetry(),
+		frontend.WithLogger(slog.New(slog.DiscardHandler)),
+		frontend.WithPeerToken("peer"),
+		frontend.WithCluster(frontend.ClusterConfig{
+			ID:                id,
+			RaftAddress:       "raft-" + name,
+			RaftListener:      tc.listen("raft-" + name),
+			RaftDialer:        tc.dial,
+			Dir:               t.TempDir(),
+			Bootstrap:         bootstrap,
+			SnapshotInterval:  50 * time.Millisecond,
+			SnapshotThreshold: 5,
+			DialOptions:       []grpc.DialOption{grpc.WithContextDialer(tc.dial)},
+			PeerToken:         func() string { return "peer" },
+			Namespaces:        namespaces,
+		}),
+	}, opts...)
+	s, cleanup, err := frontend.NewServer(t.Context(), backend, opts...)
+	require.NoError(t, err)
+	go s.Serve(tc.listen(name))
+
+	c, err := client.New(client.WithTarget(id), client.WithDialer(tc.dial))
+	require.NoError(t, err)
+	conn, err := grpc.NewClient(id, grpc.WithContextDialer(tc.dial), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	node := &clusterNode{
+		id:         id,
+		client:     c,
+		cluster:    clusterpb.NewClusterServiceClient(conn),
+		namespaces: namespaces,
+		stop: sync.OnceFunc(func() {
+			c.Close()
+			conn.Close()
+			s.Stop()
+			cleanup()
+			require.NoError(t, backend.Close(context.Background()))
+		}),
+	}
+	t.Cleanup(node.stop)
+	tc.nodes[name] = node
+	return node
+}
+
+// join adds the named node to the cluster through an existing member.
+func (tc *testCluster) join(via *clusterNode, name string, opts ...frontend.ServerOption) *clusterNode {
+	node := tc.start(name, false, opts...)
+	_, err := via.cluster.AddMember(tc.t.Context(), clusterpb.AddMemberRequest_builder{
+		Id:          node.id,
+		RaftAddress: "raft-" + name,
+	}.Build())
+	require.NoError(tc.t, err)
+	return node
+}
+
+// leader waits for a leader to be known to node and returns its ID.
+func leader(t *testing.T, node *clusterNode) string {
+	var id string
+	require.Eventually(t, func() bool {
+		resp, err := node.cluster.ListMembers(t.Context(), &clusterpb.ListMembersRequest{})
+		if err != nil {
+			return false
+		}
+		for _, member := range resp.GetMembers() {
+			if member.GetLeader() {
+				id = member.GetId()
+				return true
+			}
+		}
+		return false
+	}, 10*time.Second, 10*time.Millisecond)
+	return id
+}
+
+func TestCluster(t *testing.T) {
+	tc := newTestCluster(t)
+	a := tc.start("a", true)
+	require.Equal(t, a.id, leader(t, a))
+
+	b := tc.join(a, "b")
+	c := tc.join(b, "c") // membership changes are forwarded to the leader
+
+	resp, err := c.cluster.ListMembers(t.Context(), &clusterpb.ListMembersRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetMembers(), 3)
+
+	// Writes to followers are forwarded, and reads from any node see them
+	require.NoError(t, b.client.Put(t.Context(), 1, "one"))
+	value, err := c.client.Get(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "one", value)
+
+	require.NoError(t, c.client.BatchPut(t.Context(), []client.KeyValue{{Key: 2, Value: "two"}, {Key: 3, Value: "three"}}))
+	found, err := b.client.Delete(t.Context(), 2)
+	require.NoError(t, err)
+	require.True(t, found)
+	for _, node := range []*clusterNode{a, b, c} {
+		require.Equal(t, []client.KeyValue{{Key: 1, Value: "one"}, {Key: 3, Value: "three"}}, entries(t, node.client))
+	}
+
+	// Nodes joining after the log was truncated catch up from a snapshot
+	for key := range int64(50) {
+		require.NoError(t, a.client.Put(t.Context(), 100+key, fmt.Sprint("value", key)))
+	}
+	time.Sleep(200 * time.Millisecond) // lets the nodes snapshot
+	d := tc.join(a, "d")
+	value, err = d.client.Get(t.Context(), 149)
+	require.NoError(t, err)
+	require.Equal(t, "value49", value)
+
+	// and number changes like the others, so revisions mean the same on
+	// every node
+	snapshot, err := a.client.Snapshot(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, a.client.Put(t.Context(), 149, "changed"))
+	require.Eventually(t, func() bool {
+		value, err := d.client.Get(t.Context(), 149)
+		return err == nil && value == "changed"
+	}, 5*time.Second, 10*time.Millisecond)
+	values, err := d.client.SnapshotAt(snapshot.Revision()).BatchGet(t.Context(), 100, 149)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]string{100: "value0", 149: "value49"}, values)
+	revisions := func(node *clusterNode) map[int64]string {
+		versions, _, err := node.client.History(t.Context(), 149)
+		require.NoError(t, err)
+		revisions := make(map[int64]string, len(versions))
+		for _, version := range versions {
+			revisions[version.Revision] = version.Value
+		}
+		return revisions
+	}
+	require.Equal(t, revisions(a), revisions(d))
+
+	// The cluster fails over when the leader stops
+	a.stop()
+	_, err = b.cluster.RemoveMember(t.Context(), clusterpb.RemoveMemberRequest_builder{Id: a.id}.Build())
+	for status.Code(err) == codes.Unavailable {
+		time.Sleep(50 * time.Millisecond)
+		_, err = b.cluster.RemoveMember(t.Context(), clusterpb.RemoveMemberRequest_builder{Id: a.id}.Build())
+	}
+	require.NoError(t, err)
+	require.NotEqual(t, a.id, leader(t, c))
+
+	require.NoError(t, d.client.Put(t.Context(), 1, "uno"))
+	for _, node := range []*clusterNode{b, c, d} {
+		value, err := node.client.Get(t.Context(), 1)
+		require.NoError(t, err)
+		require.Equal(t, "uno", value)
+	}
+}
+
+func TestClusterNamespaces(t *testing.T) {
+	tc := newTestCluster(t)
+	a := tc.start("a", true)
+	require.Equal(t, a.id, leader(t, a))
+	b := tc.join(a, "b")
+	c := tc.join(a, "c")
+
+	// Namespace changes made through a follower are applied by every node
+	ns, err := b.namespaces.CreateNamespace(t.Context(), "team-a", sqlbackend.Quota{MaxKeys: 1})
+	require.NoError(t, err)
+	require.Equal(t, "team-a", ns.Name)
+	_, err = c.namespaces.CreateNamespace(t.Context(), "team-a", sqlbackend.Quota{})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	teamA, err := client.New(client.WithTarget(c.id), client.WithDialer(tc.dial), client.WithNamespace("team-a"))
+	require.NoError(t, err)
+	t.Cleanup(func() { teamA.Close() })
+
+	// Every node checks writes against the same quota
+	require.NoError(t, teamA.Put(t.Context(), 1, "one"))
+	err = teamA.Put(t.Context(), 2, "two")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	for _, node := range []*clusterNode{a, b, c} {
+		ns, err := node.namespaces.GetNamespace(t.Context(), "team-a")
+		require.NoError(t, err)
+		require.Equal(t, sqlbackend.Quota{MaxKeys: 1}, ns.Quota)
+		require.Equal(t, int64(1), ns.Keys)
+	}
+
+	_, err = c.namespaces.SetQuota(t.Context(), "team-a", sqlbackend.Quota{MaxKeys: 2})
+	require.NoError(t, err)
+	require.NoError(t, teamA.Put(t.Context(), 2, "two"))
+
+	// Nodes catching up from a snapshot get its namespaces and quotas
+	for key := range int64(10) {
+		require.NoError(t, a.client.Put(t.Context(), 100+key, "value"))
+	}
+	time.Sleep(200 * time.Millisecond) // lets the nodes snapshot
+	d := tc.join(a, "d")
+	require.Eventually(t, func() bool {
+		ns, err := d.namespaces.GetNamespace(t.Context(), "team-a")
+		return err == nil && ns.Quota == sqlbackend.Quota{MaxKeys: 2} && ns.Keys == 2
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// Deleting a namespace removes it from every node
+	for _, key := range []int64{1, 2} {
+		_, err := teamA.Delete(t.Context(), key)
+		require.NoError(t, err)
+	}
+	require.NoError(t, d.namespaces.DeleteNamespace(t.Context(), "team-a"))
+	for _, node := range []*clusterNode{a, b, c, d} {
+		_, err := node.namespaces.GetNamespace(t.Context(), "team-a")
+		require.ErrorIs(t, err, sqlbackend.ErrNamespaceNotFound)
+	}
+}
@@ -0,0 +1,194 @@
+package itest
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynoinc/gh-go/internal/config"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func parseConfigFlags(t *testing.T, args ...string) *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	config.RegisterFlags(fs)
+	require.NoError(t, fs.Parse(args))
+	return fs
+}
+
+func TestConfigLayers(t *testing.T) {
+	yamlPath := writeConfigFile(t, "config.yaml", `
+port: 6000
+log_level: debug
+rate_limit: 10
+history_max_age: 24h
+log_sample_rates:
+  "*": 0.5
+`)
+	tomlPath := writeConfigFile(t, "config.toml", `
+port = 6000
+log_level = "debug"
+rate_limit = 10
+history_max_age = "24h"
+
+[log_sample_rates]
+"*" = 0.5
+`)
+
+	for _, path := range []string{yamlPath, tomlPath} {
+		t.Run(filepath.Ext(path), func(t *testing.T) {
+			t.Setenv("RATE_LIMIT", "20")
+			fs := parseConfigFlags(t, "-log-level=warn", "-log-payloads", "-history-compaction-interval=5m")
+
+			cfg, err := config.Load(path, fs)
+			require.NoError(t, err)
+
+			require.Equal(t, 6000, cfg.Port)                                   // file
+			require.Equal(t, map[string]float64{"*": 0.5}, cfg.LogSampleRates) // file
+			require.Equal(t, 20.0, cfg.RateLimit)                              // env beats file
+			require.Equal(t, slog.LevelWarn, cfg.LogLevel)                     // flag beats file
+			require.True(t, cfg.LogPayloads)                                   // flag
+			require.Equal(t, 24*time.Hour, cfg.HistoryMaxAge)                  // file
+			require.Equal(t, 5*time.Minute, cfg.HistoryCompactionInterval)     // flag
+			require.Equal(t, 100, cfg.RateBurst)                               // default
+			require.Equal(t, "text", cfg.LogFormat)                            // default
+		})
+	}
+
+	// Without a file the defaults apply
+	cfg, err := config.Load("", nil)
+	require.NoError(t, err)
+	require.Equal(t, config.Default().Port, cfg.Port)
+}
+
+func TestConfigValidation(t *testing.T) {
+	_, err := config.Load(writeConfigFile(t, "config.yaml", "prot: 1\n"), nil)
+	require.ErrorContains(t, err, "prot")
+
+	_, err = config.Load(writeConfigFile(t, "config.toml", "prot = 1\n"), nil)
+	require.ErrorContains(t, err, "prot")
+
+	_, err = config.Load(writeConfigFile(t, "config.json", "{}"), nil)
+	require.ErrorContains(t, err, "unsupported config file extension")
+
+	// Every problem is reported by its file key
+	_, err = config.Load(writeConfigFile(t, "config.yaml", `
+port: 70000
+log_format: xml
+admin_port: 5052
+listen: ["unix://", "localhost"]
+socket_mode: rw
+raft_address: 10.0.0.1:7000
+raft_peers: [10.0.0.2:5051]
+cdc_webhook_url: hooks.example.com
+audit_key: secret
+`), nil)
+	require.ErrorContains(t, err, "port: must be between 1 and 65535")
+	require.ErrorContains(t, err, `log_format: must be "text" or "json"`)
+	require.ErrorContains(t, err, "admin_token: is required")
+	require.ErrorContains(t, err, `listen: unix address "unix://" has no socket path`)
+	require.ErrorContains(t, err, `listen: invalid address "localhost"`)
+	require.ErrorContains(t, err, "socket_mode: must be an octal file mode")
+	require.ErrorContains(t, err, "raft_id: is required when raft_address is set")
+	require.ErrorContains(t, err, `raft_peers: must be "raft_id=raft_address"`)
+	require.ErrorContains(t, err, "cdc_offset_dir: is required when a CDC sink is set")
+	require.ErrorContains(t, err, "journal_dir: is required when a CDC sink is set")
+	require.ErrorContains(t, err, "cdc_webhook_url: must be an http or https URL")
+	require.ErrorContains(t, err, "audit_key: requires audit_log")
+
+	fs := parseConfigFlags(t, "-port=abc")
+	_, err = config.Load("", fs)
+	require.ErrorContains(t, err, "-port")
+}
+
+func TestConfigPrint(t *testing.T) {
+	t.Setenv("AUTH_TOKEN", "call-secret")
+	cfg, err := config.Load("", nil)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, config.Write(&out, cfg.WithoutSecrets()))
+	require.Contains(t, out.String(), "port: 5051")
+	require.NotContains(t, out.String(), "call-secret")
+
+	// The printed configuration is a valid config file, without the secrets
+	t.Setenv("AUTH_TOKEN", "")
+	require.NoError(t, os.Unsetenv("AUTH_TOKEN"))
+	printed, err := config.Load(writeConfigFile(t, "printed.yaml", out.String()), nil)
+	require.NoError(t, err)
+	require.Equal(t, cfg.LogLevel, printed.LogLevel)
+	require.Empty(t, printed.AuthToken)
+
+	// Redacted dumps mark secrets as set, but can't be loaded as they are
+	out.Reset()
+	require.NoError(t, config.Write(&out, cfg.Redacted()))
+	require.Contains(t, out.String(), "auth_token: REDACTED")
+	_, err = config.Load(writeConfigFile(t, "redacted.yaml", out.String()), nil)
+	require.ErrorContains(t, err, "auth_token: is the REDACTED placeholder")
+}
+
+func TestConfigWatch(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "log_level: info\n")
+	cfg, err := config.Load(path, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	applied := make(chan config.Config, 1)
+	done := make(chan error)
+	go func() {
+		done <- config.Watch(ctx, path, nil, *cfg, 10*time.Millisecond, func(next config.Config) {
+			applied <- next
+		})
+	}()
+	defer func() {
+		cancel()
+		require.NoError(t, <-done)
+	}()
+
+	// Give the watcher time to record the initial file version
+	time.Sleep(100 * time.Millisecond)
+
+	// Reloadable settings are applied, restart-only ones are ignored
+	require.NoError(t, os.WriteFile(path, []byte("log_level: debug\nport: 6000\n"), 0o600))
+	select {
+	case next := <-applied:
+		require.Equal(t, slog.LevelDebug, next.LogLevel)
+		require.Equal(t, cfg.Port, next.Port)
+	case <-time.After(5 * time.Second):
+		t.Fatal("config file change was not applied")
+	}
+
+	// Invalid files are skipped
+	require.NoError(t, os.WriteFile(path, []byte("log_level: loud\n"), 0o600))
+	select {
+	case next := <-applied:
+		t.Fatalf("invalid config applied: %+v", next)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// SIGHUP reloads environment changes too
+	require.NoError(t, os.WriteFile(path, []byte("log_level: debug\n"), 0o600))
+	t.Setenv("RATE_LIMIT", "5")
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	require.Eventually(t, func() bool {
+		select {
+		case next := <-applied:
+			return next.RateLimit == 5
+		default:
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -3,8 +3,10 @@ package itest
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
@@ -27,17 +29,30 @@ func (m *mockBackend) Get(ctx context.Context, key int64) (string, error) {
 	return "", errors.New("mock database error on Get")
 }
 
+func (m *mockBackend) Delete(ctx context.Context, key int64) (bool, error) {
+	return false, errors.New("mock database error on Delete")
+}
+
+func (m *mockBackend) Scan(ctx context.Context, first, last int64, limit int) ([]sqlbackend.KeyValue, error) {
+	return nil, errors.New("mock database error on Scan")
+}
+
+func (m *mockBackend) BatchPut(ctx context.Context, entries []sqlbackend.KeyValue) error {
+	return errors.New("mock database error on BatchPut")
+}
+
 func (m *mockBackend) Close(context.Context) error {
 	return nil
 }
 
 // setupTestServer creates a gRPC test server with the given backend
-func setupTestServer(t *testing.T, backend sqlbackend.Backend) (*client.Client, func()) {
+func setupTestServer(t *testing.T, backend sqlbackend.Backend, opts ...frontend.ServerOption) (*client.Client, func()) {
 	// Create in-memory gRPC server using bufconn
 	lis := bufconn.Listen(1024 * 1024)
 
 	// Create server with noop telemetry to avoid OTLP connection timeouts
-	s, otelCleanup, err := frontend.NewServer(t.Context(), backend, frontend.WithNoopTelemetry())
+	opts = append([]frontend.ServerOption{frontend.WithNoopTelemetry()}, opts...)
+	s, otelCleanup, err := frontend.NewServer(t.Context(), backend, opts...)
 	require.NoError(t, err)
 
 	// Start server in background
@@ -162,3 +177,72 @@ func TestGetStatusCodes(t *testing.T) {
 	require.True(t, ok, "expected gRPC status error")
 	require.Equal(t, codes.Internal, st.Code(), "expected Internal status code")
 }
+
+func TestScanDeleteWatch(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+
+	c, cleanup := setupTestServer(t, backend)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	changes := make(chan client.Change, 10)
+	go func() {
+		for change, err := range c.Watch(ctx, 1, 2) {
+			if err != nil {
+				return
+			}
+			changes <- change
+		}
+	}()
+
+	// Keys are only watched once the stream is up, so retry the first change
+	require.Eventually(t, func() bool {
+		require.NoError(t, c.Put(t.Context(), 1, "one"))
+		select {
+		case <-changes:
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	for key := range int64(5) {
+		require.NoError(t, c.Put(t.Context(), key, fmt.Sprint("value", key)))
+	}
+
+	var keys []int64
+	for entry, err := range c.Scan(t.Context(), client.ScanFrom(1), client.ScanTo(4)) {
+		require.NoError(t, err)
+		keys = append(keys, entry.Key)
+	}
+	require.Equal(t, []int64{1, 2, 3}, keys)
+
+	keys = nil
+	for entry, err := range c.Scan(t.Context(), client.ScanLimit(2)) {
+		require.NoError(t, err)
+		keys = append(keys, entry.Key)
+	}
+	require.Equal(t, []int64{0, 1}, keys)
+
+	found, err := c.Delete(t.Context(), 2)
+	require.NoError(t, err)
+	require.True(t, found)
+	found, err = c.Delete(t.Context(), 2)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Only changes of the watched keys are streamed, after any left over from
+	// establishing the stream
+	next := func() client.Change {
+		for {
+			if change := <-changes; change.Value != "one" {
+				return change
+			}
+		}
+	}
+	require.Equal(t, client.Change{Key: 1, Value: "value1"}, next())
+	require.Equal(t, client.Change{Key: 2, Value: "value2"}, next())
+	require.Equal(t, client.Change{Key: 2, Deleted: true}, next())
+}
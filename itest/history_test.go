@@ -0,0 +1,105 @@
+package itest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+func TestHistory(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context(), sqlbackend.WithCompactionInterval(time.Hour))
+	require.NoError(t, err)
+	c, cleanup := setupTestServer(t, backend)
+	defer cleanup()
+
+	// Revisions 1 to 5 write key 1, and revision 3 another key
+	require.NoError(t, c.Put(t.Context(), 1, "a"))
+	require.NoError(t, c.Put(t.Context(), 1, "b"))
+	require.NoError(t, c.Put(t.Context(), 2, "other"))
+	time.Sleep(10 * time.Millisecond)
+	afterB := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	_, err = c.Delete(t.Context(), 1)
+	require.NoError(t, err)
+	require.NoError(t, c.Put(t.Context(), 1, "c"))
+
+	// Reads at a revision or time see the value current then
+	for revision, want := range map[int64]string{1: "a", 2: "b", 3: "b", 5: "c", 100: "c"} {
+		value, err := c.Get(t.Context(), 1, client.GetAtRevision(revision))
+		require.NoError(t, err, "revision %d", revision)
+		require.Equal(t, want, value, "revision %d", revision)
+	}
+	value, err := c.Get(t.Context(), 1, client.GetAtTime(afterB))
+	require.NoError(t, err)
+	require.Equal(t, "b", value)
+
+	_, err = c.Get(t.Context(), 1, client.GetAtRevision(4))
+	require.Equal(t, codes.NotFound, status.Code(err)) // deleted then
+	_, err = c.Get(t.Context(), 2, client.GetAtRevision(2))
+	require.Equal(t, codes.NotFound, status.Code(err)) // not written yet
+	_, err = c.Get(t.Context(), 1, client.GetAtRevision(-1))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// History lists versions newest first, in pages
+	versions, compacted, err := c.History(t.Context(), 1)
+	require.NoError(t, err)
+	require.False(t, compacted)
+	require.Len(t, versions, 4)
+	require.Equal(t, client.Version{Revision: 5, Value: "c", CommittedAt: versions[0].CommittedAt}, versions[0])
+	require.True(t, versions[1].Deleted)
+
+	versions, _, err = c.History(t.Context(), 1, client.HistoryBefore(4), client.HistoryLimit(1))
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	require.Equal(t, "b", versions[0].Value)
+
+	// Compaction trims versions beyond the retention, but keeps the latest
+	versioned := backend.(sqlbackend.Versioned)
+	_, err = versioned.CompactHistory(t.Context(), sqlbackend.HistoryRetention{Versions: 2})
+	require.NoError(t, err)
+
+	versions, compacted, err = c.History(t.Context(), 1)
+	require.NoError(t, err)
+	require.True(t, compacted)
+	require.Len(t, versions, 2)
+	require.Equal(t, int64(4), versions[1].Revision)
+
+	_, err = c.Get(t.Context(), 1, client.GetAtRevision(2))
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+	value, err = c.Get(t.Context(), 2, client.GetAtRevision(4))
+	require.NoError(t, err)
+	require.Equal(t, "other", value)
+
+	_, err = versioned.CompactHistory(t.Context(), sqlbackend.HistoryRetention{MaxAge: time.Nanosecond})
+	require.NoError(t, err)
+	versions, compacted, err = c.History(t.Context(), 1)
+	require.NoError(t, err)
+	require.True(t, compacted)
+	require.Len(t, versions, 1)
+	value, err = c.Get(t.Context(), 1, client.GetAtRevision(5))
+	require.NoError(t, err)
+	require.Equal(t, "c", value)
+}
+
+func TestHistoryBackgroundCompaction(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context(),
+		sqlbackend.WithHistoryRetention(sqlbackend.HistoryRetention{Versions: 1}),
+		sqlbackend.WithCompactionInterval(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+	c, cleanup := setupTestServer(t, backend)
+	defer cleanup()
+
+	require.NoError(t, c.Put(t.Context(), 1, "a"))
+	require.NoError(t, c.Put(t.Context(), 1, "b"))
+	require.Eventually(t, func() bool {
+		versions, compacted, err := c.History(t.Context(), 1)
+		return err == nil && compacted && len(versions) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,85 @@
+package itest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/client/clienttest"
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+func TestClientInterceptors(t *testing.T) {
+	var trace []string
+	record := func(name string) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			_, hasDeadline := ctx.Deadline()
+			trace = append(trace, fmt.Sprintf("%s tenant=%s deadline=%t", name, firstValue(md, "tenant"), hasDeadline))
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+	var streamTenant string
+	recordStream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		streamTenant = firstValue(md, "tenant")
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c, _ := clienttest.NewTestServer(t, clienttest.WithClientOptions(
+		client.WithUnaryInterceptors(record("first")),
+		client.WithMetadata("tenant", "acme"),
+		client.WithUnaryInterceptors(record("second")),
+		client.WithDefaultDeadline(time.Minute),
+		client.WithUnaryInterceptors(record("third")),
+		client.WithStreamInterceptors(recordStream),
+		client.WithCallLogging(logger),
+		client.WithDialOptions(grpc.WithChainUnaryInterceptor(record("dial option"))),
+	))
+
+	// Interceptors compose in the order given, ahead of dial options
+	require.NoError(t, c.Put(t.Context(), 1, "value"))
+	require.Equal(t, []string{
+		"first tenant= deadline=false",
+		"second tenant=acme deadline=false",
+		"third tenant=acme deadline=true",
+		"dial option tenant=acme deadline=true",
+	}, trace)
+
+	// Callers' deadlines are kept
+	trace = nil
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+	_, err := c.Get(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, "second tenant=acme deadline=true", trace[1])
+
+	// Streams get metadata and logging too
+	for _, err := range c.Scan(t.Context()) {
+		require.NoError(t, err)
+	}
+	require.Equal(t, "acme", streamTenant)
+
+	require.Contains(t, logs.String(), "grpc.method=Put")
+	require.Contains(t, logs.String(), "grpc.method=Get")
+	require.Contains(t, logs.String(), "grpc.method=Scan")
+	require.Contains(t, logs.String(), "grpc.service="+frontendpb.FrontendService_ServiceDesc.ServiceName)
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
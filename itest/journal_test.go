@@ -0,0 +1,163 @@
+package itest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+func TestJournalRecovery(t *testing.T) {
+	dir := t.TempDir()
+	c, adminAddr := setupBackupServer(t, sqlbackend.WithJournal(dir), sqlbackend.WithJournalSegmentSize(50))
+	runAdmin := func(args ...string) (int, string, string) {
+		return runCLI(t, "", append([]string{"-admin-addr", adminAddr, "-admin-token", testAdminToken, "-o", "json"}, args...)...)
+	}
+	recoverTo := func(args ...string) (string, sqlbackend.BackupInfo) {
+		out := filepath.Join(t.TempDir(), "recovered.db")
+		code, stdout, stderr := runCLI(t, "", append(append([]string{"-o", "json", "recover", "-journal", dir}, args...), out)...)
+		require.Equal(t, 0, code, stderr)
+		var info sqlbackend.BackupInfo
+		require.NoError(t, json.Unmarshal([]byte(stdout), &info))
+		return out, info
+	}
+
+	require.NoError(t, c.Put(t.Context(), 1, "one"))
+	backup := filepath.Join(t.TempDir(), "backup.db")
+	code, _, stderr := runAdmin("backup", backup)
+	require.Equal(t, 0, code, stderr)
+
+	// Changes 2 to 4 follow the backup
+	require.NoError(t, c.BatchPut(t.Context(), []client.KeyValue{{Key: 2, Value: "two"}, {Key: 3, Value: "three"}}))
+	time.Sleep(10 * time.Millisecond)
+	until := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	_, err := c.Delete(t.Context(), 1)
+	require.NoError(t, err)
+
+	// Small segments rotate
+	segments, err := filepath.Glob(filepath.Join(dir, "*.journal"))
+	require.NoError(t, err)
+	require.Greater(t, len(segments), 1)
+	var seqs []int64
+	for record, err := range sqlbackend.ReadJournal(dir) {
+		require.NoError(t, err)
+		seqs = append(seqs, record.Seq)
+	}
+	require.Equal(t, []int64{1, 2, 3, 4}, seqs)
+
+	// Recovering to a revision or a time replays the journal up to it
+	out, info := recoverTo("-backup", backup, "-revision", "2")
+	require.Equal(t, int64(2), info.LastSeq)
+	code, _, stderr = runAdmin("restore", out)
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, []client.KeyValue{{Key: 1, Value: "one"}, {Key: 2, Value: "two"}}, entries(t, c))
+
+	out, info = recoverTo("-backup", backup, "-until", until.Format(time.RFC3339Nano))
+	require.Equal(t, int64(3), info.LastSeq)
+	code, _, stderr = runAdmin("restore", out)
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, []client.KeyValue{{Key: 1, Value: "one"}, {Key: 2, Value: "two"}, {Key: 3, Value: "three"}}, entries(t, c))
+
+	// Restores are journaled, and changes after them numbered after the
+	// restore, so only backups made after a restore recover past it
+	require.NoError(t, c.Put(t.Context(), 4, "four"))
+	code, _, stderr = runCLI(t, "", "recover", "-backup", backup, "-journal", dir, filepath.Join(t.TempDir(), "out.db"))
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "journal records a restore at sequence number 5")
+
+	later := filepath.Join(t.TempDir(), "later.db")
+	code, _, stderr = runAdmin("backup", later)
+	require.Equal(t, 0, code, stderr)
+	require.NoError(t, c.Put(t.Context(), 5, "five"))
+	_, info = recoverTo("-backup", later)
+	require.Equal(t, int64(8), info.LastSeq)
+}
+
+func TestJournalTornTail(t *testing.T) {
+	dir := t.TempDir()
+	opts := []sqlbackend.Option{sqlbackend.WithJournal(dir), sqlbackend.WithJournalSegmentSize(1)}
+	backend, err := sqlbackend.New(t.Context(), opts...)
+	require.NoError(t, err)
+	require.NoError(t, backend.Put(t.Context(), 1, "one"))
+	require.NoError(t, backend.Put(t.Context(), 2, "two"))
+	require.NoError(t, backend.Close(t.Context()))
+
+	// A crash during an append leaves a partial record at the end
+	segments, err := filepath.Glob(filepath.Join(dir, "*.journal"))
+	require.NoError(t, err)
+	require.Len(t, segments, 2)
+	f, err := os.OpenFile(segments[1], os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 20, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// Reading stops before it, and reopening truncates it and continues
+	// numbering after the journaled changes
+	backend, err = sqlbackend.New(t.Context(), opts...)
+	require.NoError(t, err)
+	require.NoError(t, backend.Put(t.Context(), 3, "three"))
+	require.NoError(t, backend.Close(t.Context()))
+
+	var keys []string
+	for record, err := range sqlbackend.ReadJournal(dir) {
+		require.NoError(t, err)
+		keys = append(keys, strconv.FormatInt(record.Seq, 10)+"="+record.Value)
+	}
+	require.Equal(t, []string{"1=one", "2=two", "3=three"}, keys)
+
+	// Corruption in earlier segments is reported
+	data := readFile(t, segments[0])
+	data[10] ^= 0xff
+	require.NoError(t, os.WriteFile(segments[0], data, 0o600))
+	var corrupt error
+	for _, err := range sqlbackend.ReadJournal(dir) {
+		corrupt = err
+	}
+	require.ErrorIs(t, corrupt, sqlbackend.ErrJournalCorrupt)
+}
+
+func TestJournalCorruptLastSegment(t *testing.T) {
+	dir := t.TempDir()
+	backend, err := sqlbackend.New(t.Context(), sqlbackend.WithJournal(dir))
+	require.NoError(t, err)
+	require.NoError(t, backend.Put(t.Context(), 1, "one"))
+	require.NoError(t, backend.Put(t.Context(), 2, "two"))
+	require.NoError(t, backend.Close(t.Context()))
+
+	segments, err := filepath.Glob(filepath.Join(dir, "*.journal"))
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+	data := readFile(t, segments[0])
+
+	// A zero-filled tail, as left by some file systems after a crash, is torn
+	// too and truncated
+	require.NoError(t, os.WriteFile(segments[0], append(slices.Clone(data), make([]byte, 100)...), 0o600))
+	backend, err = sqlbackend.New(t.Context(), sqlbackend.WithJournal(dir))
+	require.NoError(t, err)
+	require.NoError(t, backend.Close(t.Context()))
+	require.Equal(t, data, readFile(t, segments[0]))
+
+	// A bad record followed by others is corruption: it's reported rather
+	// than truncated along with the records after it
+	data[10] ^= 0xff
+	require.NoError(t, os.WriteFile(segments[0], data, 0o600))
+	var corrupt error
+	for _, err := range sqlbackend.ReadJournal(dir) {
+		corrupt = err
+	}
+	require.ErrorIs(t, corrupt, sqlbackend.ErrJournalCorrupt)
+
+	_, err = sqlbackend.New(t.Context(), sqlbackend.WithJournal(dir))
+	require.ErrorIs(t, err, sqlbackend.ErrJournalCorrupt)
+	require.Equal(t, data, readFile(t, segments[0]))
+}
@@ -0,0 +1,93 @@
+package itest
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/frontend"
+	"github.com/dynoinc/gh-go/internal/listen"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+func TestMultipleListeners(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+
+	sock := filepath.Join(t.TempDir(), "frontend.sock")
+
+	// A stale socket from a previous run is replaced
+	stale, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	listeners, err := listen.Listen([]string{"tcp://127.0.0.1:0", "unix://" + sock}, 0o600)
+	require.NoError(t, err)
+	require.Len(t, listeners, 2)
+
+	info, err := os.Stat(sock)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	s, otelCleanup, err := frontend.NewServer(t.Context(), backend, frontend.WithNoopTelemetry())
+	require.NoError(t, err)
+	for _, lis := range listeners {
+		go func() {
+			if err := s.Serve(lis); err != nil {
+				t.Logf("Server exited: %v", err)
+			}
+		}()
+	}
+	defer func() {
+		s.Stop()
+		require.NoError(t, backend.Close(t.Context()))
+		otelCleanup()
+	}()
+
+	tcpClient, err := client.New(client.WithTarget(listeners[0].Addr().String()))
+	require.NoError(t, err)
+	defer tcpClient.Close()
+
+	unixClient, err := client.New(client.WithTarget(listen.Addr(listeners[1])))
+	require.NoError(t, err)
+	defer unixClient.Close()
+
+	// Both listeners share one server and backend
+	require.NoError(t, tcpClient.Put(t.Context(), 1, "over tcp"))
+	value, err := unixClient.Get(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "over tcp", value)
+
+	require.NoError(t, unixClient.Put(t.Context(), 2, "over unix"))
+	value, err = tcpClient.Get(t.Context(), 2)
+	require.NoError(t, err)
+	require.Equal(t, "over unix", value)
+}
+
+func TestListenErrors(t *testing.T) {
+	// Regular files are never replaced by a socket
+	path := filepath.Join(t.TempDir(), "not-a-socket")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	_, err := listen.Listen([]string{"unix://" + path}, 0o600)
+	require.ErrorContains(t, err, "not a socket")
+
+	// Sockets a server still listens on are never replaced
+	live := filepath.Join(t.TempDir(), "live.sock")
+	lis, err := net.Listen("unix", live)
+	require.NoError(t, err)
+	defer lis.Close()
+	_, err = listen.Listen([]string{"unix://" + live}, 0o600)
+	require.ErrorContains(t, err, "a server is listening on it")
+
+	// Listeners opened before a failure are closed again
+	sock := filepath.Join(t.TempDir(), "frontend.sock")
+	_, err = listen.Listen([]string{"unix://" + sock, "tcp://256.0.0.1:0"}, 0o600)
+	require.Error(t, err)
+	_, err = os.Stat(sock)
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,114 @@
+package itest
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dynoinc/gh-go/internal/frontend"
+	"github.com/dynoinc/gh-go/internal/logger"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use by the server and test
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// Take returns the buffered output and resets the buffer
+func (b *syncBuffer) Take() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer b.buf.Reset()
+	return b.buf.String()
+}
+
+func TestLoggerFormats(t *testing.T) {
+	_, err := logger.New(&bytes.Buffer{}, "xml", new(slog.LevelVar))
+	require.Error(t, err)
+
+	var buf bytes.Buffer
+	level := new(slog.LevelVar)
+	l, err := logger.New(&buf, logger.FormatJSON, level)
+	require.NoError(t, err)
+
+	// Records carry trace correlation fields from the context
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{2},
+	})
+	l.InfoContext(trace.ContextWithSpanContext(context.Background(), sc), "hello")
+	require.Contains(t, buf.String(), `"trace_id":"`+sc.TraceID().String()+`"`)
+	require.Contains(t, buf.String(), `"span_id":"`+sc.SpanID().String()+`"`)
+
+	// Level changes take effect immediately
+	buf.Reset()
+	l.Debug("hidden")
+	require.Empty(t, buf.String())
+	level.Set(slog.LevelDebug)
+	l.Debug("shown")
+	require.Contains(t, buf.String(), "shown")
+}
+
+func TestCallLogging(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+
+	var out syncBuffer
+	l, err := logger.New(&out, logger.FormatJSON, new(slog.LevelVar))
+	require.NoError(t, err)
+
+	settings := frontend.NewLogSettings(frontend.LogOptions{
+		Payloads:     true,
+		RedactFields: []string{"value"},
+	})
+	c, cleanup := setupTestServer(t, backend, frontend.WithLogger(l), frontend.WithLogSettings(settings))
+	defer cleanup()
+
+	// Payloads are logged with redacted values
+	require.NoError(t, c.Put(t.Context(), 7, "secret-value"))
+	logs := out.Take()
+	require.Contains(t, logs, "grpc.request.content")
+	require.Contains(t, logs, "REDACTED")
+	require.NotContains(t, logs, "secret-value")
+
+	// Sampled out calls are not logged unless they fail
+	settings.Update(frontend.LogOptions{
+		SampleRates: map[string]float64{"frontend.v1.FrontendService": 0},
+	})
+	_, err = c.Get(t.Context(), 7)
+	require.NoError(t, err)
+	require.Empty(t, out.Take())
+
+	_, err = c.Get(t.Context(), 8)
+	require.Error(t, err)
+	logs = out.Take()
+	require.Equal(t, 1, strings.Count(logs, "finished call"))
+	require.Contains(t, logs, "NotFound")
+
+	// Method-level rates take precedence over service-level rates
+	settings.Update(frontend.LogOptions{
+		SampleRates: map[string]float64{
+			"frontend.v1.FrontendService":      0,
+			"/frontend.v1.FrontendService/Get": 1,
+		},
+	})
+	_, err = c.Get(t.Context(), 7)
+	require.NoError(t, err)
+	logs = out.Take()
+	require.Contains(t, logs, "finished call")
+	require.NotContains(t, logs, "grpc.request.content")
+}
@@ -0,0 +1,222 @@
+package itest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/admin"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+// setupNamespaceServer serves a frontend on TCP and an admin listener
+// managing the namespaces of its backend.
+func setupNamespaceServer(t *testing.T) (string, string) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+	addr := serveTCP(t, backend)
+	return addr, serveAdmin(t, admin.WithToken(testAdminToken), admin.WithNamespaces(backend.(sqlbackend.Namespaces)))
+}
+
+type namespaceJSON struct {
+	Name     string `json:"name"`
+	MaxKeys  int64  `json:"max_keys"`
+	MaxBytes int64  `json:"max_bytes"`
+	Keys     int64  `json:"keys"`
+	Bytes    int64  `json:"bytes"`
+}
+
+func TestNamespaces(t *testing.T) {
+	addr, adminAddr := setupNamespaceServer(t)
+	runAdmin := func(args ...string) []namespaceJSON {
+		code, stdout, stderr := runCLI(t, "", append([]string{"-admin-addr", adminAddr, "-admin-token", testAdminToken, "-o", "json", "namespace"}, args...)...)
+		require.Equal(t, 0, code, stderr)
+
+		var namespaces []namespaceJSON
+		scanner := bufio.NewScanner(strings.NewReader(stdout))
+		for scanner.Scan() {
+			var ns namespaceJSON
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &ns))
+			namespaces = append(namespaces, ns)
+		}
+		return namespaces
+	}
+	newClient := func(namespace string) *client.Client {
+		c, err := client.New(client.WithTarget(addr), client.WithInsecure(), client.WithNamespace(namespace))
+		require.NoError(t, err)
+		t.Cleanup(func() { c.Close() })
+		return c
+	}
+
+	// Only the default namespace exists at first, and others must be created
+	// before they're used
+	require.Equal(t, []namespaceJSON{{}}, runAdmin("list"))
+	teamA := newClient("team-a")
+	_, err := teamA.Get(t.Context(), 1)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	err = newClient("Team A").Put(t.Context(), 1, "one")
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	created := runAdmin("create", "-max-keys", "2", "-max-bytes", "10", "team-a")
+	require.Equal(t, []namespaceJSON{{Name: "team-a", MaxKeys: 2, MaxBytes: 10}}, created)
+	code, _, stderr := runCLI(t, "", "-admin-addr", adminAddr, "-admin-token", testAdminToken, "namespace", "create", "team-a")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "AlreadyExists")
+	runAdmin("create", "team-b")
+
+	// The same key is independent in each namespace
+	def := newClient("")
+	require.NoError(t, def.Put(t.Context(), 1, "default"))
+	require.NoError(t, teamA.Put(t.Context(), 1, "one"))
+	code, _, stderr = runCLI(t, "", "-addr", addr, "-namespace", "team-b", "put", "1", "b")
+	require.Equal(t, 0, code, stderr)
+	for namespace, want := range map[string]string{"": "default", "team-a": "one", "team-b": "b"} {
+		value, err := newClient(namespace).Get(t.Context(), 1)
+		require.NoError(t, err)
+		require.Equal(t, want, value)
+	}
+	var keys []int64
+	for entry, err := range teamA.Scan(t.Context()) {
+		require.NoError(t, err)
+		keys = append(keys, entry.Key)
+	}
+	require.Equal(t, []int64{1}, keys)
+
+	// Writes beyond the quota are rejected, and usage is kept up to date
+	require.NoError(t, teamA.Put(t.Context(), 2, "two"))
+	err = teamA.Put(t.Context(), 3, "three")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	err = teamA.Put(t.Context(), 2, "too large")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, []namespaceJSON{{Name: "team-a", MaxKeys: 2, MaxBytes: 10, Keys: 2, Bytes: 6}}, runAdmin("get", "team-a"))
+
+	updated := runAdmin("update", "-max-bytes", "0", "team-a")
+	require.Equal(t, []namespaceJSON{{Name: "team-a", MaxKeys: 2, Keys: 2, Bytes: 6}}, updated)
+	require.NoError(t, teamA.Put(t.Context(), 2, "no longer too large"))
+
+	// Namespaces with keys can't be deleted
+	code, _, stderr = runCLI(t, "", "-admin-addr", adminAddr, "-admin-token", testAdminToken, "namespace", "delete", "team-a")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "FailedPrecondition")
+	for _, key := range []int64{1, 2} {
+		_, err := teamA.Delete(t.Context(), key)
+		require.NoError(t, err)
+	}
+	runAdmin("delete", "team-a")
+	_, err = teamA.Get(t.Context(), 1)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	namespaces := runAdmin("list")
+	require.Len(t, namespaces, 2)
+	require.Equal(t, "", namespaces[0].Name)
+	require.Equal(t, "team-b", namespaces[1].Name)
+}
+
+func TestNamespaceWatch(t *testing.T) {
+	addr, adminAddr := setupNamespaceServer(t)
+	code, _, stderr := runCLI(t, "", "-admin-addr", adminAddr, "-admin-token", testAdminToken, "namespace", "create", "team-a")
+	require.Equal(t, 0, code, stderr)
+
+	def, err := client.New(client.WithTarget(addr), client.WithInsecure())
+	require.NoError(t, err)
+	defer def.Close()
+	teamA, err := client.New(client.WithTarget(addr), client.WithInsecure(), client.WithNamespace("team-a"))
+	require.NoError(t, err)
+	defer teamA.Close()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	changes := make(chan client.Change, 10)
+	go func() {
+		for change, err := range teamA.Watch(ctx, 1) {
+			if err != nil {
+				return
+			}
+			changes <- change
+		}
+	}()
+
+	// Keys are only watched once the stream is up, so retry the first change
+	require.Eventually(t, func() bool {
+		require.NoError(t, teamA.Put(t.Context(), 1, "ready"))
+		select {
+		case <-changes:
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	// Changes of the same key in other namespaces aren't streamed
+	require.NoError(t, def.Put(t.Context(), 1, "default"))
+	require.NoError(t, teamA.Put(t.Context(), 1, "one"))
+	for {
+		change := <-changes
+		if change.Value == "ready" {
+			continue
+		}
+		require.Equal(t, client.Change{Key: 1, Value: "one"}, change)
+		break
+	}
+}
+
+func TestNamespaceJournal(t *testing.T) {
+	dir := t.TempDir()
+	backend, err := sqlbackend.New(t.Context(), sqlbackend.WithJournal(dir))
+	require.NoError(t, err)
+	defer backend.Close(t.Context())
+
+	namespaces := backend.(sqlbackend.Namespaces)
+	_, err = namespaces.CreateNamespace(t.Context(), "team-a", sqlbackend.Quota{})
+	require.NoError(t, err)
+	teamA := namespaces.Namespace("team-a")
+	require.NoError(t, backend.Put(t.Context(), 1, "default"))
+	require.NoError(t, teamA.Put(t.Context(), 1, "one"))
+	_, err = teamA.Delete(t.Context(), 1)
+	require.NoError(t, err)
+
+	// Records name the namespace of their key
+	var changes []sqlbackend.Change
+	for record, err := range sqlbackend.ReadJournal(dir) {
+		require.NoError(t, err)
+		changes = append(changes, sqlbackend.Change{Seq: record.Seq, Namespace: record.Namespace, Key: record.Key, Value: record.Value, Deleted: record.Deleted})
+	}
+	require.Equal(t, []sqlbackend.Change{
+		{Seq: 1, Key: 1, Value: "default"},
+		{Seq: 2, Namespace: "team-a", Key: 1, Value: "one"},
+		{Seq: 3, Namespace: "team-a", Key: 1, Deleted: true},
+	}, changes)
+}
+
+func TestNamespaceDeletedBeforeWrite(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+	defer backend.Close(t.Context())
+	namespaces := backend.(sqlbackend.Namespaces)
+	_, err = namespaces.CreateNamespace(t.Context(), "team-a", sqlbackend.Quota{MaxKeys: 1})
+	require.NoError(t, err)
+	teamA := namespaces.Namespace("team-a")
+
+	// A write racing the namespace's deletion fails instead of recreating it
+	// without its quota
+	require.NoError(t, namespaces.DeleteNamespace(t.Context(), "team-a"))
+	err = teamA.BatchPut(t.Context(), []sqlbackend.KeyValue{{Key: 1, Value: "one"}, {Key: 2, Value: "two"}})
+	require.ErrorIs(t, err, sqlbackend.ErrNamespaceNotFound)
+	_, err = namespaces.GetNamespace(t.Context(), "team-a")
+	require.ErrorIs(t, err, sqlbackend.ErrNamespaceNotFound)
+
+	// Replicated changes still create the namespaces they're in
+	log := backend.(sqlbackend.ChangeLog)
+	require.NoError(t, log.Apply(t.Context(), []sqlbackend.Change{{Seq: 10, Namespace: "team-b", Key: 1, Value: "one"}}))
+	ns, err := namespaces.GetNamespace(t.Context(), "team-b")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), ns.Keys)
+}
@@ -0,0 +1,212 @@
+package itest
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/frontend"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+// replicationNode is an in-process server of a replicated deployment.
+type replicationNode struct {
+	backend sqlbackend.Backend
+	server  *grpc.Server
+	lis     *bufconn.Listener
+	client  *client.Client
+	conn    *grpc.ClientConn // for calls needing response headers
+}
+
+func startReplicationNode(t *testing.T, opts ...frontend.ServerOption) *replicationNode {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+
+	opts = append([]frontend.ServerOption{frontend.WithNoopTelemetry()}, opts...)
+	s, cleanup, err := frontend.NewServer(t.Context(), backend, opts...)
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go s.Serve(lis)
+
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }
+	c, err := client.New(client.WithTarget("passthrough:///node"), client.WithDialer(dialer))
+	require.NoError(t, err)
+	conn, err := grpc.NewClient("passthrough:///node",
+		grpc.WithContextDialer(dialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		c.Close()
+		conn.Close()
+		s.Stop()
+		cleanup()
+		require.NoError(t, backend.Close(context.Background()))
+	})
+	return &replicationNode{backend: backend, server: s, lis: lis, client: c, conn: conn}
+}
+
+// follow replicates from the primary.
+func follow(primary *replicationNode, forwardWrites bool) frontend.ServerOption {
+	return frontend.WithReplication(frontend.ReplicationConfig{
+		Primary:       "passthrough:///primary",
+		ForwardWrites: forwardWrites,
+		DialOptions: []grpc.DialOption{grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return primary.lis.DialContext(ctx)
+		})},
+	})
+}
+
+// replicationLag reads a follower's lag from the header of a Get.
+func replicationLag(t *testing.T, node *replicationNode) time.Duration {
+	var header metadata.MD
+	_, err := frontendpb.NewFrontendServiceClient(node.conn).Get(t.Context(),
+		frontendpb.GetRequest_builder{Key: -1}.Build(), grpc.Header(&header))
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	values := header.Get(frontend.ReplicationLagHeader)
+	require.Len(t, values, 1)
+	seconds, err := strconv.ParseFloat(values[0], 64)
+	require.NoError(t, err)
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func TestReplication(t *testing.T) {
+	primary := startReplicationNode(t)
+
+	// Writes made before a follower starts are replicated too
+	require.NoError(t, primary.client.Put(t.Context(), 1, "one"))
+	require.NoError(t, primary.client.Put(t.Context(), 2, "two"))
+	require.NoError(t, primary.client.BatchPut(t.Context(), []client.KeyValue{{Key: 3, Value: "three"}, {Key: 4, Value: "four"}}))
+	_, err := primary.client.Delete(t.Context(), 2)
+	require.NoError(t, err)
+
+	readOnly := startReplicationNode(t, follow(primary, false))
+	forwarding := startReplicationNode(t, follow(primary, true))
+
+	want := []client.KeyValue{{Key: 1, Value: "one"}, {Key: 3, Value: "three"}, {Key: 4, Value: "four"}}
+	for _, follower := range []*replicationNode{readOnly, forwarding} {
+		require.Eventually(t, func() bool {
+			return slices.Equal(want, entries(t, follower.client))
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Zero(t, replicationLag(t, follower))
+	}
+
+	// Changes reach watchers on followers
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	changes := make(chan client.Change, 10)
+	go func() {
+		for change, err := range readOnly.client.Watch(ctx, 5) {
+			if err != nil {
+				return
+			}
+			changes <- change
+		}
+	}()
+
+	// Keys are only watched once the stream is up, so retry the change
+	require.Eventually(t, func() bool {
+		require.NoError(t, primary.client.Put(t.Context(), 5, "five"))
+		select {
+		case change := <-changes:
+			return change == client.Change{Key: 5, Value: "five"}
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, time.Millisecond)
+
+	// Followers reject or forward writes
+	err = readOnly.client.Put(t.Context(), 6, "six")
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = readOnly.client.Delete(t.Context(), 1)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	require.NoError(t, forwarding.client.Put(t.Context(), 6, "six"))
+	value, err := primary.client.Get(t.Context(), 6)
+	require.NoError(t, err)
+	require.Equal(t, "six", value)
+	require.Eventually(t, func() bool {
+		value, err := readOnly.client.Get(t.Context(), 6)
+		return err == nil && value == "six"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Lag grows once the primary is gone
+	primary.server.Stop()
+	require.Eventually(t, func() bool {
+		return replicationLag(t, readOnly) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	value, err = readOnly.client.Get(t.Context(), 6)
+	require.NoError(t, err)
+	require.Equal(t, "six", value)
+}
+
+func TestReplicationTrimmedChanges(t *testing.T) {
+	primary := startReplicationNode(t)
+	log := primary.backend.(sqlbackend.ChangeLog)
+	for key := range int64(10) {
+		require.NoError(t, primary.client.Put(t.Context(), key, strconv.FormatInt(key, 10)))
+	}
+
+	// Changes a reader still needs are kept, and the latest always is
+	lowWater := int64(5)
+	log.Retain(func() int64 { return lowWater })
+	trimmed, err := log.TrimChanges(t.Context(), sqlbackend.ChangeLogRetention{Changes: 2})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), trimmed)
+	_, err = log.Changes(t.Context(), 4, 10)
+	require.ErrorIs(t, err, sqlbackend.ErrChangesTrimmed)
+	changes, err := log.Changes(t.Context(), 5, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 5)
+
+	lowWater = 10
+	trimmed, err = log.TrimChanges(t.Context(), sqlbackend.ChangeLogRetention{MaxAge: time.Nanosecond})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), trimmed)
+	last, err := log.LastSeq(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, int64(10), last)
+
+	// A follower needing trimmed changes starts over from a copy of the
+	// primary, then streams the changes following it
+	follower := startReplicationNode(t, follow(primary, false))
+	want := make([]client.KeyValue, 0, 11)
+	for key := range int64(10) {
+		want = append(want, client.KeyValue{Key: key, Value: strconv.FormatInt(key, 10)})
+	}
+	require.Eventually(t, func() bool {
+		return slices.Equal(want, entries(t, follower.client))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, primary.client.Put(t.Context(), 10, "10"))
+	want = append(want, client.KeyValue{Key: 10, Value: "10"})
+	require.Eventually(t, func() bool {
+		return slices.Equal(want, entries(t, follower.client))
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// entries returns all entries of c, or nil if the scan fails.
+func entries(t *testing.T, c *client.Client) []client.KeyValue {
+	var got []client.KeyValue
+	for entry, err := range c.Scan(t.Context()) {
+		if err != nil {
+			return nil
+		}
+		got = append(got, entry)
+	}
+	return got
+}
@@ -0,0 +1,282 @@
+package itest
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/frontend"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+// flakyServer fails the first calls with Unavailable and can delay the first
+// call, every call or every Get, wrapping the real handler for everything else
+type flakyServer struct {
+	frontendpb.FrontendServiceServer
+
+	failures   atomic.Int32 // remaining calls to fail
+	slowFirst  time.Duration
+	latency    time.Duration
+	getLatency time.Duration
+	getCalls   atomic.Int32
+	putCalls   atomic.Int32
+	batchCalls atomic.Int32
+	totalCalls atomic.Int32
+}
+
+func (f *flakyServer) intercept(ctx context.Context) error {
+	if f.latency > 0 {
+		time.Sleep(f.latency)
+	}
+	if f.totalCalls.Add(1) == 1 && f.slowFirst > 0 {
+		select {
+		case <-time.After(f.slowFirst):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if f.failures.Add(-1) >= 0 {
+		return status.Error(codes.Unavailable, "injected failure")
+	}
+	return nil
+}
+
+func (f *flakyServer) Put(ctx context.Context, req *frontendpb.PutRequest) (*frontendpb.PutResponse, error) {
+	f.putCalls.Add(1)
+	if err := f.intercept(ctx); err != nil {
+		return nil, err
+	}
+	return f.FrontendServiceServer.Put(ctx, req)
+}
+
+func (f *flakyServer) Get(ctx context.Context, req *frontendpb.GetRequest) (*frontendpb.GetResponse, error) {
+	f.getCalls.Add(1)
+	time.Sleep(f.getLatency)
+	if err := f.intercept(ctx); err != nil {
+		return nil, err
+	}
+	return f.FrontendServiceServer.Get(ctx, req)
+}
+
+func (f *flakyServer) BatchPut(ctx context.Context, req *frontendpb.BatchPutRequest) (*frontendpb.BatchPutResponse, error) {
+	f.batchCalls.Add(1)
+	if err := f.intercept(ctx); err != nil {
+		return nil, err
+	}
+	return f.FrontendServiceServer.BatchPut(ctx, req)
+}
+
+func (f *flakyServer) BatchGet(ctx context.Context, req *frontendpb.BatchGetRequest) (*frontendpb.BatchGetResponse, error) {
+	f.batchCalls.Add(1)
+	if err := f.intercept(ctx); err != nil {
+		return nil, err
+	}
+	return f.FrontendServiceServer.BatchGet(ctx, req)
+}
+
+// setupFlakyServer serves a flakyServer over bufconn and returns a client
+// factory for it
+func setupFlakyServer(t testing.TB, flaky *flakyServer) func(opts ...client.Option) *client.Client {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+	flaky.FrontendServiceServer = frontend.New(backend)
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	frontendpb.RegisterFrontendServiceServer(s, flaky)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			t.Logf("Server exited: %v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		s.Stop()
+		require.NoError(t, backend.Close(context.Background()))
+	})
+
+	return func(opts ...client.Option) *client.Client {
+		opts = append([]client.Option{
+			client.WithTarget("passthrough:///bufnet"),
+			client.WithDialer(func(ctx context.Context, target string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			}),
+		}, opts...)
+		c, err := client.New(opts...)
+		require.NoError(t, err)
+		t.Cleanup(func() { c.Close() })
+		return c
+	}
+}
+
+var fastRetries = client.RetryPolicy{
+	MaxAttempts:       4,
+	InitialBackoff:    time.Millisecond,
+	MaxBackoff:        10 * time.Millisecond,
+	BackoffMultiplier: 2,
+	RetryableCodes:    []codes.Code{codes.Unavailable},
+}
+
+func TestClientRetriesGet(t *testing.T) {
+	flaky := &flakyServer{}
+	newClient := setupFlakyServer(t, flaky)
+	c := newClient(client.WithRetryPolicy(fastRetries))
+
+	require.NoError(t, c.Put(t.Context(), 1, "value"))
+
+	// Get is retried by default
+	flaky.failures.Store(2)
+	value, err := c.Get(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+	require.Equal(t, int32(3), flaky.getCalls.Load())
+
+	// Put is not retried unless enabled
+	flaky.failures.Store(1)
+	err = c.Put(t.Context(), 2, "value")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, int32(2), flaky.putCalls.Load())
+
+	// Attempts are bounded by the policy
+	flaky.getCalls.Store(0)
+	flaky.failures.Store(10)
+	_, err = c.Get(t.Context(), 1)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, int32(4), flaky.getCalls.Load())
+}
+
+func TestClientRetriesPutOptIn(t *testing.T) {
+	flaky := &flakyServer{}
+	newClient := setupFlakyServer(t, flaky)
+	c := newClient(client.WithRetryPolicy(fastRetries), client.WithPutRetries())
+
+	flaky.failures.Store(2)
+	require.NoError(t, c.Put(t.Context(), 1, "value"))
+	require.Equal(t, int32(3), flaky.putCalls.Load())
+
+	// Retries can be turned off entirely
+	noRetries := newClient(client.WithoutRetries())
+	flaky.failures.Store(1)
+	_, err := noRetries.Get(t.Context(), 1)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestClientCallTimeout(t *testing.T) {
+	flaky := &flakyServer{slowFirst: time.Second}
+	newClient := setupFlakyServer(t, flaky)
+	c := newClient(client.WithCallTimeout(50 * time.Millisecond))
+
+	_, err := c.Get(t.Context(), 1)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestClientHedging(t *testing.T) {
+	flaky := &flakyServer{slowFirst: 5 * time.Second}
+	newClient := setupFlakyServer(t, flaky)
+	c := newClient(client.WithHedging(client.HedgingPolicy{
+		MaxAttempts:   3,
+		Delay:         20 * time.Millisecond,
+		NonFatalCodes: []codes.Code{codes.Unavailable},
+	}))
+
+	// The slow first attempt is overtaken by a hedged one
+	start := time.Now()
+	_, err := c.Get(t.Context(), 1)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Less(t, time.Since(start), time.Second)
+	require.GreaterOrEqual(t, flaky.getCalls.Load(), int32(2))
+
+	// Non-fatal failures move on to the next attempt immediately
+	require.NoError(t, c.Put(t.Context(), 1, "value"))
+	flaky.getCalls.Store(0)
+	flaky.failures.Store(2)
+	value, err := c.Get(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+	require.Equal(t, int32(3), flaky.getCalls.Load())
+}
+
+func TestClientCircuitBreaker(t *testing.T) {
+	flaky := &flakyServer{}
+	newClient := setupFlakyServer(t, flaky)
+
+	var mu sync.Mutex
+	var transitions []string
+	var c *client.Client
+	config := client.DefaultBreakerConfig()
+	config.ConsecutiveFailures = 3
+	config.Cooldown = 100 * time.Millisecond
+	config.OnStateChange = func(target string, from, to client.BreakerState) {
+		// The callback may call the client, here failing fast on the open
+		// circuit
+		var err error
+		if to == client.BreakerOpen {
+			_, err = c.Get(t.Context(), 1)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		transitions = append(transitions, from.String()+"->"+to.String())
+		if err != nil && !errors.Is(err, client.ErrCircuitOpen) {
+			transitions = append(transitions, err.Error())
+		}
+	}
+	c = newClient(client.WithoutRetries(), client.WithCircuitBreaker(config))
+
+	// NotFound doesn't count as a failure
+	for range 5 {
+		_, err := c.Get(t.Context(), 1)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	}
+
+	// Consecutive failures open the circuit, after which calls fail fast
+	flaky.failures.Store(3)
+	for range 3 {
+		_, err := c.Get(t.Context(), 1)
+		require.Equal(t, codes.Unavailable, status.Code(err))
+		require.NotErrorIs(t, err, client.ErrCircuitOpen)
+	}
+	flaky.getCalls.Store(0)
+	_, err := c.Get(t.Context(), 1)
+	require.ErrorIs(t, err, client.ErrCircuitOpen)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.ErrorIs(t, c.Put(t.Context(), 1, "value"), client.ErrCircuitOpen)
+	require.Equal(t, int32(0), flaky.getCalls.Load())
+
+	// A failed probe after the cool-down re-opens the circuit
+	time.Sleep(config.Cooldown)
+	flaky.failures.Store(1)
+	_, err = c.Get(t.Context(), 1)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.NotErrorIs(t, err, client.ErrCircuitOpen)
+	_, err = c.Get(t.Context(), 1)
+	require.ErrorIs(t, err, client.ErrCircuitOpen)
+
+	// A successful probe closes it again
+	time.Sleep(config.Cooldown)
+	require.NoError(t, c.Put(t.Context(), 1, "value"))
+	value, err := c.Get(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{
+		"closed->open",
+		"open->half-open",
+		"half-open->open",
+		"open->half-open",
+		"half-open->closed",
+	}, transitions)
+}
@@ -0,0 +1,236 @@
+package itest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/frontend"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+// setupShards serves a frontend per name over bufconn and returns a dialer
+// routing "passthrough:///<name>" targets to them.
+func setupShards(t *testing.T, names ...string) client.Option {
+	listeners := make(map[string]*bufconn.Listener)
+	for _, name := range names {
+		backend, err := sqlbackend.New(t.Context())
+		require.NoError(t, err)
+
+		s, otelCleanup, err := frontend.NewServer(t.Context(), backend, frontend.WithNoopTelemetry())
+		require.NoError(t, err)
+
+		lis := bufconn.Listen(1024 * 1024)
+		go s.Serve(lis)
+		t.Cleanup(func() {
+			s.Stop()
+			require.NoError(t, backend.Close(context.Background()))
+			otelCleanup()
+		})
+		listeners[name] = lis
+	}
+
+	return client.WithDialer(func(ctx context.Context, target string) (net.Conn, error) {
+		lis, ok := listeners[target]
+		if !ok {
+			return nil, fmt.Errorf("unknown shard %q", target)
+		}
+		return lis.DialContext(ctx)
+	})
+}
+
+// shardClient connects to a single shard, bypassing routing.
+func shardClient(t *testing.T, dialer client.Option, name string) *client.Client {
+	c, err := client.New(client.WithTarget("passthrough:///"+name), dialer)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func scanAll(t *testing.T, s *client.Sharded, opts ...client.ScanOption) []client.KeyValue {
+	var entries []client.KeyValue
+	for entry, err := range s.Scan(t.Context(), opts...) {
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestShardedRange(t *testing.T) {
+	dialer := setupShards(t, "low", "high")
+	topology := &client.Topology{
+		Strategy: client.StrategyRange,
+		Shards: []client.Shard{
+			{Name: "high", Target: "passthrough:///high", Start: 100},
+			{Name: "low", Target: "passthrough:///low", Start: math.MinInt64},
+		},
+	}
+
+	s, err := client.NewSharded(topology, dialer)
+	require.NoError(t, err)
+	defer s.Close()
+
+	for _, key := range []int64{-5, 99, 100, 250} {
+		require.NoError(t, s.Put(t.Context(), key, fmt.Sprint("v", key)))
+	}
+
+	// Keys land on the shard owning their range
+	low := shardClient(t, dialer, "low")
+	_, err = low.Get(t.Context(), 99)
+	require.NoError(t, err)
+	_, err = low.Get(t.Context(), 100)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	value, err := s.Get(t.Context(), 250)
+	require.NoError(t, err)
+	require.Equal(t, "v250", value)
+
+	// Scans merge the shards in key order
+	require.Equal(t, []client.KeyValue{
+		{Key: -5, Value: "v-5"},
+		{Key: 99, Value: "v99"},
+		{Key: 100, Value: "v100"},
+		{Key: 250, Value: "v250"},
+	}, scanAll(t, s))
+	require.Equal(t, []client.KeyValue{
+		{Key: 99, Value: "v99"},
+		{Key: 100, Value: "v100"},
+	}, scanAll(t, s, client.ScanFrom(0), client.ScanLimit(2)))
+
+	found, err := s.Delete(t.Context(), 100)
+	require.NoError(t, err)
+	require.True(t, found)
+	_, err = s.Get(t.Context(), 100)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestShardedHashResharding(t *testing.T) {
+	dialer := setupShards(t, "a", "b", "c")
+	old := &client.Topology{
+		Strategy: client.StrategyHash,
+		Shards: []client.Shard{
+			{Name: "a", Target: "passthrough:///a"},
+			{Name: "b", Target: "passthrough:///b"},
+		},
+	}
+
+	s, err := client.NewSharded(old, dialer)
+	require.NoError(t, err)
+	defer s.Close()
+
+	const keys = 300
+	for key := range int64(keys) {
+		require.NoError(t, s.Put(t.Context(), key, fmt.Sprint("v", key)))
+	}
+
+	// Both shards receive a share of the keys
+	counts := make(map[string]int)
+	for _, name := range []string{"a", "b"} {
+		for _, err := range shardClient(t, dialer, name).Scan(t.Context()) {
+			require.NoError(t, err)
+			counts[name]++
+		}
+	}
+	require.Equal(t, keys, counts["a"]+counts["b"])
+	require.Greater(t, counts["a"], keys/4)
+	require.Greater(t, counts["b"], keys/4)
+
+	// Adding a shard keeps every key readable before it is moved
+	resharded, err := client.NewSharded(&client.Topology{
+		Strategy: client.StrategyHash,
+		Shards:   append(old.Shards, client.Shard{Name: "c", Target: "passthrough:///c"}),
+		Previous: old,
+	}, dialer)
+	require.NoError(t, err)
+	defer resharded.Close()
+
+	for key := range int64(keys) {
+		value, err := resharded.Get(t.Context(), key)
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprint("v", key), value)
+	}
+
+	// New writes go to the new shard and win over unmoved copies
+	expected := make([]client.KeyValue, keys)
+	for key := range int64(keys) {
+		expected[key] = client.KeyValue{Key: key, Value: fmt.Sprint("v", key)}
+	}
+	for key := range int64(10) {
+		require.NoError(t, resharded.Put(t.Context(), key, "new"))
+		expected[key].Value = "new"
+	}
+	onNew := 0
+	for _, err := range shardClient(t, dialer, "c").Scan(t.Context()) {
+		require.NoError(t, err)
+		onNew++
+	}
+	require.Positive(t, onNew)
+	for key := range int64(10) {
+		value, err := resharded.Get(t.Context(), key)
+		require.NoError(t, err)
+		require.Equal(t, "new", value)
+	}
+	require.Equal(t, expected, scanAll(t, resharded))
+
+	// Migration moves the remaining keys without losing newer values
+	moved, err := resharded.Migrate(t.Context())
+	require.NoError(t, err)
+	require.Positive(t, moved)
+	require.Less(t, moved, keys/2)
+
+	final, err := client.NewSharded(&client.Topology{
+		Strategy: client.StrategyHash,
+		Shards:   append(old.Shards, client.Shard{Name: "c", Target: "passthrough:///c"}),
+	}, dialer)
+	require.NoError(t, err)
+	defer final.Close()
+	require.Equal(t, expected, scanAll(t, final))
+}
+
+func TestLoadTopology(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "topology.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	topology, err := client.LoadTopology(write(`
+strategy: range
+shards:
+  - {name: low, target: "dns:///low:5051", start: -9223372036854775808}
+  - {name: high, target: "dns:///high:5051", start: 0}
+previous:
+  strategy: hash
+  shards:
+    - {name: only, target: "dns:///only:5051"}
+`))
+	require.NoError(t, err)
+	require.Equal(t, client.StrategyRange, topology.Strategy)
+	require.Len(t, topology.Shards, 2)
+	require.Equal(t, "dns:///only:5051", topology.Previous.Shards[0].Target)
+
+	_, err = client.LoadTopology(write(`
+strategy: range
+shards:
+  - {name: a, target: "dns:///a:5051", start: 5}
+  - {name: a, start: 5}
+`))
+	require.ErrorContains(t, err, "shard name \"a\" is used twice")
+	require.ErrorContains(t, err, "has no target")
+	require.ErrorContains(t, err, "both start at 5")
+	require.ErrorContains(t, err, "one shard must start at")
+
+	_, err = client.LoadTopology(write("strategy: hash\nshard: []\n"))
+	require.ErrorContains(t, err, "field shard not found")
+}
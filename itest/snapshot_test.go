@@ -0,0 +1,110 @@
+package itest
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+func TestSnapshot(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+	c, cleanup := setupTestServer(t, backend)
+	defer cleanup()
+
+	// Enough entries for Scan to read more than one page
+	entries := make([]client.KeyValue, 1500)
+	for i := range entries {
+		entries[i] = client.KeyValue{Key: int64(i), Value: "old"}
+	}
+	require.NoError(t, c.BatchPut(t.Context(), entries))
+
+	snap, err := c.Snapshot(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, int64(1500), snap.Revision())
+
+	for i := range entries {
+		entries[i].Value = "new"
+	}
+	require.NoError(t, c.BatchPut(t.Context(), entries))
+	_, err = c.Delete(t.Context(), 1)
+	require.NoError(t, err)
+	require.NoError(t, c.Put(t.Context(), 2000, "created"))
+
+	// Reads through the snapshot don't see the later writes
+	values, err := snap.BatchGet(t.Context(), 0, 1, 2000)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]string{0: "old", 1: "old"}, values)
+
+	var scanned int
+	for entry, err := range c.SnapshotAt(snap.Revision()).Scan(t.Context()) {
+		require.NoError(t, err)
+		require.Equal(t, "old", entry.Value, "key %d", entry.Key)
+		scanned++
+	}
+	require.Equal(t, 1500, scanned)
+
+	// The latest snapshot sees them
+	latest, err := c.Snapshot(t.Context())
+	require.NoError(t, err)
+	values, err = latest.BatchGet(t.Context(), 0, 1, 2000)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]string{0: "new", 2000: "created"}, values)
+
+	_, err = c.SnapshotAt(latest.Revision()+1).BatchGet(t.Context(), 0)
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+	_, err = c.SnapshotAt(-1).BatchGet(t.Context(), 0)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Compaction trims the versions the old snapshot reads
+	_, err = backend.(sqlbackend.Versioned).CompactHistory(t.Context(), sqlbackend.HistoryRetention{Versions: 1})
+	require.NoError(t, err)
+	_, err = snap.BatchGet(t.Context(), 0)
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+	for _, err := range snap.Scan(t.Context(), client.ScanFrom(10), client.ScanLimit(5)) {
+		require.Equal(t, codes.OutOfRange, status.Code(err))
+	}
+}
+
+func TestSnapshotConsistency(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+	c, cleanup := setupTestServer(t, backend)
+	defer cleanup()
+
+	// A writer keeps both keys equal, so a consistent read never sees them
+	// differ
+	done := make(chan struct{})
+	writerErr := make(chan error, 1)
+	go func() {
+		defer close(writerErr)
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			value := strconv.Itoa(i)
+			if err := c.BatchPut(t.Context(), []client.KeyValue{{Key: 1, Value: value}, {Key: 2, Value: value}}); err != nil {
+				writerErr <- err
+				return
+			}
+		}
+	}()
+
+	for range 200 {
+		snap, err := c.Snapshot(t.Context())
+		require.NoError(t, err)
+		values, err := snap.BatchGet(t.Context(), 1, 2)
+		require.NoError(t, err)
+		require.Equal(t, values[1], values[2], "revision %d", snap.Revision())
+	}
+	close(done)
+	require.NoError(t, <-writerErr)
+}
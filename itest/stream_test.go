@@ -0,0 +1,171 @@
+package itest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	testgrpc "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dynoinc/gh-go/internal/frontend"
+	"github.com/dynoinc/gh-go/internal/logger"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+)
+
+// streamService is a server-streaming test service that panics when asked
+// for no responses
+type streamService struct {
+	testgrpc.UnimplementedTestServiceServer
+}
+
+func (s *streamService) StreamingOutputCall(
+	req *testgrpc.StreamingOutputCallRequest,
+	stream grpc.ServerStreamingServer[testgrpc.StreamingOutputCallResponse],
+) error {
+	if len(req.GetResponseParameters()) == 0 {
+		panic("no response parameters")
+	}
+
+	for _, p := range req.GetResponseParameters() {
+		resp := &testgrpc.StreamingOutputCallResponse{
+			Payload: &testgrpc.Payload{Body: make([]byte, p.GetSize())},
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setupStreamTestServer creates a frontend server with the stream test
+// service registered next to the frontend services
+func setupStreamTestServer(t *testing.T, opts ...frontend.ServerOption) *grpc.ClientConn {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1024 * 1024)
+
+	opts = append([]frontend.ServerOption{frontend.WithNoopTelemetry()}, opts...)
+	s, otelCleanup, err := frontend.NewServer(t.Context(), backend, opts...)
+	require.NoError(t, err)
+	testgrpc.RegisterTestServiceServer(s, &streamService{})
+
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			t.Logf("Server exited: %v", err)
+		}
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, target string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+		require.NoError(t, backend.Close(context.Background()))
+		otelCleanup()
+	})
+
+	return conn
+}
+
+// receiveAll drains a streaming call and returns the number of messages and
+// the final error
+func receiveAll(ctx context.Context, conn *grpc.ClientConn, sizes ...int32) (int, error) {
+	req := &testgrpc.StreamingOutputCallRequest{}
+	for _, size := range sizes {
+		req.ResponseParameters = append(req.ResponseParameters, &testgrpc.ResponseParameters{Size: size})
+	}
+
+	stream, err := testgrpc.NewTestServiceClient(conn).StreamingOutputCall(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return n, nil
+			}
+			return n, err
+		}
+		n++
+	}
+}
+
+func TestStreamRecoveryAndLogging(t *testing.T) {
+	var out syncBuffer
+	l, err := logger.New(&out, logger.FormatJSON, new(slog.LevelVar))
+	require.NoError(t, err)
+
+	conn := setupStreamTestServer(t, frontend.WithLogger(l))
+
+	n, err := receiveAll(t.Context(), conn, 1, 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	logs := out.Take()
+	require.Contains(t, logs, "finished call")
+	require.Contains(t, logs, "StreamingOutputCall")
+
+	// A panicking stream handler is turned into Internal and logged
+	_, err = receiveAll(t.Context(), conn)
+	require.Equal(t, codes.Internal, status.Code(err))
+	require.Contains(t, out.Take(), "recovered from panic")
+
+	// The server keeps serving after the panic
+	n, err = receiveAll(t.Context(), conn, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+func TestStreamAuth(t *testing.T) {
+	conn := setupStreamTestServer(t, frontend.WithAuthToken("token"))
+
+	_, err := receiveAll(t.Context(), conn, 1)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(t.Context(), "authorization", "Bearer wrong")
+	_, err = receiveAll(ctx, conn, 1)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = metadata.AppendToOutgoingContext(t.Context(), "authorization", "Bearer token")
+	n, err := receiveAll(ctx, conn, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	// Health streams stay reachable without a token
+	watch, err := grpc_health_v1.NewHealthClient(conn).Watch(t.Context(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	resp, err := watch.Recv()
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+}
+
+func TestStreamRateLimit(t *testing.T) {
+	conn := setupStreamTestServer(t, frontend.WithRateLimiter(rate.NewLimiter(rate.Every(1<<62), 1)))
+
+	_, err := receiveAll(t.Context(), conn, 1)
+	require.NoError(t, err)
+
+	_, err = receiveAll(t.Context(), conn, 1)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,112 @@
+package itest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protodelim"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+func TestImportExport(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+	c, cleanup := setupTestServer(t, backend)
+	defer cleanup()
+
+	// Each format imports in chunks
+	result, err := c.Import(t.Context(), strings.NewReader("key,value\n1,one\n2,\"t,wo\"\n3,three\n"), client.FormatCSV, client.ImportChunkSize(2))
+	require.NoError(t, err)
+	require.Equal(t, client.ImportResult{Entries: 3, Chunks: 2}, result)
+
+	result, err = c.Import(t.Context(), strings.NewReader(`{"key":4,"value":"four"}`+"\n\n"+`{"key":1,"value":"uno"}`), client.FormatJSONL)
+	require.NoError(t, err)
+	require.Equal(t, client.ImportResult{Entries: 2, Chunks: 1}, result)
+
+	var delimited bytes.Buffer
+	for key := int64(5); key <= 7; key++ {
+		_, err := protodelim.MarshalTo(&delimited, frontendpb.PutRequest_builder{Key: key, Value: "proto"}.Build())
+		require.NoError(t, err)
+	}
+	var progress []client.Progress
+	result, err = c.Import(t.Context(), &delimited, client.FormatProto, client.ImportProgress(func(p client.Progress) {
+		progress = append(progress, p)
+	}))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), result.Entries)
+	require.Equal(t, int64(3), progress[len(progress)-1].Entries)
+	require.Len(t, entries(t, c), 7)
+
+	// Dry runs validate without storing
+	result, err = c.Import(t.Context(), strings.NewReader("8,eight\n9,nine\n"), client.FormatCSV, client.ImportDryRun())
+	require.NoError(t, err)
+	require.Equal(t, client.ImportResult{Entries: 2}, result)
+	_, err = c.Import(t.Context(), strings.NewReader("8,eight\nnine,9\n"), client.FormatCSV, client.ImportDryRun())
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, err.Error(), `line 2: invalid key "nine"`)
+	require.Len(t, entries(t, c), 7)
+
+	// Chunks committed before invalid data are kept
+	_, err = c.Import(t.Context(), strings.NewReader(`{"key":8,"value":"eight"}`+"\n"+`{"value":"keyless"}`), client.FormatJSONL, client.ImportChunkSize(1))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, err.Error(), "line 2: missing key (1 entries imported before it)")
+	require.Len(t, entries(t, c), 8)
+
+	_, err = c.Import(t.Context(), strings.NewReader(""), client.Format(0))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Exports stream chunks of whole entries, and resume after the last key
+	var out bytes.Buffer
+	progress = nil
+	exported, err := c.Export(t.Context(), &out, client.FormatCSV, client.ExportTo(4), client.ExportChunkSize(2), client.ExportProgress(func(p client.Progress) {
+		progress = append(progress, p)
+	}))
+	require.NoError(t, err)
+	require.Equal(t, client.ExportResult{Entries: 3, LastKey: 3}, exported)
+	require.Equal(t, "1,uno\n2,\"t,wo\"\n3,three\n", out.String())
+	require.Len(t, progress, 2)
+	require.Equal(t, int64(2), progress[0].LastKey)
+
+	exported, err = c.Export(t.Context(), &out, client.FormatCSV, client.ExportFrom(exported.LastKey+1), client.ExportTo(6))
+	require.NoError(t, err)
+	require.Equal(t, client.ExportResult{Entries: 2, LastKey: 5}, exported)
+	require.Equal(t, "1,uno\n2,\"t,wo\"\n3,three\n4,four\n5,proto\n", out.String())
+
+	// Exports import back in every format
+	for _, format := range []client.Format{client.FormatJSONL, client.FormatCSV, client.FormatProto} {
+		var buf bytes.Buffer
+		exported, err := c.Export(t.Context(), &buf, format)
+		require.NoError(t, err)
+		require.Equal(t, int64(8), exported.Entries)
+
+		result, err := c.Import(t.Context(), &buf, format, client.ImportDryRun())
+		require.NoError(t, err)
+		require.Equal(t, int64(8), result.Entries)
+	}
+}
+
+func TestCLIImportExportFormats(t *testing.T) {
+	addr := startTCPServer(t)
+
+	code, _, stderr := runCLI(t, "1,one\n2,two\n", "-addr", addr, "import", "-format", "csv", "-dry-run")
+	require.Equal(t, 0, code, stderr)
+	require.Contains(t, stderr, "validated 2 entries")
+
+	code, _, stderr = runCLI(t, "1,one\n2,two\n", "-addr", addr, "import", "-format", "csv")
+	require.Equal(t, 0, code, stderr)
+
+	code, stdout, stderr := runCLI(t, "", "-addr", addr, "export", "-format", "csv", "-from", "2")
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, "2,two\n", stdout)
+
+	code, _, stderr = runCLI(t, "", "-addr", addr, "export", "-format", "xml")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, `format must be "jsonl", "csv" or "proto"`)
+}
@@ -0,0 +1,91 @@
+package itest
+
+import (
+	"compress/gzip"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dynoinc/gh-go/client"
+	"github.com/dynoinc/gh-go/internal/sqlbackend"
+	frontendpb "github.com/dynoinc/gh-go/proto/frontend/v1"
+)
+
+type profile struct {
+	Name  string
+	Tags  []string
+	Score float64
+}
+
+func TestTypedCodecs(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+
+	c, cleanup := setupTestServer(t, backend)
+	defer cleanup()
+
+	want := profile{Name: "gopher", Tags: []string{"a", "b"}, Score: 4.5}
+	codecs := map[string]client.Codec[profile]{
+		"json":    client.JSONCodec[profile](),
+		"gob":     client.GobCodec[profile](),
+		"msgpack": client.MsgpackCodec[profile](),
+	}
+	key := int64(1)
+	for name, codec := range codecs {
+		for _, compressed := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/compressed=%v", name, compressed), func(t *testing.T) {
+				var opts []client.TypedOption
+				if compressed {
+					opts = append(opts, client.WithCompression(client.Gzip(gzip.BestSpeed)))
+				}
+				typed := client.NewTyped(c, codec, opts...)
+
+				key++
+				require.NoError(t, typed.Put(t.Context(), key, want))
+				got, err := typed.Get(t.Context(), key)
+				require.NoError(t, err)
+				require.Equal(t, want, got)
+			})
+		}
+	}
+
+	// JSON is stored as is, so other clients can read it
+	typed := client.NewTyped(c, client.JSONCodec[profile]())
+	require.NoError(t, typed.Put(t.Context(), 100, want))
+	raw, err := c.Get(t.Context(), 100)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(raw, `{"Name":"gopher"`))
+
+	// Protobuf messages round-trip through their wire format
+	messages := client.NewTyped(c, client.ProtoCodec[*frontendpb.PutRequest]())
+	msg := frontendpb.PutRequest_builder{Key: 7, Value: "nested"}.Build()
+	require.NoError(t, messages.Put(t.Context(), 101, msg))
+	gotMsg, err := messages.Get(t.Context(), 101)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(msg, gotMsg))
+}
+
+func TestTypedDecodeError(t *testing.T) {
+	backend, err := sqlbackend.New(t.Context())
+	require.NoError(t, err)
+
+	c, cleanup := setupTestServer(t, backend)
+	defer cleanup()
+
+	require.NoError(t, c.Put(t.Context(), 42, "not json"))
+
+	typed := client.NewTyped(c, client.JSONCodec[profile]())
+	_, err = typed.Get(t.Context(), 42)
+	var decodeErr *client.DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	require.Equal(t, int64(42), decodeErr.Key)
+	require.ErrorContains(t, err, "key 42")
+
+	binary := client.NewTyped(c, client.MsgpackCodec[profile]())
+	_, err = binary.Get(t.Context(), 42)
+	require.ErrorAs(t, err, &decodeErr)
+	require.Equal(t, int64(42), decodeErr.Key)
+}
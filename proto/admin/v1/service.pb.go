@@ -0,0 +1,1876 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: admin/v1/service.proto
+
+package v1
+
+import (
+	reflect "reflect"
+	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BackupInfo describes a backup file.
+type BackupInfo struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Path          string                 `protobuf:"bytes,1,opt,name=path"`
+	xxx_hidden_Sha256        string                 `protobuf:"bytes,2,opt,name=sha256"`
+	xxx_hidden_SizeBytes     int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes"`
+	xxx_hidden_SchemaVersion uint64                 `protobuf:"varint,4,opt,name=schema_version,json=schemaVersion"`
+	xxx_hidden_LastSeq       int64                  `protobuf:"varint,5,opt,name=last_seq,json=lastSeq"`
+	xxx_hidden_CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *BackupInfo) Reset() {
+	*x = BackupInfo{}
+	mi := &file_admin_v1_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupInfo) ProtoMessage() {}
+
+func (x *BackupInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BackupInfo) GetPath() string {
+	if x != nil {
+		return x.xxx_hidden_Path
+	}
+	return ""
+}
+
+func (x *BackupInfo) GetSha256() string {
+	if x != nil {
+		return x.xxx_hidden_Sha256
+	}
+	return ""
+}
+
+func (x *BackupInfo) GetSizeBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_SizeBytes
+	}
+	return 0
+}
+
+func (x *BackupInfo) GetSchemaVersion() uint64 {
+	if x != nil {
+		return x.xxx_hidden_SchemaVersion
+	}
+	return 0
+}
+
+func (x *BackupInfo) GetLastSeq() int64 {
+	if x != nil {
+		return x.xxx_hidden_LastSeq
+	}
+	return 0
+}
+
+func (x *BackupInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return 0
+}
+
+func (x *BackupInfo) SetPath(v string) {
+	x.xxx_hidden_Path = v
+}
+
+func (x *BackupInfo) SetSha256(v string) {
+	x.xxx_hidden_Sha256 = v
+}
+
+func (x *BackupInfo) SetSizeBytes(v int64) {
+	x.xxx_hidden_SizeBytes = v
+}
+
+func (x *BackupInfo) SetSchemaVersion(v uint64) {
+	x.xxx_hidden_SchemaVersion = v
+}
+
+func (x *BackupInfo) SetLastSeq(v int64) {
+	x.xxx_hidden_LastSeq = v
+}
+
+func (x *BackupInfo) SetCreatedAt(v int64) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+type BackupInfo_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Path of the backup on the server.
+	Path string
+	// Hex-encoded SHA-256 checksum of the file.
+	Sha256    string
+	SizeBytes int64
+	// Migration version of the database schema.
+	SchemaVersion uint64
+	// Sequence number of the last change included.
+	LastSeq int64
+	// Unix nanoseconds.
+	CreatedAt int64
+}
+
+func (b0 BackupInfo_builder) Build() *BackupInfo {
+	m0 := &BackupInfo{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Path = b.Path
+	x.xxx_hidden_Sha256 = b.Sha256
+	x.xxx_hidden_SizeBytes = b.SizeBytes
+	x.xxx_hidden_SchemaVersion = b.SchemaVersion
+	x.xxx_hidden_LastSeq = b.LastSeq
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	return m0
+}
+
+type BackupRequest struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Path string                 `protobuf:"bytes,1,opt,name=path"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BackupRequest) Reset() {
+	*x = BackupRequest{}
+	mi := &file_admin_v1_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupRequest) ProtoMessage() {}
+
+func (x *BackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BackupRequest) GetPath() string {
+	if x != nil {
+		return x.xxx_hidden_Path
+	}
+	return ""
+}
+
+func (x *BackupRequest) SetPath(v string) {
+	x.xxx_hidden_Path = v
+}
+
+type BackupRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Path on the server to write the backup to. It must not exist.
+	Path string
+}
+
+func (b0 BackupRequest_builder) Build() *BackupRequest {
+	m0 := &BackupRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Path = b.Path
+	return m0
+}
+
+type BackupResponse struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Info *BackupInfo            `protobuf:"bytes,1,opt,name=info"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BackupResponse) Reset() {
+	*x = BackupResponse{}
+	mi := &file_admin_v1_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupResponse) ProtoMessage() {}
+
+func (x *BackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BackupResponse) GetInfo() *BackupInfo {
+	if x != nil {
+		return x.xxx_hidden_Info
+	}
+	return nil
+}
+
+func (x *BackupResponse) SetInfo(v *BackupInfo) {
+	x.xxx_hidden_Info = v
+}
+
+func (x *BackupResponse) HasInfo() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Info != nil
+}
+
+func (x *BackupResponse) ClearInfo() {
+	x.xxx_hidden_Info = nil
+}
+
+type BackupResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Info *BackupInfo
+}
+
+func (b0 BackupResponse_builder) Build() *BackupResponse {
+	m0 := &BackupResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Info = b.Info
+	return m0
+}
+
+type RestoreRequest struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Path string                 `protobuf:"bytes,1,opt,name=path"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RestoreRequest) Reset() {
+	*x = RestoreRequest{}
+	mi := &file_admin_v1_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreRequest) ProtoMessage() {}
+
+func (x *RestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestoreRequest) GetPath() string {
+	if x != nil {
+		return x.xxx_hidden_Path
+	}
+	return ""
+}
+
+func (x *RestoreRequest) SetPath(v string) {
+	x.xxx_hidden_Path = v
+}
+
+type RestoreRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Path on the server of a backup made by Backup.
+	Path string
+}
+
+func (b0 RestoreRequest_builder) Build() *RestoreRequest {
+	m0 := &RestoreRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Path = b.Path
+	return m0
+}
+
+type RestoreResponse struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Info *BackupInfo            `protobuf:"bytes,1,opt,name=info"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RestoreResponse) Reset() {
+	*x = RestoreResponse{}
+	mi := &file_admin_v1_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreResponse) ProtoMessage() {}
+
+func (x *RestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestoreResponse) GetInfo() *BackupInfo {
+	if x != nil {
+		return x.xxx_hidden_Info
+	}
+	return nil
+}
+
+func (x *RestoreResponse) SetInfo(v *BackupInfo) {
+	x.xxx_hidden_Info = v
+}
+
+func (x *RestoreResponse) HasInfo() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Info != nil
+}
+
+func (x *RestoreResponse) ClearInfo() {
+	x.xxx_hidden_Info = nil
+}
+
+type RestoreResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Info *BackupInfo
+}
+
+func (b0 RestoreResponse_builder) Build() *RestoreResponse {
+	m0 := &RestoreResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Info = b.Info
+	return m0
+}
+
+// AuditEntry records a change of a key made by a write the server handled.
+type AuditEntry struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Seq       int64                  `protobuf:"varint,1,opt,name=seq"`
+	xxx_hidden_Time      int64                  `protobuf:"varint,2,opt,name=time"`
+	xxx_hidden_Principal string                 `protobuf:"bytes,3,opt,name=principal"`
+	xxx_hidden_Peer      string                 `protobuf:"bytes,4,opt,name=peer"`
+	xxx_hidden_Method    string                 `protobuf:"bytes,5,opt,name=method"`
+	xxx_hidden_Key       int64                  `protobuf:"varint,6,opt,name=key"`
+	xxx_hidden_OldHash   string                 `protobuf:"bytes,7,opt,name=old_hash,json=oldHash"`
+	xxx_hidden_NewHash   string                 `protobuf:"bytes,8,opt,name=new_hash,json=newHash"`
+	xxx_hidden_TraceId   string                 `protobuf:"bytes,9,opt,name=trace_id,json=traceId"`
+	xxx_hidden_PrevHash  string                 `protobuf:"bytes,10,opt,name=prev_hash,json=prevHash"`
+	xxx_hidden_Hash      string                 `protobuf:"bytes,11,opt,name=hash"`
+	xxx_hidden_Namespace string                 `protobuf:"bytes,12,opt,name=namespace"`
+	xxx_hidden_Via       string                 `protobuf:"bytes,13,opt,name=via"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_admin_v1_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AuditEntry) GetSeq() int64 {
+	if x != nil {
+		return x.xxx_hidden_Seq
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetTime() int64 {
+	if x != nil {
+		return x.xxx_hidden_Time
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetPrincipal() string {
+	if x != nil {
+		return x.xxx_hidden_Principal
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetPeer() string {
+	if x != nil {
+		return x.xxx_hidden_Peer
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetMethod() string {
+	if x != nil {
+		return x.xxx_hidden_Method
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_Key
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetOldHash() string {
+	if x != nil {
+		return x.xxx_hidden_OldHash
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetNewHash() string {
+	if x != nil {
+		return x.xxx_hidden_NewHash
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetTraceId() string {
+	if x != nil {
+		return x.xxx_hidden_TraceId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetPrevHash() string {
+	if x != nil {
+		return x.xxx_hidden_PrevHash
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetHash() string {
+	if x != nil {
+		return x.xxx_hidden_Hash
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetNamespace() string {
+	if x != nil {
+		return x.xxx_hidden_Namespace
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetVia() string {
+	if x != nil {
+		return x.xxx_hidden_Via
+	}
+	return ""
+}
+
+func (x *AuditEntry) SetSeq(v int64) {
+	x.xxx_hidden_Seq = v
+}
+
+func (x *AuditEntry) SetTime(v int64) {
+	x.xxx_hidden_Time = v
+}
+
+func (x *AuditEntry) SetPrincipal(v string) {
+	x.xxx_hidden_Principal = v
+}
+
+func (x *AuditEntry) SetPeer(v string) {
+	x.xxx_hidden_Peer = v
+}
+
+func (x *AuditEntry) SetMethod(v string) {
+	x.xxx_hidden_Method = v
+}
+
+func (x *AuditEntry) SetKey(v int64) {
+	x.xxx_hidden_Key = v
+}
+
+func (x *AuditEntry) SetOldHash(v string) {
+	x.xxx_hidden_OldHash = v
+}
+
+func (x *AuditEntry) SetNewHash(v string) {
+	x.xxx_hidden_NewHash = v
+}
+
+func (x *AuditEntry) SetTraceId(v string) {
+	x.xxx_hidden_TraceId = v
+}
+
+func (x *AuditEntry) SetPrevHash(v string) {
+	x.xxx_hidden_PrevHash = v
+}
+
+func (x *AuditEntry) SetHash(v string) {
+	x.xxx_hidden_Hash = v
+}
+
+func (x *AuditEntry) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = v
+}
+
+func (x *AuditEntry) SetVia(v string) {
+	x.xxx_hidden_Via = v
+}
+
+type AuditEntry_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Seq int64
+	// Unix nanoseconds.
+	Time int64
+	// principal identifies the caller by its TLS client certificate or a
+	// fingerprint of its bearer token; peer is its address.
+	Principal string
+	Peer      string
+	// method is the full gRPC method name of the write.
+	Method string
+	Key    int64
+	// Hex-encoded SHA-256 hashes of the value before and after the
+	// change, empty if the key didn't exist or was deleted.
+	OldHash string
+	NewHash string
+	TraceId string
+	// hash chains the entry to prev_hash, the hash of the entry before.
+	PrevHash string
+	Hash     string
+	// namespace of the key, empty for the default namespace.
+	Namespace string
+	// via is the principal of the server that forwarded the write on
+	// the caller's behalf, empty if the caller made it directly.
+	Via string
+}
+
+func (b0 AuditEntry_builder) Build() *AuditEntry {
+	m0 := &AuditEntry{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Seq = b.Seq
+	x.xxx_hidden_Time = b.Time
+	x.xxx_hidden_Principal = b.Principal
+	x.xxx_hidden_Peer = b.Peer
+	x.xxx_hidden_Method = b.Method
+	x.xxx_hidden_Key = b.Key
+	x.xxx_hidden_OldHash = b.OldHash
+	x.xxx_hidden_NewHash = b.NewHash
+	x.xxx_hidden_TraceId = b.TraceId
+	x.xxx_hidden_PrevHash = b.PrevHash
+	x.xxx_hidden_Hash = b.Hash
+	x.xxx_hidden_Namespace = b.Namespace
+	x.xxx_hidden_Via = b.Via
+	return m0
+}
+
+type QueryAuditRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Key         int64                  `protobuf:"varint,1,opt,name=key"`
+	xxx_hidden_Principal   string                 `protobuf:"bytes,2,opt,name=principal"`
+	xxx_hidden_Method      string                 `protobuf:"bytes,3,opt,name=method"`
+	xxx_hidden_Since       int64                  `protobuf:"varint,4,opt,name=since"`
+	xxx_hidden_Until       int64                  `protobuf:"varint,5,opt,name=until"`
+	xxx_hidden_AfterSeq    int64                  `protobuf:"varint,6,opt,name=after_seq,json=afterSeq"`
+	xxx_hidden_Limit       int64                  `protobuf:"varint,7,opt,name=limit"`
+	xxx_hidden_Namespace   *string                `protobuf:"bytes,8,opt,name=namespace"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *QueryAuditRequest) Reset() {
+	*x = QueryAuditRequest{}
+	mi := &file_admin_v1_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditRequest) ProtoMessage() {}
+
+func (x *QueryAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *QueryAuditRequest) GetKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_Key
+	}
+	return 0
+}
+
+func (x *QueryAuditRequest) GetPrincipal() string {
+	if x != nil {
+		return x.xxx_hidden_Principal
+	}
+	return ""
+}
+
+func (x *QueryAuditRequest) GetMethod() string {
+	if x != nil {
+		return x.xxx_hidden_Method
+	}
+	return ""
+}
+
+func (x *QueryAuditRequest) GetSince() int64 {
+	if x != nil {
+		return x.xxx_hidden_Since
+	}
+	return 0
+}
+
+func (x *QueryAuditRequest) GetUntil() int64 {
+	if x != nil {
+		return x.xxx_hidden_Until
+	}
+	return 0
+}
+
+func (x *QueryAuditRequest) GetAfterSeq() int64 {
+	if x != nil {
+		return x.xxx_hidden_AfterSeq
+	}
+	return 0
+}
+
+func (x *QueryAuditRequest) GetLimit() int64 {
+	if x != nil {
+		return x.xxx_hidden_Limit
+	}
+	return 0
+}
+
+func (x *QueryAuditRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *QueryAuditRequest) SetKey(v int64) {
+	x.xxx_hidden_Key = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
+}
+
+func (x *QueryAuditRequest) SetPrincipal(v string) {
+	x.xxx_hidden_Principal = v
+}
+
+func (x *QueryAuditRequest) SetMethod(v string) {
+	x.xxx_hidden_Method = v
+}
+
+func (x *QueryAuditRequest) SetSince(v int64) {
+	x.xxx_hidden_Since = v
+}
+
+func (x *QueryAuditRequest) SetUntil(v int64) {
+	x.xxx_hidden_Until = v
+}
+
+func (x *QueryAuditRequest) SetAfterSeq(v int64) {
+	x.xxx_hidden_AfterSeq = v
+}
+
+func (x *QueryAuditRequest) SetLimit(v int64) {
+	x.xxx_hidden_Limit = v
+}
+
+func (x *QueryAuditRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 8)
+}
+
+func (x *QueryAuditRequest) HasKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *QueryAuditRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *QueryAuditRequest) ClearKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Key = 0
+}
+
+func (x *QueryAuditRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Namespace = nil
+}
+
+type QueryAuditRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// key, if set, matches entries of this key.
+	Key       *int64
+	Principal string
+	Method    string
+	// since and until bound the entries' times in Unix nanoseconds,
+	// inclusive; zero leaves them unbounded.
+	Since int64
+	Until int64
+	// after_seq matches entries following this one, to page through
+	// results.
+	AfterSeq int64
+	// limit caps the number of entries returned; zero returns up to 1000.
+	Limit int64
+	// namespace, if set, matches entries of keys in this namespace.
+	Namespace *string
+}
+
+func (b0 QueryAuditRequest_builder) Build() *QueryAuditRequest {
+	m0 := &QueryAuditRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Key != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
+		x.xxx_hidden_Key = *b.Key
+	}
+	x.xxx_hidden_Principal = b.Principal
+	x.xxx_hidden_Method = b.Method
+	x.xxx_hidden_Since = b.Since
+	x.xxx_hidden_Until = b.Until
+	x.xxx_hidden_AfterSeq = b.AfterSeq
+	x.xxx_hidden_Limit = b.Limit
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 8)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	return m0
+}
+
+type QueryAuditResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Entries  *[]*AuditEntry         `protobuf:"bytes,1,rep,name=entries"`
+	xxx_hidden_HeadSeq  int64                  `protobuf:"varint,2,opt,name=head_seq,json=headSeq"`
+	xxx_hidden_HeadHash string                 `protobuf:"bytes,3,opt,name=head_hash,json=headHash"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *QueryAuditResponse) Reset() {
+	*x = QueryAuditResponse{}
+	mi := &file_admin_v1_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditResponse) ProtoMessage() {}
+
+func (x *QueryAuditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *QueryAuditResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		if x.xxx_hidden_Entries != nil {
+			return *x.xxx_hidden_Entries
+		}
+	}
+	return nil
+}
+
+func (x *QueryAuditResponse) GetHeadSeq() int64 {
+	if x != nil {
+		return x.xxx_hidden_HeadSeq
+	}
+	return 0
+}
+
+func (x *QueryAuditResponse) GetHeadHash() string {
+	if x != nil {
+		return x.xxx_hidden_HeadHash
+	}
+	return ""
+}
+
+func (x *QueryAuditResponse) SetEntries(v []*AuditEntry) {
+	x.xxx_hidden_Entries = &v
+}
+
+func (x *QueryAuditResponse) SetHeadSeq(v int64) {
+	x.xxx_hidden_HeadSeq = v
+}
+
+func (x *QueryAuditResponse) SetHeadHash(v string) {
+	x.xxx_hidden_HeadHash = v
+}
+
+type QueryAuditResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// entries are in log order.
+	Entries []*AuditEntry
+	// head_seq and head_hash identify the last entry of the log. Keeping
+	// them elsewhere makes removing entries from its end evident.
+	HeadSeq  int64
+	HeadHash string
+}
+
+func (b0 QueryAuditResponse_builder) Build() *QueryAuditResponse {
+	m0 := &QueryAuditResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Entries = &b.Entries
+	x.xxx_hidden_HeadSeq = b.HeadSeq
+	x.xxx_hidden_HeadHash = b.HeadHash
+	return m0
+}
+
+// Namespace is a partition of the key space with its own quota.
+type Namespace struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name      string                 `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_MaxKeys   int64                  `protobuf:"varint,2,opt,name=max_keys,json=maxKeys"`
+	xxx_hidden_MaxBytes  int64                  `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes"`
+	xxx_hidden_Keys      int64                  `protobuf:"varint,4,opt,name=keys"`
+	xxx_hidden_Bytes     int64                  `protobuf:"varint,5,opt,name=bytes"`
+	xxx_hidden_CreatedAt int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Namespace) Reset() {
+	*x = Namespace{}
+	mi := &file_admin_v1_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Namespace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Namespace) ProtoMessage() {}
+
+func (x *Namespace) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Namespace) GetName() string {
+	if x != nil {
+		return x.xxx_hidden_Name
+	}
+	return ""
+}
+
+func (x *Namespace) GetMaxKeys() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxKeys
+	}
+	return 0
+}
+
+func (x *Namespace) GetMaxBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxBytes
+	}
+	return 0
+}
+
+func (x *Namespace) GetKeys() int64 {
+	if x != nil {
+		return x.xxx_hidden_Keys
+	}
+	return 0
+}
+
+func (x *Namespace) GetBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_Bytes
+	}
+	return 0
+}
+
+func (x *Namespace) GetCreatedAt() int64 {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return 0
+}
+
+func (x *Namespace) SetName(v string) {
+	x.xxx_hidden_Name = v
+}
+
+func (x *Namespace) SetMaxKeys(v int64) {
+	x.xxx_hidden_MaxKeys = v
+}
+
+func (x *Namespace) SetMaxBytes(v int64) {
+	x.xxx_hidden_MaxBytes = v
+}
+
+func (x *Namespace) SetKeys(v int64) {
+	x.xxx_hidden_Keys = v
+}
+
+func (x *Namespace) SetBytes(v int64) {
+	x.xxx_hidden_Bytes = v
+}
+
+func (x *Namespace) SetCreatedAt(v int64) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+type Namespace_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// name is empty for the default namespace.
+	Name string
+	// max_keys and max_bytes limit the number of keys and the total size
+	// of their values; zero is unlimited.
+	MaxKeys  int64
+	MaxBytes int64
+	// keys and bytes are the namespace's current usage.
+	Keys  int64
+	Bytes int64
+	// Unix nanoseconds.
+	CreatedAt int64
+}
+
+func (b0 Namespace_builder) Build() *Namespace {
+	m0 := &Namespace{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Name = b.Name
+	x.xxx_hidden_MaxKeys = b.MaxKeys
+	x.xxx_hidden_MaxBytes = b.MaxBytes
+	x.xxx_hidden_Keys = b.Keys
+	x.xxx_hidden_Bytes = b.Bytes
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	return m0
+}
+
+type CreateNamespaceRequest struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name     string                 `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_MaxKeys  int64                  `protobuf:"varint,2,opt,name=max_keys,json=maxKeys"`
+	xxx_hidden_MaxBytes int64                  `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CreateNamespaceRequest) Reset() {
+	*x = CreateNamespaceRequest{}
+	mi := &file_admin_v1_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNamespaceRequest) ProtoMessage() {}
+
+func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.xxx_hidden_Name
+	}
+	return ""
+}
+
+func (x *CreateNamespaceRequest) GetMaxKeys() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxKeys
+	}
+	return 0
+}
+
+func (x *CreateNamespaceRequest) GetMaxBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxBytes
+	}
+	return 0
+}
+
+func (x *CreateNamespaceRequest) SetName(v string) {
+	x.xxx_hidden_Name = v
+}
+
+func (x *CreateNamespaceRequest) SetMaxKeys(v int64) {
+	x.xxx_hidden_MaxKeys = v
+}
+
+func (x *CreateNamespaceRequest) SetMaxBytes(v int64) {
+	x.xxx_hidden_MaxBytes = v
+}
+
+type CreateNamespaceRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// name has up to 64 lowercase letters, digits, '.', '_' and '-',
+	// starting with a letter or digit.
+	Name     string
+	MaxKeys  int64
+	MaxBytes int64
+}
+
+func (b0 CreateNamespaceRequest_builder) Build() *CreateNamespaceRequest {
+	m0 := &CreateNamespaceRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Name = b.Name
+	x.xxx_hidden_MaxKeys = b.MaxKeys
+	x.xxx_hidden_MaxBytes = b.MaxBytes
+	return m0
+}
+
+type CreateNamespaceResponse struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Namespace *Namespace             `protobuf:"bytes,1,opt,name=namespace"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CreateNamespaceResponse) Reset() {
+	*x = CreateNamespaceResponse{}
+	mi := &file_admin_v1_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNamespaceResponse) ProtoMessage() {}
+
+func (x *CreateNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateNamespaceResponse) GetNamespace() *Namespace {
+	if x != nil {
+		return x.xxx_hidden_Namespace
+	}
+	return nil
+}
+
+func (x *CreateNamespaceResponse) SetNamespace(v *Namespace) {
+	x.xxx_hidden_Namespace = v
+}
+
+func (x *CreateNamespaceResponse) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Namespace != nil
+}
+
+func (x *CreateNamespaceResponse) ClearNamespace() {
+	x.xxx_hidden_Namespace = nil
+}
+
+type CreateNamespaceResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Namespace *Namespace
+}
+
+func (b0 CreateNamespaceResponse_builder) Build() *CreateNamespaceResponse {
+	m0 := &CreateNamespaceResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Namespace = b.Namespace
+	return m0
+}
+
+type GetNamespaceRequest struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name string                 `protobuf:"bytes,1,opt,name=name"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetNamespaceRequest) Reset() {
+	*x = GetNamespaceRequest{}
+	mi := &file_admin_v1_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNamespaceRequest) ProtoMessage() {}
+
+func (x *GetNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.xxx_hidden_Name
+	}
+	return ""
+}
+
+func (x *GetNamespaceRequest) SetName(v string) {
+	x.xxx_hidden_Name = v
+}
+
+type GetNamespaceRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name string
+}
+
+func (b0 GetNamespaceRequest_builder) Build() *GetNamespaceRequest {
+	m0 := &GetNamespaceRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Name = b.Name
+	return m0
+}
+
+type GetNamespaceResponse struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Namespace *Namespace             `protobuf:"bytes,1,opt,name=namespace"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetNamespaceResponse) Reset() {
+	*x = GetNamespaceResponse{}
+	mi := &file_admin_v1_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNamespaceResponse) ProtoMessage() {}
+
+func (x *GetNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetNamespaceResponse) GetNamespace() *Namespace {
+	if x != nil {
+		return x.xxx_hidden_Namespace
+	}
+	return nil
+}
+
+func (x *GetNamespaceResponse) SetNamespace(v *Namespace) {
+	x.xxx_hidden_Namespace = v
+}
+
+func (x *GetNamespaceResponse) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Namespace != nil
+}
+
+func (x *GetNamespaceResponse) ClearNamespace() {
+	x.xxx_hidden_Namespace = nil
+}
+
+type GetNamespaceResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Namespace *Namespace
+}
+
+func (b0 GetNamespaceResponse_builder) Build() *GetNamespaceResponse {
+	m0 := &GetNamespaceResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Namespace = b.Namespace
+	return m0
+}
+
+type ListNamespacesRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesRequest) Reset() {
+	*x = ListNamespacesRequest{}
+	mi := &file_admin_v1_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesRequest) ProtoMessage() {}
+
+func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type ListNamespacesRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 ListNamespacesRequest_builder) Build() *ListNamespacesRequest {
+	m0 := &ListNamespacesRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type ListNamespacesResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Namespaces *[]*Namespace          `protobuf:"bytes,1,rep,name=namespaces"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ListNamespacesResponse) Reset() {
+	*x = ListNamespacesResponse{}
+	mi := &file_admin_v1_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesResponse) ProtoMessage() {}
+
+func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListNamespacesResponse) GetNamespaces() []*Namespace {
+	if x != nil {
+		if x.xxx_hidden_Namespaces != nil {
+			return *x.xxx_hidden_Namespaces
+		}
+	}
+	return nil
+}
+
+func (x *ListNamespacesResponse) SetNamespaces(v []*Namespace) {
+	x.xxx_hidden_Namespaces = &v
+}
+
+type ListNamespacesResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// namespaces are in name order, starting with the default one.
+	Namespaces []*Namespace
+}
+
+func (b0 ListNamespacesResponse_builder) Build() *ListNamespacesResponse {
+	m0 := &ListNamespacesResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Namespaces = &b.Namespaces
+	return m0
+}
+
+type UpdateNamespaceRequest struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name     string                 `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_MaxKeys  int64                  `protobuf:"varint,2,opt,name=max_keys,json=maxKeys"`
+	xxx_hidden_MaxBytes int64                  `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *UpdateNamespaceRequest) Reset() {
+	*x = UpdateNamespaceRequest{}
+	mi := &file_admin_v1_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNamespaceRequest) ProtoMessage() {}
+
+func (x *UpdateNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UpdateNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.xxx_hidden_Name
+	}
+	return ""
+}
+
+func (x *UpdateNamespaceRequest) GetMaxKeys() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxKeys
+	}
+	return 0
+}
+
+func (x *UpdateNamespaceRequest) GetMaxBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxBytes
+	}
+	return 0
+}
+
+func (x *UpdateNamespaceRequest) SetName(v string) {
+	x.xxx_hidden_Name = v
+}
+
+func (x *UpdateNamespaceRequest) SetMaxKeys(v int64) {
+	x.xxx_hidden_MaxKeys = v
+}
+
+func (x *UpdateNamespaceRequest) SetMaxBytes(v int64) {
+	x.xxx_hidden_MaxBytes = v
+}
+
+type UpdateNamespaceRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name string
+	// max_keys and max_bytes replace the namespace's quota.
+	MaxKeys  int64
+	MaxBytes int64
+}
+
+func (b0 UpdateNamespaceRequest_builder) Build() *UpdateNamespaceRequest {
+	m0 := &UpdateNamespaceRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Name = b.Name
+	x.xxx_hidden_MaxKeys = b.MaxKeys
+	x.xxx_hidden_MaxBytes = b.MaxBytes
+	return m0
+}
+
+type UpdateNamespaceResponse struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Namespace *Namespace             `protobuf:"bytes,1,opt,name=namespace"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UpdateNamespaceResponse) Reset() {
+	*x = UpdateNamespaceResponse{}
+	mi := &file_admin_v1_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNamespaceResponse) ProtoMessage() {}
+
+func (x *UpdateNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UpdateNamespaceResponse) GetNamespace() *Namespace {
+	if x != nil {
+		return x.xxx_hidden_Namespace
+	}
+	return nil
+}
+
+func (x *UpdateNamespaceResponse) SetNamespace(v *Namespace) {
+	x.xxx_hidden_Namespace = v
+}
+
+func (x *UpdateNamespaceResponse) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Namespace != nil
+}
+
+func (x *UpdateNamespaceResponse) ClearNamespace() {
+	x.xxx_hidden_Namespace = nil
+}
+
+type UpdateNamespaceResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Namespace *Namespace
+}
+
+func (b0 UpdateNamespaceResponse_builder) Build() *UpdateNamespaceResponse {
+	m0 := &UpdateNamespaceResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Namespace = b.Namespace
+	return m0
+}
+
+type DeleteNamespaceRequest struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name string                 `protobuf:"bytes,1,opt,name=name"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteNamespaceRequest) Reset() {
+	*x = DeleteNamespaceRequest{}
+	mi := &file_admin_v1_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNamespaceRequest) ProtoMessage() {}
+
+func (x *DeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.xxx_hidden_Name
+	}
+	return ""
+}
+
+func (x *DeleteNamespaceRequest) SetName(v string) {
+	x.xxx_hidden_Name = v
+}
+
+type DeleteNamespaceRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name string
+}
+
+func (b0 DeleteNamespaceRequest_builder) Build() *DeleteNamespaceRequest {
+	m0 := &DeleteNamespaceRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Name = b.Name
+	return m0
+}
+
+type DeleteNamespaceResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNamespaceResponse) Reset() {
+	*x = DeleteNamespaceResponse{}
+	mi := &file_admin_v1_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNamespaceResponse) ProtoMessage() {}
+
+func (x *DeleteNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type DeleteNamespaceResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 DeleteNamespaceResponse_builder) Build() *DeleteNamespaceResponse {
+	m0 := &DeleteNamespaceResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+var File_admin_v1_service_proto protoreflect.FileDescriptor
+
+const file_admin_v1_service_proto_rawDesc = "" +
+	"\n" +
+	"\x16admin/v1/service.proto\x12\badmin.v1\"\xe2\x01\n" +
+	"\n" +
+	"BackupInfo\x12\x19\n" +
+	"\x04path\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04path\x12\x1d\n" +
+	"\x06sha256\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\x06sha256\x12$\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03B\x05\xaa\x01\x02\b\x02R\tsizeBytes\x12,\n" +
+	"\x0eschema_version\x18\x04 \x01(\x04B\x05\xaa\x01\x02\b\x02R\rschemaVersion\x12 \n" +
+	"\blast_seq\x18\x05 \x01(\x03B\x05\xaa\x01\x02\b\x02R\alastSeq\x12$\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03B\x05\xaa\x01\x02\b\x02R\tcreatedAt\"*\n" +
+	"\rBackupRequest\x12\x19\n" +
+	"\x04path\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04path\":\n" +
+	"\x0eBackupResponse\x12(\n" +
+	"\x04info\x18\x01 \x01(\v2\x14.admin.v1.BackupInfoR\x04info\"+\n" +
+	"\x0eRestoreRequest\x12\x19\n" +
+	"\x04path\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04path\";\n" +
+	"\x0fRestoreResponse\x12(\n" +
+	"\x04info\x18\x01 \x01(\v2\x14.admin.v1.BackupInfoR\x04info\"\x9b\x03\n" +
+	"\n" +
+	"AuditEntry\x12\x17\n" +
+	"\x03seq\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03seq\x12\x19\n" +
+	"\x04time\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x04time\x12#\n" +
+	"\tprincipal\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x02R\tprincipal\x12\x19\n" +
+	"\x04peer\x18\x04 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04peer\x12\x1d\n" +
+	"\x06method\x18\x05 \x01(\tB\x05\xaa\x01\x02\b\x02R\x06method\x12\x17\n" +
+	"\x03key\x18\x06 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\x12 \n" +
+	"\bold_hash\x18\a \x01(\tB\x05\xaa\x01\x02\b\x02R\aoldHash\x12 \n" +
+	"\bnew_hash\x18\b \x01(\tB\x05\xaa\x01\x02\b\x02R\anewHash\x12 \n" +
+	"\btrace_id\x18\t \x01(\tB\x05\xaa\x01\x02\b\x02R\atraceId\x12\"\n" +
+	"\tprev_hash\x18\n" +
+	" \x01(\tB\x05\xaa\x01\x02\b\x02R\bprevHash\x12\x19\n" +
+	"\x04hash\x18\v \x01(\tB\x05\xaa\x01\x02\b\x02R\x04hash\x12#\n" +
+	"\tnamespace\x18\f \x01(\tB\x05\xaa\x01\x02\b\x02R\tnamespace\x12\x17\n" +
+	"\x03via\x18\r \x01(\tB\x05\xaa\x01\x02\b\x02R\x03via\"\x82\x02\n" +
+	"\x11QueryAuditRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12#\n" +
+	"\tprincipal\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\tprincipal\x12\x1d\n" +
+	"\x06method\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x02R\x06method\x12\x1b\n" +
+	"\x05since\x18\x04 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x05since\x12\x1b\n" +
+	"\x05until\x18\x05 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x05until\x12\"\n" +
+	"\tafter_seq\x18\x06 \x01(\x03B\x05\xaa\x01\x02\b\x02R\bafterSeq\x12\x1b\n" +
+	"\x05limit\x18\a \x01(\x03B\x05\xaa\x01\x02\b\x02R\x05limit\x12\x1c\n" +
+	"\tnamespace\x18\b \x01(\tR\tnamespace\"\x8a\x01\n" +
+	"\x12QueryAuditResponse\x12.\n" +
+	"\aentries\x18\x01 \x03(\v2\x14.admin.v1.AuditEntryR\aentries\x12 \n" +
+	"\bhead_seq\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\aheadSeq\x12\"\n" +
+	"\thead_hash\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x02R\bheadHash\"\xca\x01\n" +
+	"\tNamespace\x12\x19\n" +
+	"\x04name\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04name\x12 \n" +
+	"\bmax_keys\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\amaxKeys\x12\"\n" +
+	"\tmax_bytes\x18\x03 \x01(\x03B\x05\xaa\x01\x02\b\x02R\bmaxBytes\x12\x19\n" +
+	"\x04keys\x18\x04 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x04keys\x12\x1b\n" +
+	"\x05bytes\x18\x05 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x05bytes\x12$\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03B\x05\xaa\x01\x02\b\x02R\tcreatedAt\"y\n" +
+	"\x16CreateNamespaceRequest\x12\x19\n" +
+	"\x04name\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04name\x12 \n" +
+	"\bmax_keys\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\amaxKeys\x12\"\n" +
+	"\tmax_bytes\x18\x03 \x01(\x03B\x05\xaa\x01\x02\b\x02R\bmaxBytes\"L\n" +
+	"\x17CreateNamespaceResponse\x121\n" +
+	"\tnamespace\x18\x01 \x01(\v2\x13.admin.v1.NamespaceR\tnamespace\"0\n" +
+	"\x13GetNamespaceRequest\x12\x19\n" +
+	"\x04name\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04name\"I\n" +
+	"\x14GetNamespaceResponse\x121\n" +
+	"\tnamespace\x18\x01 \x01(\v2\x13.admin.v1.NamespaceR\tnamespace\"\x17\n" +
+	"\x15ListNamespacesRequest\"M\n" +
+	"\x16ListNamespacesResponse\x123\n" +
+	"\n" +
+	"namespaces\x18\x01 \x03(\v2\x13.admin.v1.NamespaceR\n" +
+	"namespaces\"y\n" +
+	"\x16UpdateNamespaceRequest\x12\x19\n" +
+	"\x04name\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04name\x12 \n" +
+	"\bmax_keys\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\amaxKeys\x12\"\n" +
+	"\tmax_bytes\x18\x03 \x01(\x03B\x05\xaa\x01\x02\b\x02R\bmaxBytes\"L\n" +
+	"\x17UpdateNamespaceResponse\x121\n" +
+	"\tnamespace\x18\x01 \x01(\v2\x13.admin.v1.NamespaceR\tnamespace\"3\n" +
+	"\x16DeleteNamespaceRequest\x12\x19\n" +
+	"\x04name\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04name\"\x19\n" +
+	"\x17DeleteNamespaceResponse2\x8b\x01\n" +
+	"\fAdminService\x12;\n" +
+	"\x06Backup\x12\x17.admin.v1.BackupRequest\x1a\x18.admin.v1.BackupResponse\x12>\n" +
+	"\aRestore\x12\x18.admin.v1.RestoreRequest\x1a\x19.admin.v1.RestoreResponse2W\n" +
+	"\fAuditService\x12G\n" +
+	"\n" +
+	"QueryAudit\x12\x1b.admin.v1.QueryAuditRequest\x1a\x1c.admin.v1.QueryAuditResponse2\xbe\x03\n" +
+	"\x10NamespaceService\x12V\n" +
+	"\x0fCreateNamespace\x12 .admin.v1.CreateNamespaceRequest\x1a!.admin.v1.CreateNamespaceResponse\x12M\n" +
+	"\fGetNamespace\x12\x1d.admin.v1.GetNamespaceRequest\x1a\x1e.admin.v1.GetNamespaceResponse\x12S\n" +
+	"\x0eListNamespaces\x12\x1f.admin.v1.ListNamespacesRequest\x1a .admin.v1.ListNamespacesResponse\x12V\n" +
+	"\x0fUpdateNamespace\x12 .admin.v1.UpdateNamespaceRequest\x1a!.admin.v1.UpdateNamespaceResponse\x12V\n" +
+	"\x0fDeleteNamespace\x12 .admin.v1.DeleteNamespaceRequest\x1a!.admin.v1.DeleteNamespaceResponseB)Z'github.com/dynoinc/gh-go/proto/admin/v1b\beditionsp\xe8\a"
+
+var file_admin_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_admin_v1_service_proto_goTypes = []any{
+	(*BackupInfo)(nil),              // 0: admin.v1.BackupInfo
+	(*BackupRequest)(nil),           // 1: admin.v1.BackupRequest
+	(*BackupResponse)(nil),          // 2: admin.v1.BackupResponse
+	(*RestoreRequest)(nil),          // 3: admin.v1.RestoreRequest
+	(*RestoreResponse)(nil),         // 4: admin.v1.RestoreResponse
+	(*AuditEntry)(nil),              // 5: admin.v1.AuditEntry
+	(*QueryAuditRequest)(nil),       // 6: admin.v1.QueryAuditRequest
+	(*QueryAuditResponse)(nil),      // 7: admin.v1.QueryAuditResponse
+	(*Namespace)(nil),               // 8: admin.v1.Namespace
+	(*CreateNamespaceRequest)(nil),  // 9: admin.v1.CreateNamespaceRequest
+	(*CreateNamespaceResponse)(nil), // 10: admin.v1.CreateNamespaceResponse
+	(*GetNamespaceRequest)(nil),     // 11: admin.v1.GetNamespaceRequest
+	(*GetNamespaceResponse)(nil),    // 12: admin.v1.GetNamespaceResponse
+	(*ListNamespacesRequest)(nil),   // 13: admin.v1.ListNamespacesRequest
+	(*ListNamespacesResponse)(nil),  // 14: admin.v1.ListNamespacesResponse
+	(*UpdateNamespaceRequest)(nil),  // 15: admin.v1.UpdateNamespaceRequest
+	(*UpdateNamespaceResponse)(nil), // 16: admin.v1.UpdateNamespaceResponse
+	(*DeleteNamespaceRequest)(nil),  // 17: admin.v1.DeleteNamespaceRequest
+	(*DeleteNamespaceResponse)(nil), // 18: admin.v1.DeleteNamespaceResponse
+}
+var file_admin_v1_service_proto_depIdxs = []int32{
+	0,  // 0: admin.v1.BackupResponse.info:type_name -> admin.v1.BackupInfo
+	0,  // 1: admin.v1.RestoreResponse.info:type_name -> admin.v1.BackupInfo
+	5,  // 2: admin.v1.QueryAuditResponse.entries:type_name -> admin.v1.AuditEntry
+	8,  // 3: admin.v1.CreateNamespaceResponse.namespace:type_name -> admin.v1.Namespace
+	8,  // 4: admin.v1.GetNamespaceResponse.namespace:type_name -> admin.v1.Namespace
+	8,  // 5: admin.v1.ListNamespacesResponse.namespaces:type_name -> admin.v1.Namespace
+	8,  // 6: admin.v1.UpdateNamespaceResponse.namespace:type_name -> admin.v1.Namespace
+	1,  // 7: admin.v1.AdminService.Backup:input_type -> admin.v1.BackupRequest
+	3,  // 8: admin.v1.AdminService.Restore:input_type -> admin.v1.RestoreRequest
+	6,  // 9: admin.v1.AuditService.QueryAudit:input_type -> admin.v1.QueryAuditRequest
+	9,  // 10: admin.v1.NamespaceService.CreateNamespace:input_type -> admin.v1.CreateNamespaceRequest
+	11, // 11: admin.v1.NamespaceService.GetNamespace:input_type -> admin.v1.GetNamespaceRequest
+	13, // 12: admin.v1.NamespaceService.ListNamespaces:input_type -> admin.v1.ListNamespacesRequest
+	15, // 13: admin.v1.NamespaceService.UpdateNamespace:input_type -> admin.v1.UpdateNamespaceRequest
+	17, // 14: admin.v1.NamespaceService.DeleteNamespace:input_type -> admin.v1.DeleteNamespaceRequest
+	2,  // 15: admin.v1.AdminService.Backup:output_type -> admin.v1.BackupResponse
+	4,  // 16: admin.v1.AdminService.Restore:output_type -> admin.v1.RestoreResponse
+	7,  // 17: admin.v1.AuditService.QueryAudit:output_type -> admin.v1.QueryAuditResponse
+	10, // 18: admin.v1.NamespaceService.CreateNamespace:output_type -> admin.v1.CreateNamespaceResponse
+	12, // 19: admin.v1.NamespaceService.GetNamespace:output_type -> admin.v1.GetNamespaceResponse
+	14, // 20: admin.v1.NamespaceService.ListNamespaces:output_type -> admin.v1.ListNamespacesResponse
+	16, // 21: admin.v1.NamespaceService.UpdateNamespace:output_type -> admin.v1.UpdateNamespaceResponse
+	18, // 22: admin.v1.NamespaceService.DeleteNamespace:output_type -> admin.v1.DeleteNamespaceResponse
+	15, // [15:23] is the sub-list for method output_type
+	7,  // [7:15] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_admin_v1_service_proto_init() }
+func file_admin_v1_service_proto_init() {
+	if File_admin_v1_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_v1_service_proto_rawDesc), len(file_admin_v1_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_admin_v1_service_proto_goTypes,
+		DependencyIndexes: file_admin_v1_service_proto_depIdxs,
+		MessageInfos:      file_admin_v1_service_proto_msgTypes,
+	}.Build()
+	File_admin_v1_service_proto = out.File
+	file_admin_v1_service_proto_goTypes = nil
+	file_admin_v1_service_proto_depIdxs = nil
+}
@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: cdc/v1/service.proto
+
+package v1
+
+import (
+	reflect "reflect"
+	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Change is a committed write, as numbered by the change log.
+type Change struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Seq         int64                  `protobuf:"varint,1,opt,name=seq"`
+	xxx_hidden_Key         int64                  `protobuf:"varint,2,opt,name=key"`
+	xxx_hidden_Value       string                 `protobuf:"bytes,3,opt,name=value"`
+	xxx_hidden_Deleted     bool                   `protobuf:"varint,4,opt,name=deleted"`
+	xxx_hidden_CommittedAt int64                  `protobuf:"varint,5,opt,name=committed_at,json=committedAt"`
+	xxx_hidden_Namespace   string                 `protobuf:"bytes,6,opt,name=namespace"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Change) Reset() {
+	*x = Change{}
+	mi := &file_cdc_v1_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Change) ProtoMessage() {}
+
+func (x *Change) ProtoReflect() protoreflect.Message {
+	mi := &file_cdc_v1_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Change) GetSeq() int64 {
+	if x != nil {
+		return x.xxx_hidden_Seq
+	}
+	return 0
+}
+
+func (x *Change) GetKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_Key
+	}
+	return 0
+}
+
+func (x *Change) GetValue() string {
+	if x != nil {
+		return x.xxx_hidden_Value
+	}
+	return ""
+}
+
+func (x *Change) GetDeleted() bool {
+	if x != nil {
+		return x.xxx_hidden_Deleted
+	}
+	return false
+}
+
+func (x *Change) GetCommittedAt() int64 {
+	if x != nil {
+		return x.xxx_hidden_CommittedAt
+	}
+	return 0
+}
+
+func (x *Change) GetNamespace() string {
+	if x != nil {
+		return x.xxx_hidden_Namespace
+	}
+	return ""
+}
+
+func (x *Change) SetSeq(v int64) {
+	x.xxx_hidden_Seq = v
+}
+
+func (x *Change) SetKey(v int64) {
+	x.xxx_hidden_Key = v
+}
+
+func (x *Change) SetValue(v string) {
+	x.xxx_hidden_Value = v
+}
+
+func (x *Change) SetDeleted(v bool) {
+	x.xxx_hidden_Deleted = v
+}
+
+func (x *Change) SetCommittedAt(v int64) {
+	x.xxx_hidden_CommittedAt = v
+}
+
+func (x *Change) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = v
+}
+
+type Change_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Seq     int64
+	Key     int64
+	Value   string
+	Deleted bool
+	// Unix nanoseconds.
+	CommittedAt int64
+	// Empty for the default namespace.
+	Namespace string
+}
+
+func (b0 Change_builder) Build() *Change {
+	m0 := &Change{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Seq = b.Seq
+	x.xxx_hidden_Key = b.Key
+	x.xxx_hidden_Value = b.Value
+	x.xxx_hidden_Deleted = b.Deleted
+	x.xxx_hidden_CommittedAt = b.CommittedAt
+	x.xxx_hidden_Namespace = b.Namespace
+	return m0
+}
+
+type DeliverRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Changes *[]*Change             `protobuf:"bytes,1,rep,name=changes"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DeliverRequest) Reset() {
+	*x = DeliverRequest{}
+	mi := &file_cdc_v1_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliverRequest) ProtoMessage() {}
+
+func (x *DeliverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cdc_v1_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeliverRequest) GetChanges() []*Change {
+	if x != nil {
+		if x.xxx_hidden_Changes != nil {
+			return *x.xxx_hidden_Changes
+		}
+	}
+	return nil
+}
+
+func (x *DeliverRequest) SetChanges(v []*Change) {
+	x.xxx_hidden_Changes = &v
+}
+
+type DeliverRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Changes in log order. A batch that wasn't acknowledged is delivered
+	// again, so receivers should skip sequence numbers they've seen.
+	Changes []*Change
+}
+
+func (b0 DeliverRequest_builder) Build() *DeliverRequest {
+	m0 := &DeliverRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Changes = &b.Changes
+	return m0
+}
+
+type DeliverResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliverResponse) Reset() {
+	*x = DeliverResponse{}
+	mi := &file_cdc_v1_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliverResponse) ProtoMessage() {}
+
+func (x *DeliverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cdc_v1_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type DeliverResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 DeliverResponse_builder) Build() *DeliverResponse {
+	m0 := &DeliverResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+var File_cdc_v1_service_proto protoreflect.FileDescriptor
+
+const file_cdc_v1_service_proto_rawDesc = "" +
+	"\n" +
+	"\x14cdc/v1/service.proto\x12\x06cdc.v1\"\xc7\x01\n" +
+	"\x06Change\x12\x17\n" +
+	"\x03seq\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03seq\x12\x17\n" +
+	"\x03key\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\x12\x1b\n" +
+	"\x05value\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x02R\x05value\x12\x1f\n" +
+	"\adeleted\x18\x04 \x01(\bB\x05\xaa\x01\x02\b\x02R\adeleted\x12(\n" +
+	"\fcommitted_at\x18\x05 \x01(\x03B\x05\xaa\x01\x02\b\x02R\vcommittedAt\x12#\n" +
+	"\tnamespace\x18\x06 \x01(\tB\x05\xaa\x01\x02\b\x02R\tnamespace\":\n" +
+	"\x0eDeliverRequest\x12(\n" +
+	"\achanges\x18\x01 \x03(\v2\x0e.cdc.v1.ChangeR\achanges\"\x11\n" +
+	"\x0fDeliverResponse2O\n" +
+	"\x11ChangeSinkService\x12:\n" +
+	"\aDeliver\x12\x16.cdc.v1.DeliverRequest\x1a\x17.cdc.v1.DeliverResponseB'Z%github.com/dynoinc/gh-go/proto/cdc/v1b\beditionsp\xe8\a"
+
+var file_cdc_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_cdc_v1_service_proto_goTypes = []any{
+	(*Change)(nil),          // 0: cdc.v1.Change
+	(*DeliverRequest)(nil),  // 1: cdc.v1.DeliverRequest
+	(*DeliverResponse)(nil), // 2: cdc.v1.DeliverResponse
+}
+var file_cdc_v1_service_proto_depIdxs = []int32{
+	0, // 0: cdc.v1.DeliverRequest.changes:type_name -> cdc.v1.Change
+	1, // 1: cdc.v1.ChangeSinkService.Deliver:input_type -> cdc.v1.DeliverRequest
+	2, // 2: cdc.v1.ChangeSinkService.Deliver:output_type -> cdc.v1.DeliverResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_cdc_v1_service_proto_init() }
+func file_cdc_v1_service_proto_init() {
+	if File_cdc_v1_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cdc_v1_service_proto_rawDesc), len(file_cdc_v1_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cdc_v1_service_proto_goTypes,
+		DependencyIndexes: file_cdc_v1_service_proto_depIdxs,
+		MessageInfos:      file_cdc_v1_service_proto_msgTypes,
+	}.Build()
+	File_cdc_v1_service_proto = out.File
+	file_cdc_v1_service_proto_goTypes = nil
+	file_cdc_v1_service_proto_depIdxs = nil
+}
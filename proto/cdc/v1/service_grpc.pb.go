@@ -0,0 +1,130 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: cdc/v1/service.proto
+
+package v1
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ChangeSinkService_Deliver_FullMethodName = "/cdc.v1.ChangeSinkService/Deliver"
+)
+
+// ChangeSinkServiceClient is the client API for ChangeSinkService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ChangeSinkService is implemented by endpoints receiving changes captured by
+// a server's CDC pipeline.
+type ChangeSinkServiceClient interface {
+	// Deliver acknowledges a batch of changes once it returns OK.
+	Deliver(ctx context.Context, in *DeliverRequest, opts ...grpc.CallOption) (*DeliverResponse, error)
+}
+
+type changeSinkServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChangeSinkServiceClient(cc grpc.ClientConnInterface) ChangeSinkServiceClient {
+	return &changeSinkServiceClient{cc}
+}
+
+func (c *changeSinkServiceClient) Deliver(ctx context.Context, in *DeliverRequest, opts ...grpc.CallOption) (*DeliverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeliverResponse)
+	err := c.cc.Invoke(ctx, ChangeSinkService_Deliver_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChangeSinkServiceServer is the server API for ChangeSinkService service.
+// All implementations must embed UnimplementedChangeSinkServiceServer
+// for forward compatibility.
+//
+// ChangeSinkService is implemented by endpoints receiving changes captured by
+// a server's CDC pipeline.
+type ChangeSinkServiceServer interface {
+	// Deliver acknowledges a batch of changes once it returns OK.
+	Deliver(context.Context, *DeliverRequest) (*DeliverResponse, error)
+	mustEmbedUnimplementedChangeSinkServiceServer()
+}
+
+// UnimplementedChangeSinkServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChangeSinkServiceServer struct{}
+
+func (UnimplementedChangeSinkServiceServer) Deliver(context.Context, *DeliverRequest) (*DeliverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deliver not implemented")
+}
+func (UnimplementedChangeSinkServiceServer) mustEmbedUnimplementedChangeSinkServiceServer() {}
+func (UnimplementedChangeSinkServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeChangeSinkServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChangeSinkServiceServer will
+// result in compilation errors.
+type UnsafeChangeSinkServiceServer interface {
+	mustEmbedUnimplementedChangeSinkServiceServer()
+}
+
+func RegisterChangeSinkServiceServer(s grpc.ServiceRegistrar, srv ChangeSinkServiceServer) {
+	// If the following call pancis, it indicates UnimplementedChangeSinkServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChangeSinkService_ServiceDesc, srv)
+}
+
+func _ChangeSinkService_Deliver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChangeSinkServiceServer).Deliver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChangeSinkService_Deliver_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChangeSinkServiceServer).Deliver(ctx, req.(*DeliverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ChangeSinkService_ServiceDesc is the grpc.ServiceDesc for ChangeSinkService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChangeSinkService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cdc.v1.ChangeSinkService",
+	HandlerType: (*ChangeSinkServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Deliver",
+			Handler:    _ChangeSinkService_Deliver_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cdc/v1/service.proto",
+}
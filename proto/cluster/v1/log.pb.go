@@ -0,0 +1,1088 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: cluster/v1/log.proto
+
+package v1
+
+import (
+	reflect "reflect"
+	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Entry struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Key       int64                  `protobuf:"varint,1,opt,name=key"`
+	xxx_hidden_Value     string                 `protobuf:"bytes,2,opt,name=value"`
+	xxx_hidden_Namespace string                 `protobuf:"bytes,3,opt,name=namespace"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Entry) Reset() {
+	*x = Entry{}
+	mi := &file_cluster_v1_log_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entry) ProtoMessage() {}
+
+func (x *Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_log_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Entry) GetKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_Key
+	}
+	return 0
+}
+
+func (x *Entry) GetValue() string {
+	if x != nil {
+		return x.xxx_hidden_Value
+	}
+	return ""
+}
+
+func (x *Entry) GetNamespace() string {
+	if x != nil {
+		return x.xxx_hidden_Namespace
+	}
+	return ""
+}
+
+func (x *Entry) SetKey(v int64) {
+	x.xxx_hidden_Key = v
+}
+
+func (x *Entry) SetValue(v string) {
+	x.xxx_hidden_Value = v
+}
+
+func (x *Entry) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = v
+}
+
+type Entry_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Key   int64
+	Value string
+	// The namespace of snapshot entries, empty for the default namespace.
+	Namespace string
+}
+
+func (b0 Entry_builder) Build() *Entry {
+	m0 := &Entry{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Key = b.Key
+	x.xxx_hidden_Value = b.Value
+	x.xxx_hidden_Namespace = b.Namespace
+	return m0
+}
+
+// Command is a write committed through the Raft log and applied by every node.
+type Command struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Op isCommand_Op           `protobuf_oneof:"op"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Command) Reset() {
+	*x = Command{}
+	mi := &file_cluster_v1_log_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Command) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Command) ProtoMessage() {}
+
+func (x *Command) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_log_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Command) GetBatchPut() *BatchPut {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Op.(*command_BatchPut); ok {
+			return x.BatchPut
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetDelete() *Delete {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Op.(*command_Delete); ok {
+			return x.Delete
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetCreateNamespace() *CreateNamespace {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Op.(*command_CreateNamespace); ok {
+			return x.CreateNamespace
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetSetQuota() *SetQuota {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Op.(*command_SetQuota); ok {
+			return x.SetQuota
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetDeleteNamespace() *DeleteNamespace {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Op.(*command_DeleteNamespace); ok {
+			return x.DeleteNamespace
+		}
+	}
+	return nil
+}
+
+func (x *Command) SetBatchPut(v *BatchPut) {
+	if v == nil {
+		x.xxx_hidden_Op = nil
+		return
+	}
+	x.xxx_hidden_Op = &command_BatchPut{v}
+}
+
+func (x *Command) SetDelete(v *Delete) {
+	if v == nil {
+		x.xxx_hidden_Op = nil
+		return
+	}
+	x.xxx_hidden_Op = &command_Delete{v}
+}
+
+func (x *Command) SetCreateNamespace(v *CreateNamespace) {
+	if v == nil {
+		x.xxx_hidden_Op = nil
+		return
+	}
+	x.xxx_hidden_Op = &command_CreateNamespace{v}
+}
+
+func (x *Command) SetSetQuota(v *SetQuota) {
+	if v == nil {
+		x.xxx_hidden_Op = nil
+		return
+	}
+	x.xxx_hidden_Op = &command_SetQuota{v}
+}
+
+func (x *Command) SetDeleteNamespace(v *DeleteNamespace) {
+	if v == nil {
+		x.xxx_hidden_Op = nil
+		return
+	}
+	x.xxx_hidden_Op = &command_DeleteNamespace{v}
+}
+
+func (x *Command) HasOp() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Op != nil
+}
+
+func (x *Command) HasBatchPut() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Op.(*command_BatchPut)
+	return ok
+}
+
+func (x *Command) HasDelete() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Op.(*command_Delete)
+	return ok
+}
+
+func (x *Command) HasCreateNamespace() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Op.(*command_CreateNamespace)
+	return ok
+}
+
+func (x *Command) HasSetQuota() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Op.(*command_SetQuota)
+	return ok
+}
+
+func (x *Command) HasDeleteNamespace() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Op.(*command_DeleteNamespace)
+	return ok
+}
+
+func (x *Command) ClearOp() {
+	x.xxx_hidden_Op = nil
+}
+
+func (x *Command) ClearBatchPut() {
+	if _, ok := x.xxx_hidden_Op.(*command_BatchPut); ok {
+		x.xxx_hidden_Op = nil
+	}
+}
+
+func (x *Command) ClearDelete() {
+	if _, ok := x.xxx_hidden_Op.(*command_Delete); ok {
+		x.xxx_hidden_Op = nil
+	}
+}
+
+func (x *Command) ClearCreateNamespace() {
+	if _, ok := x.xxx_hidden_Op.(*command_CreateNamespace); ok {
+		x.xxx_hidden_Op = nil
+	}
+}
+
+func (x *Command) ClearSetQuota() {
+	if _, ok := x.xxx_hidden_Op.(*command_SetQuota); ok {
+		x.xxx_hidden_Op = nil
+	}
+}
+
+func (x *Command) ClearDeleteNamespace() {
+	if _, ok := x.xxx_hidden_Op.(*command_DeleteNamespace); ok {
+		x.xxx_hidden_Op = nil
+	}
+}
+
+const Command_Op_not_set_case case_Command_Op = 0
+const Command_BatchPut_case case_Command_Op = 1
+const Command_Delete_case case_Command_Op = 2
+const Command_CreateNamespace_case case_Command_Op = 3
+const Command_SetQuota_case case_Command_Op = 4
+const Command_DeleteNamespace_case case_Command_Op = 5
+
+func (x *Command) WhichOp() case_Command_Op {
+	if x == nil {
+		return Command_Op_not_set_case
+	}
+	switch x.xxx_hidden_Op.(type) {
+	case *command_BatchPut:
+		return Command_BatchPut_case
+	case *command_Delete:
+		return Command_Delete_case
+	case *command_CreateNamespace:
+		return Command_CreateNamespace_case
+	case *command_SetQuota:
+		return Command_SetQuota_case
+	case *command_DeleteNamespace:
+		return Command_DeleteNamespace_case
+	default:
+		return Command_Op_not_set_case
+	}
+}
+
+type Command_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Fields of oneof xxx_hidden_Op:
+	BatchPut        *BatchPut
+	Delete          *Delete
+	CreateNamespace *CreateNamespace
+	SetQuota        *SetQuota
+	DeleteNamespace *DeleteNamespace
+	// -- end of xxx_hidden_Op
+}
+
+func (b0 Command_builder) Build() *Command {
+	m0 := &Command{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.BatchPut != nil {
+		x.xxx_hidden_Op = &command_BatchPut{b.BatchPut}
+	}
+	if b.Delete != nil {
+		x.xxx_hidden_Op = &command_Delete{b.Delete}
+	}
+	if b.CreateNamespace != nil {
+		x.xxx_hidden_Op = &command_CreateNamespace{b.CreateNamespace}
+	}
+	if b.SetQuota != nil {
+		x.xxx_hidden_Op = &command_SetQuota{b.SetQuota}
+	}
+	if b.DeleteNamespace != nil {
+		x.xxx_hidden_Op = &command_DeleteNamespace{b.DeleteNamespace}
+	}
+	return m0
+}
+
+type case_Command_Op protoreflect.FieldNumber
+
+func (x case_Command_Op) String() string {
+	md := file_cluster_v1_log_proto_msgTypes[1].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isCommand_Op interface {
+	isCommand_Op()
+}
+
+type command_BatchPut struct {
+	BatchPut *BatchPut `protobuf:"bytes,1,opt,name=batch_put,json=batchPut,oneof"`
+}
+
+type command_Delete struct {
+	Delete *Delete `protobuf:"bytes,2,opt,name=delete,oneof"`
+}
+
+type command_CreateNamespace struct {
+	CreateNamespace *CreateNamespace `protobuf:"bytes,3,opt,name=create_namespace,json=createNamespace,oneof"`
+}
+
+type command_SetQuota struct {
+	SetQuota *SetQuota `protobuf:"bytes,4,opt,name=set_quota,json=setQuota,oneof"`
+}
+
+type command_DeleteNamespace struct {
+	DeleteNamespace *DeleteNamespace `protobuf:"bytes,5,opt,name=delete_namespace,json=deleteNamespace,oneof"`
+}
+
+func (*command_BatchPut) isCommand_Op() {}
+
+func (*command_Delete) isCommand_Op() {}
+
+func (*command_CreateNamespace) isCommand_Op() {}
+
+func (*command_SetQuota) isCommand_Op() {}
+
+func (*command_DeleteNamespace) isCommand_Op() {}
+
+// BatchPut stores all entries atomically, in order.
+type BatchPut struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Entries   *[]*Entry              `protobuf:"bytes,1,rep,name=entries"`
+	xxx_hidden_Namespace string                 `protobuf:"bytes,2,opt,name=namespace"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *BatchPut) Reset() {
+	*x = BatchPut{}
+	mi := &file_cluster_v1_log_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPut) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPut) ProtoMessage() {}
+
+func (x *BatchPut) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_log_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchPut) GetEntries() []*Entry {
+	if x != nil {
+		if x.xxx_hidden_Entries != nil {
+			return *x.xxx_hidden_Entries
+		}
+	}
+	return nil
+}
+
+func (x *BatchPut) GetNamespace() string {
+	if x != nil {
+		return x.xxx_hidden_Namespace
+	}
+	return ""
+}
+
+func (x *BatchPut) SetEntries(v []*Entry) {
+	x.xxx_hidden_Entries = &v
+}
+
+func (x *BatchPut) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = v
+}
+
+type BatchPut_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Entries []*Entry
+	// Empty for the default namespace.
+	Namespace string
+}
+
+func (b0 BatchPut_builder) Build() *BatchPut {
+	m0 := &BatchPut{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Entries = &b.Entries
+	x.xxx_hidden_Namespace = b.Namespace
+	return m0
+}
+
+type Delete struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Key       int64                  `protobuf:"varint,1,opt,name=key"`
+	xxx_hidden_Namespace string                 `protobuf:"bytes,2,opt,name=namespace"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Delete) Reset() {
+	*x = Delete{}
+	mi := &file_cluster_v1_log_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Delete) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Delete) ProtoMessage() {}
+
+func (x *Delete) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_log_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Delete) GetKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_Key
+	}
+	return 0
+}
+
+func (x *Delete) GetNamespace() string {
+	if x != nil {
+		return x.xxx_hidden_Namespace
+	}
+	return ""
+}
+
+func (x *Delete) SetKey(v int64) {
+	x.xxx_hidden_Key = v
+}
+
+func (x *Delete) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = v
+}
+
+type Delete_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Key int64
+	// Empty for the default namespace.
+	Namespace string
+}
+
+func (b0 Delete_builder) Build() *Delete {
+	m0 := &Delete{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Key = b.Key
+	x.xxx_hidden_Namespace = b.Namespace
+	return m0
+}
+
+// Quota limits a namespace. Zero limits are unlimited.
+type Quota struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MaxKeys  int64                  `protobuf:"varint,1,opt,name=max_keys,json=maxKeys"`
+	xxx_hidden_MaxBytes int64                  `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Quota) Reset() {
+	*x = Quota{}
+	mi := &file_cluster_v1_log_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quota) ProtoMessage() {}
+
+func (x *Quota) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_log_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Quota) GetMaxKeys() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxKeys
+	}
+	return 0
+}
+
+func (x *Quota) GetMaxBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxBytes
+	}
+	return 0
+}
+
+func (x *Quota) SetMaxKeys(v int64) {
+	x.xxx_hidden_MaxKeys = v
+}
+
+func (x *Quota) SetMaxBytes(v int64) {
+	x.xxx_hidden_MaxBytes = v
+}
+
+type Quota_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	MaxKeys  int64
+	MaxBytes int64
+}
+
+func (b0 Quota_builder) Build() *Quota {
+	m0 := &Quota{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MaxKeys = b.MaxKeys
+	x.xxx_hidden_MaxBytes = b.MaxBytes
+	return m0
+}
+
+type CreateNamespace struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name  string                 `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Quota *Quota                 `protobuf:"bytes,2,opt,name=quota"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateNamespace) Reset() {
+	*x = CreateNamespace{}
+	mi := &file_cluster_v1_log_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNamespace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNamespace) ProtoMessage() {}
+
+func (x *CreateNamespace) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_log_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateNamespace) GetName() string {
+	if x != nil {
+		return x.xxx_hidden_Name
+	}
+	return ""
+}
+
+func (x *CreateNamespace) GetQuota() *Quota {
+	if x != nil {
+		return x.xxx_hidden_Quota
+	}
+	return nil
+}
+
+func (x *CreateNamespace) SetName(v string) {
+	x.xxx_hidden_Name = v
+}
+
+func (x *CreateNamespace) SetQuota(v *Quota) {
+	x.xxx_hidden_Quota = v
+}
+
+func (x *CreateNamespace) HasQuota() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Quota != nil
+}
+
+func (x *CreateNamespace) ClearQuota() {
+	x.xxx_hidden_Quota = nil
+}
+
+type CreateNamespace_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name  string
+	Quota *Quota
+}
+
+func (b0 CreateNamespace_builder) Build() *CreateNamespace {
+	m0 := &CreateNamespace{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Name = b.Name
+	x.xxx_hidden_Quota = b.Quota
+	return m0
+}
+
+type SetQuota struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name  string                 `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Quota *Quota                 `protobuf:"bytes,2,opt,name=quota"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetQuota) Reset() {
+	*x = SetQuota{}
+	mi := &file_cluster_v1_log_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetQuota) ProtoMessage() {}
+
+func (x *SetQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_log_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SetQuota) GetName() string {
+	if x != nil {
+		return x.xxx_hidden_Name
+	}
+	return ""
+}
+
+func (x *SetQuota) GetQuota() *Quota {
+	if x != nil {
+		return x.xxx_hidden_Quota
+	}
+	return nil
+}
+
+func (x *SetQuota) SetName(v string) {
+	x.xxx_hidden_Name = v
+}
+
+func (x *SetQuota) SetQuota(v *Quota) {
+	x.xxx_hidden_Quota = v
+}
+
+func (x *SetQuota) HasQuota() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Quota != nil
+}
+
+func (x *SetQuota) ClearQuota() {
+	x.xxx_hidden_Quota = nil
+}
+
+type SetQuota_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name  string
+	Quota *Quota
+}
+
+func (b0 SetQuota_builder) Build() *SetQuota {
+	m0 := &SetQuota{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Name = b.Name
+	x.xxx_hidden_Quota = b.Quota
+	return m0
+}
+
+// DeleteNamespace deletes an empty namespace.
+type DeleteNamespace struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name string                 `protobuf:"bytes,1,opt,name=name"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteNamespace) Reset() {
+	*x = DeleteNamespace{}
+	mi := &file_cluster_v1_log_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNamespace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNamespace) ProtoMessage() {}
+
+func (x *DeleteNamespace) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_log_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteNamespace) GetName() string {
+	if x != nil {
+		return x.xxx_hidden_Name
+	}
+	return ""
+}
+
+func (x *DeleteNamespace) SetName(v string) {
+	x.xxx_hidden_Name = v
+}
+
+type DeleteNamespace_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name string
+}
+
+func (b0 DeleteNamespace_builder) Build() *DeleteNamespace {
+	m0 := &DeleteNamespace{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Name = b.Name
+	return m0
+}
+
+// Snapshot is the full state of a node, replacing the Raft log up to it.
+type Snapshot struct {
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_AppliedIndex     uint64                 `protobuf:"varint,1,opt,name=applied_index,json=appliedIndex"`
+	xxx_hidden_Entries          *[]*Entry              `protobuf:"bytes,2,rep,name=entries"`
+	xxx_hidden_Namespaces       *[]*CreateNamespace    `protobuf:"bytes,3,rep,name=namespaces"`
+	xxx_hidden_Database         []byte                 `protobuf:"bytes,4,opt,name=database"`
+	xxx_hidden_DatabaseMetadata []byte                 `protobuf:"bytes,5,opt,name=database_metadata,json=databaseMetadata"`
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_cluster_v1_log_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_log_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Snapshot) GetAppliedIndex() uint64 {
+	if x != nil {
+		return x.xxx_hidden_AppliedIndex
+	}
+	return 0
+}
+
+func (x *Snapshot) GetEntries() []*Entry {
+	if x != nil {
+		if x.xxx_hidden_Entries != nil {
+			return *x.xxx_hidden_Entries
+		}
+	}
+	return nil
+}
+
+func (x *Snapshot) GetNamespaces() []*CreateNamespace {
+	if x != nil {
+		if x.xxx_hidden_Namespaces != nil {
+			return *x.xxx_hidden_Namespaces
+		}
+	}
+	return nil
+}
+
+func (x *Snapshot) GetDatabase() []byte {
+	if x != nil {
+		return x.xxx_hidden_Database
+	}
+	return nil
+}
+
+func (x *Snapshot) GetDatabaseMetadata() []byte {
+	if x != nil {
+		return x.xxx_hidden_DatabaseMetadata
+	}
+	return nil
+}
+
+func (x *Snapshot) SetAppliedIndex(v uint64) {
+	x.xxx_hidden_AppliedIndex = v
+}
+
+func (x *Snapshot) SetEntries(v []*Entry) {
+	x.xxx_hidden_Entries = &v
+}
+
+func (x *Snapshot) SetNamespaces(v []*CreateNamespace) {
+	x.xxx_hidden_Namespaces = &v
+}
+
+func (x *Snapshot) SetDatabase(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Database = v
+}
+
+func (x *Snapshot) SetDatabaseMetadata(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_DatabaseMetadata = v
+}
+
+type Snapshot_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The index of the last command applied to the state.
+	AppliedIndex uint64
+	// All entries, in namespace and key order.
+	Entries []*Entry
+	// All namespaces, including the default one, with their quotas.
+	Namespaces []*CreateNamespace
+	// A copy of the database of backends that can be copied, and its
+	// metadata, instead of entries and namespaces. Nodes restored from a
+	// copy keep the numbers of its changes, so revisions mean the same
+	// on every node.
+	Database         []byte
+	DatabaseMetadata []byte
+}
+
+func (b0 Snapshot_builder) Build() *Snapshot {
+	m0 := &Snapshot{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_AppliedIndex = b.AppliedIndex
+	x.xxx_hidden_Entries = &b.Entries
+	x.xxx_hidden_Namespaces = &b.Namespaces
+	x.xxx_hidden_Database = b.Database
+	x.xxx_hidden_DatabaseMetadata = b.DatabaseMetadata
+	return m0
+}
+
+var File_cluster_v1_log_proto protoreflect.FileDescriptor
+
+const file_cluster_v1_log_proto_rawDesc = "" +
+	"\n" +
+	"\x14cluster/v1/log.proto\x12\n" +
+	"cluster.v1\"b\n" +
+	"\x05Entry\x12\x17\n" +
+	"\x03key\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\x12\x1b\n" +
+	"\x05value\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\x05value\x12#\n" +
+	"\tnamespace\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x02R\tnamespace\"\xbb\x02\n" +
+	"\aCommand\x123\n" +
+	"\tbatch_put\x18\x01 \x01(\v2\x14.cluster.v1.BatchPutH\x00R\bbatchPut\x12,\n" +
+	"\x06delete\x18\x02 \x01(\v2\x12.cluster.v1.DeleteH\x00R\x06delete\x12H\n" +
+	"\x10create_namespace\x18\x03 \x01(\v2\x1b.cluster.v1.CreateNamespaceH\x00R\x0fcreateNamespace\x123\n" +
+	"\tset_quota\x18\x04 \x01(\v2\x14.cluster.v1.SetQuotaH\x00R\bsetQuota\x12H\n" +
+	"\x10delete_namespace\x18\x05 \x01(\v2\x1b.cluster.v1.DeleteNamespaceH\x00R\x0fdeleteNamespaceB\x04\n" +
+	"\x02op\"\\\n" +
+	"\bBatchPut\x12+\n" +
+	"\aentries\x18\x01 \x03(\v2\x11.cluster.v1.EntryR\aentries\x12#\n" +
+	"\tnamespace\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\tnamespace\"F\n" +
+	"\x06Delete\x12\x17\n" +
+	"\x03key\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\x12#\n" +
+	"\tnamespace\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\tnamespace\"M\n" +
+	"\x05Quota\x12 \n" +
+	"\bmax_keys\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\amaxKeys\x12\"\n" +
+	"\tmax_bytes\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\bmaxBytes\"U\n" +
+	"\x0fCreateNamespace\x12\x19\n" +
+	"\x04name\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04name\x12'\n" +
+	"\x05quota\x18\x02 \x01(\v2\x11.cluster.v1.QuotaR\x05quota\"N\n" +
+	"\bSetQuota\x12\x19\n" +
+	"\x04name\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04name\x12'\n" +
+	"\x05quota\x18\x02 \x01(\v2\x11.cluster.v1.QuotaR\x05quota\",\n" +
+	"\x0fDeleteNamespace\x12\x19\n" +
+	"\x04name\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04name\"\xf7\x01\n" +
+	"\bSnapshot\x12*\n" +
+	"\rapplied_index\x18\x01 \x01(\x04B\x05\xaa\x01\x02\b\x02R\fappliedIndex\x12+\n" +
+	"\aentries\x18\x02 \x03(\v2\x11.cluster.v1.EntryR\aentries\x12;\n" +
+	"\n" +
+	"namespaces\x18\x03 \x03(\v2\x1b.cluster.v1.CreateNamespaceR\n" +
+	"namespaces\x12!\n" +
+	"\bdatabase\x18\x04 \x01(\fB\x05\xaa\x01\x02\b\x02R\bdatabase\x122\n" +
+	"\x11database_metadata\x18\x05 \x01(\fB\x05\xaa\x01\x02\b\x02R\x10databaseMetadataB+Z)github.com/dynoinc/gh-go/proto/cluster/v1b\beditionsp\xe8\a"
+
+var file_cluster_v1_log_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_cluster_v1_log_proto_goTypes = []any{
+	(*Entry)(nil),           // 0: cluster.v1.Entry
+	(*Command)(nil),         // 1: cluster.v1.Command
+	(*BatchPut)(nil),        // 2: cluster.v1.BatchPut
+	(*Delete)(nil),          // 3: cluster.v1.Delete
+	(*Quota)(nil),           // 4: cluster.v1.Quota
+	(*CreateNamespace)(nil), // 5: cluster.v1.CreateNamespace
+	(*SetQuota)(nil),        // 6: cluster.v1.SetQuota
+	(*DeleteNamespace)(nil), // 7: cluster.v1.DeleteNamespace
+	(*Snapshot)(nil),        // 8: cluster.v1.Snapshot
+}
+var file_cluster_v1_log_proto_depIdxs = []int32{
+	2,  // 0: cluster.v1.Command.batch_put:type_name -> cluster.v1.BatchPut
+	3,  // 1: cluster.v1.Command.delete:type_name -> cluster.v1.Delete
+	5,  // 2: cluster.v1.Command.create_namespace:type_name -> cluster.v1.CreateNamespace
+	6,  // 3: cluster.v1.Command.set_quota:type_name -> cluster.v1.SetQuota
+	7,  // 4: cluster.v1.Command.delete_namespace:type_name -> cluster.v1.DeleteNamespace
+	0,  // 5: cluster.v1.BatchPut.entries:type_name -> cluster.v1.Entry
+	4,  // 6: cluster.v1.CreateNamespace.quota:type_name -> cluster.v1.Quota
+	4,  // 7: cluster.v1.SetQuota.quota:type_name -> cluster.v1.Quota
+	0,  // 8: cluster.v1.Snapshot.entries:type_name -> cluster.v1.Entry
+	5,  // 9: cluster.v1.Snapshot.namespaces:type_name -> cluster.v1.CreateNamespace
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_cluster_v1_log_proto_init() }
+func file_cluster_v1_log_proto_init() {
+	if File_cluster_v1_log_proto != nil {
+		return
+	}
+	file_cluster_v1_log_proto_msgTypes[1].OneofWrappers = []any{
+		(*command_BatchPut)(nil),
+		(*command_Delete)(nil),
+		(*command_CreateNamespace)(nil),
+		(*command_SetQuota)(nil),
+		(*command_DeleteNamespace)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cluster_v1_log_proto_rawDesc), len(file_cluster_v1_log_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_cluster_v1_log_proto_goTypes,
+		DependencyIndexes: file_cluster_v1_log_proto_depIdxs,
+		MessageInfos:      file_cluster_v1_log_proto_msgTypes,
+	}.Build()
+	File_cluster_v1_log_proto = out.File
+	file_cluster_v1_log_proto_goTypes = nil
+	file_cluster_v1_log_proto_depIdxs = nil
+}
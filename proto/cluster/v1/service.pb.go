@@ -0,0 +1,855 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: cluster/v1/service.proto
+
+package v1
+
+import (
+	reflect "reflect"
+	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Member struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          string                 `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_RaftAddress string                 `protobuf:"bytes,2,opt,name=raft_address,json=raftAddress"`
+	xxx_hidden_Voter       bool                   `protobuf:"varint,3,opt,name=voter"`
+	xxx_hidden_Leader      bool                   `protobuf:"varint,4,opt,name=leader"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Member) Reset() {
+	*x = Member{}
+	mi := &file_cluster_v1_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Member) ProtoMessage() {}
+
+func (x *Member) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Member) GetId() string {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return ""
+}
+
+func (x *Member) GetRaftAddress() string {
+	if x != nil {
+		return x.xxx_hidden_RaftAddress
+	}
+	return ""
+}
+
+func (x *Member) GetVoter() bool {
+	if x != nil {
+		return x.xxx_hidden_Voter
+	}
+	return false
+}
+
+func (x *Member) GetLeader() bool {
+	if x != nil {
+		return x.xxx_hidden_Leader
+	}
+	return false
+}
+
+func (x *Member) SetId(v string) {
+	x.xxx_hidden_Id = v
+}
+
+func (x *Member) SetRaftAddress(v string) {
+	x.xxx_hidden_RaftAddress = v
+}
+
+func (x *Member) SetVoter(v bool) {
+	x.xxx_hidden_Voter = v
+}
+
+func (x *Member) SetLeader(v bool) {
+	x.xxx_hidden_Leader = v
+}
+
+type Member_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The node's ID, which is the gRPC address it serves clients on.
+	Id string
+	// The address of the node's Raft transport.
+	RaftAddress string
+	// Whether the node votes in elections and counts towards the quorum.
+	Voter  bool
+	Leader bool
+}
+
+func (b0 Member_builder) Build() *Member {
+	m0 := &Member{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Id = b.Id
+	x.xxx_hidden_RaftAddress = b.RaftAddress
+	x.xxx_hidden_Voter = b.Voter
+	x.xxx_hidden_Leader = b.Leader
+	return m0
+}
+
+type ReadIndexRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadIndexRequest) Reset() {
+	*x = ReadIndexRequest{}
+	mi := &file_cluster_v1_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadIndexRequest) ProtoMessage() {}
+
+func (x *ReadIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type ReadIndexRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 ReadIndexRequest_builder) Build() *ReadIndexRequest {
+	m0 := &ReadIndexRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type ReadIndexResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Index uint64                 `protobuf:"varint,1,opt,name=index"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReadIndexResponse) Reset() {
+	*x = ReadIndexResponse{}
+	mi := &file_cluster_v1_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadIndexResponse) ProtoMessage() {}
+
+func (x *ReadIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ReadIndexResponse) GetIndex() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Index
+	}
+	return 0
+}
+
+func (x *ReadIndexResponse) SetIndex(v uint64) {
+	x.xxx_hidden_Index = v
+}
+
+type ReadIndexResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Reads are linearizable once the reading node applied the log up to
+	// this index.
+	Index uint64
+}
+
+func (b0 ReadIndexResponse_builder) Build() *ReadIndexResponse {
+	m0 := &ReadIndexResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Index = b.Index
+	return m0
+}
+
+type AddMemberRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          string                 `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_RaftAddress string                 `protobuf:"bytes,2,opt,name=raft_address,json=raftAddress"`
+	xxx_hidden_NonVoter    bool                   `protobuf:"varint,3,opt,name=non_voter,json=nonVoter"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *AddMemberRequest) Reset() {
+	*x = AddMemberRequest{}
+	mi := &file_cluster_v1_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMemberRequest) ProtoMessage() {}
+
+func (x *AddMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AddMemberRequest) GetId() string {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return ""
+}
+
+func (x *AddMemberRequest) GetRaftAddress() string {
+	if x != nil {
+		return x.xxx_hidden_RaftAddress
+	}
+	return ""
+}
+
+func (x *AddMemberRequest) GetNonVoter() bool {
+	if x != nil {
+		return x.xxx_hidden_NonVoter
+	}
+	return false
+}
+
+func (x *AddMemberRequest) SetId(v string) {
+	x.xxx_hidden_Id = v
+}
+
+func (x *AddMemberRequest) SetRaftAddress(v string) {
+	x.xxx_hidden_RaftAddress = v
+}
+
+func (x *AddMemberRequest) SetNonVoter(v bool) {
+	x.xxx_hidden_NonVoter = v
+}
+
+type AddMemberRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id          string
+	RaftAddress string
+	// Adds the node without a vote, e.g. to catch up before promoting it.
+	NonVoter bool
+}
+
+func (b0 AddMemberRequest_builder) Build() *AddMemberRequest {
+	m0 := &AddMemberRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Id = b.Id
+	x.xxx_hidden_RaftAddress = b.RaftAddress
+	x.xxx_hidden_NonVoter = b.NonVoter
+	return m0
+}
+
+type AddMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMemberResponse) Reset() {
+	*x = AddMemberResponse{}
+	mi := &file_cluster_v1_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMemberResponse) ProtoMessage() {}
+
+func (x *AddMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type AddMemberResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 AddMemberResponse_builder) Build() *AddMemberResponse {
+	m0 := &AddMemberResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type RemoveMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id string                 `protobuf:"bytes,1,opt,name=id"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberRequest) Reset() {
+	*x = RemoveMemberRequest{}
+	mi := &file_cluster_v1_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberRequest) ProtoMessage() {}
+
+func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RemoveMemberRequest) GetId() string {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return ""
+}
+
+func (x *RemoveMemberRequest) SetId(v string) {
+	x.xxx_hidden_Id = v
+}
+
+type RemoveMemberRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id string
+}
+
+func (b0 RemoveMemberRequest_builder) Build() *RemoveMemberRequest {
+	m0 := &RemoveMemberRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Id = b.Id
+	return m0
+}
+
+type RemoveMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberResponse) Reset() {
+	*x = RemoveMemberResponse{}
+	mi := &file_cluster_v1_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberResponse) ProtoMessage() {}
+
+func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type RemoveMemberResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 RemoveMemberResponse_builder) Build() *RemoveMemberResponse {
+	m0 := &RemoveMemberResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type ListMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMembersRequest) Reset() {
+	*x = ListMembersRequest{}
+	mi := &file_cluster_v1_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersRequest) ProtoMessage() {}
+
+func (x *ListMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type ListMembersRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 ListMembersRequest_builder) Build() *ListMembersRequest {
+	m0 := &ListMembersRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type ListMembersResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Members *[]*Member             `protobuf:"bytes,1,rep,name=members"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListMembersResponse) Reset() {
+	*x = ListMembersResponse{}
+	mi := &file_cluster_v1_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersResponse) ProtoMessage() {}
+
+func (x *ListMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListMembersResponse) GetMembers() []*Member {
+	if x != nil {
+		if x.xxx_hidden_Members != nil {
+			return *x.xxx_hidden_Members
+		}
+	}
+	return nil
+}
+
+func (x *ListMembersResponse) SetMembers(v []*Member) {
+	x.xxx_hidden_Members = &v
+}
+
+type ListMembersResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Members []*Member
+}
+
+func (b0 ListMembersResponse_builder) Build() *ListMembersResponse {
+	m0 := &ListMembersResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Members = &b.Members
+	return m0
+}
+
+type UpdateNamespaceRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Command *Command               `protobuf:"bytes,1,opt,name=command"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UpdateNamespaceRequest) Reset() {
+	*x = UpdateNamespaceRequest{}
+	mi := &file_cluster_v1_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNamespaceRequest) ProtoMessage() {}
+
+func (x *UpdateNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UpdateNamespaceRequest) GetCommand() *Command {
+	if x != nil {
+		return x.xxx_hidden_Command
+	}
+	return nil
+}
+
+func (x *UpdateNamespaceRequest) SetCommand(v *Command) {
+	x.xxx_hidden_Command = v
+}
+
+func (x *UpdateNamespaceRequest) HasCommand() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Command != nil
+}
+
+func (x *UpdateNamespaceRequest) ClearCommand() {
+	x.xxx_hidden_Command = nil
+}
+
+type UpdateNamespaceRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// A command creating a namespace, setting its quota or deleting it.
+	Command *Command
+}
+
+func (b0 UpdateNamespaceRequest_builder) Build() *UpdateNamespaceRequest {
+	m0 := &UpdateNamespaceRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Command = b.Command
+	return m0
+}
+
+type UpdateNamespaceResponse struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name      string                 `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Quota     *Quota                 `protobuf:"bytes,2,opt,name=quota"`
+	xxx_hidden_Keys      int64                  `protobuf:"varint,3,opt,name=keys"`
+	xxx_hidden_Bytes     int64                  `protobuf:"varint,4,opt,name=bytes"`
+	xxx_hidden_CreatedAt int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UpdateNamespaceResponse) Reset() {
+	*x = UpdateNamespaceResponse{}
+	mi := &file_cluster_v1_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNamespaceResponse) ProtoMessage() {}
+
+func (x *UpdateNamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_v1_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UpdateNamespaceResponse) GetName() string {
+	if x != nil {
+		return x.xxx_hidden_Name
+	}
+	return ""
+}
+
+func (x *UpdateNamespaceResponse) GetQuota() *Quota {
+	if x != nil {
+		return x.xxx_hidden_Quota
+	}
+	return nil
+}
+
+func (x *UpdateNamespaceResponse) GetKeys() int64 {
+	if x != nil {
+		return x.xxx_hidden_Keys
+	}
+	return 0
+}
+
+func (x *UpdateNamespaceResponse) GetBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_Bytes
+	}
+	return 0
+}
+
+func (x *UpdateNamespaceResponse) GetCreatedAt() int64 {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return 0
+}
+
+func (x *UpdateNamespaceResponse) SetName(v string) {
+	x.xxx_hidden_Name = v
+}
+
+func (x *UpdateNamespaceResponse) SetQuota(v *Quota) {
+	x.xxx_hidden_Quota = v
+}
+
+func (x *UpdateNamespaceResponse) SetKeys(v int64) {
+	x.xxx_hidden_Keys = v
+}
+
+func (x *UpdateNamespaceResponse) SetBytes(v int64) {
+	x.xxx_hidden_Bytes = v
+}
+
+func (x *UpdateNamespaceResponse) SetCreatedAt(v int64) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *UpdateNamespaceResponse) HasQuota() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Quota != nil
+}
+
+func (x *UpdateNamespaceResponse) ClearQuota() {
+	x.xxx_hidden_Quota = nil
+}
+
+type UpdateNamespaceResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The namespace as changed, unless deleted.
+	Name  string
+	Quota *Quota
+	Keys  int64
+	Bytes int64
+	// Unix nanoseconds.
+	CreatedAt int64
+}
+
+func (b0 UpdateNamespaceResponse_builder) Build() *UpdateNamespaceResponse {
+	m0 := &UpdateNamespaceResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Name = b.Name
+	x.xxx_hidden_Quota = b.Quota
+	x.xxx_hidden_Keys = b.Keys
+	x.xxx_hidden_Bytes = b.Bytes
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	return m0
+}
+
+var File_cluster_v1_service_proto protoreflect.FileDescriptor
+
+const file_cluster_v1_service_proto_rawDesc = "" +
+	"\n" +
+	"\x18cluster/v1/service.proto\x12\n" +
+	"cluster.v1\x1a\x14cluster/v1/log.proto\"\x85\x01\n" +
+	"\x06Member\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x02id\x12(\n" +
+	"\fraft_address\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\vraftAddress\x12\x1b\n" +
+	"\x05voter\x18\x03 \x01(\bB\x05\xaa\x01\x02\b\x02R\x05voter\x12\x1d\n" +
+	"\x06leader\x18\x04 \x01(\bB\x05\xaa\x01\x02\b\x02R\x06leader\"\x12\n" +
+	"\x10ReadIndexRequest\"0\n" +
+	"\x11ReadIndexResponse\x12\x1b\n" +
+	"\x05index\x18\x01 \x01(\x04B\x05\xaa\x01\x02\b\x02R\x05index\"w\n" +
+	"\x10AddMemberRequest\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x02id\x12(\n" +
+	"\fraft_address\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\vraftAddress\x12\"\n" +
+	"\tnon_voter\x18\x03 \x01(\bB\x05\xaa\x01\x02\b\x02R\bnonVoter\"\x13\n" +
+	"\x11AddMemberResponse\",\n" +
+	"\x13RemoveMemberRequest\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x02id\"\x16\n" +
+	"\x14RemoveMemberResponse\"\x14\n" +
+	"\x12ListMembersRequest\"C\n" +
+	"\x13ListMembersResponse\x12,\n" +
+	"\amembers\x18\x01 \x03(\v2\x12.cluster.v1.MemberR\amembers\"G\n" +
+	"\x16UpdateNamespaceRequest\x12-\n" +
+	"\acommand\x18\x01 \x01(\v2\x13.cluster.v1.CommandR\acommand\"\xbb\x01\n" +
+	"\x17UpdateNamespaceResponse\x12\x19\n" +
+	"\x04name\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04name\x12'\n" +
+	"\x05quota\x18\x02 \x01(\v2\x11.cluster.v1.QuotaR\x05quota\x12\x19\n" +
+	"\x04keys\x18\x03 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x04keys\x12\x1b\n" +
+	"\x05bytes\x18\x04 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x05bytes\x12$\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03B\x05\xaa\x01\x02\b\x02R\tcreatedAt2\xa3\x03\n" +
+	"\x0eClusterService\x12H\n" +
+	"\tReadIndex\x12\x1c.cluster.v1.ReadIndexRequest\x1a\x1d.cluster.v1.ReadIndexResponse\x12H\n" +
+	"\tAddMember\x12\x1c.cluster.v1.AddMemberRequest\x1a\x1d.cluster.v1.AddMemberResponse\x12Q\n" +
+	"\fRemoveMember\x12\x1f.cluster.v1.RemoveMemberRequest\x1a .cluster.v1.RemoveMemberResponse\x12N\n" +
+	"\vListMembers\x12\x1e.cluster.v1.ListMembersRequest\x1a\x1f.cluster.v1.ListMembersResponse\x12Z\n" +
+	"\x0fUpdateNamespace\x12\".cluster.v1.UpdateNamespaceRequest\x1a#.cluster.v1.UpdateNamespaceResponseB+Z)github.com/dynoinc/gh-go/proto/cluster/v1b\beditionsp\xe8\a"
+
+var file_cluster_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_cluster_v1_service_proto_goTypes = []any{
+	(*Member)(nil),                  // 0: cluster.v1.Member
+	(*ReadIndexRequest)(nil),        // 1: cluster.v1.ReadIndexRequest
+	(*ReadIndexResponse)(nil),       // 2: cluster.v1.ReadIndexResponse
+	(*AddMemberRequest)(nil),        // 3: cluster.v1.AddMemberRequest
+	(*AddMemberResponse)(nil),       // 4: cluster.v1.AddMemberResponse
+	(*RemoveMemberRequest)(nil),     // 5: cluster.v1.RemoveMemberRequest
+	(*RemoveMemberResponse)(nil),    // 6: cluster.v1.RemoveMemberResponse
+	(*ListMembersRequest)(nil),      // 7: cluster.v1.ListMembersRequest
+	(*ListMembersResponse)(nil),     // 8: cluster.v1.ListMembersResponse
+	(*UpdateNamespaceRequest)(nil),  // 9: cluster.v1.UpdateNamespaceRequest
+	(*UpdateNamespaceResponse)(nil), // 10: cluster.v1.UpdateNamespaceResponse
+	(*Command)(nil),                 // 11: cluster.v1.Command
+	(*Quota)(nil),                   // 12: cluster.v1.Quota
+}
+var file_cluster_v1_service_proto_depIdxs = []int32{
+	0,  // 0: cluster.v1.ListMembersResponse.members:type_name -> cluster.v1.Member
+	11, // 1: cluster.v1.UpdateNamespaceRequest.command:type_name -> cluster.v1.Command
+	12, // 2: cluster.v1.UpdateNamespaceResponse.quota:type_name -> cluster.v1.Quota
+	1,  // 3: cluster.v1.ClusterService.ReadIndex:input_type -> cluster.v1.ReadIndexRequest
+	3,  // 4: cluster.v1.ClusterService.AddMember:input_type -> cluster.v1.AddMemberRequest
+	5,  // 5: cluster.v1.ClusterService.RemoveMember:input_type -> cluster.v1.RemoveMemberRequest
+	7,  // 6: cluster.v1.ClusterService.ListMembers:input_type -> cluster.v1.ListMembersRequest
+	9,  // 7: cluster.v1.ClusterService.UpdateNamespace:input_type -> cluster.v1.UpdateNamespaceRequest
+	2,  // 8: cluster.v1.ClusterService.ReadIndex:output_type -> cluster.v1.ReadIndexResponse
+	4,  // 9: cluster.v1.ClusterService.AddMember:output_type -> cluster.v1.AddMemberResponse
+	6,  // 10: cluster.v1.ClusterService.RemoveMember:output_type -> cluster.v1.RemoveMemberResponse
+	8,  // 11: cluster.v1.ClusterService.ListMembers:output_type -> cluster.v1.ListMembersResponse
+	10, // 12: cluster.v1.ClusterService.UpdateNamespace:output_type -> cluster.v1.UpdateNamespaceResponse
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_cluster_v1_service_proto_init() }
+func file_cluster_v1_service_proto_init() {
+	if File_cluster_v1_service_proto != nil {
+		return
+	}
+	file_cluster_v1_log_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cluster_v1_service_proto_rawDesc), len(file_cluster_v1_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cluster_v1_service_proto_goTypes,
+		DependencyIndexes: file_cluster_v1_service_proto_depIdxs,
+		MessageInfos:      file_cluster_v1_service_proto_msgTypes,
+	}.Build()
+	File_cluster_v1_service_proto = out.File
+	file_cluster_v1_service_proto_goTypes = nil
+	file_cluster_v1_service_proto_depIdxs = nil
+}
@@ -21,6 +21,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Format is an encoding of entries for Import and Export.
+type Format int32
+
+const (
+	Format_FORMAT_UNSPECIFIED Format = 0
+	// FORMAT_JSONL is one {"key": 1, "value": "..."} object per line.
+	Format_FORMAT_JSONL Format = 1
+	// FORMAT_CSV is one key,value record per line, with an optional
+	// key,value header.
+	Format_FORMAT_CSV Format = 2
+	// FORMAT_DELIMITED_PROTO is a sequence of PutRequest messages, each
+	// preceded by its varint-encoded size.
+	Format_FORMAT_DELIMITED_PROTO Format = 3
+)
+
+// Enum value maps for Format.
+var (
+	Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "FORMAT_JSONL",
+		2: "FORMAT_CSV",
+		3: "FORMAT_DELIMITED_PROTO",
+	}
+	Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED":     0,
+		"FORMAT_JSONL":           1,
+		"FORMAT_CSV":             2,
+		"FORMAT_DELIMITED_PROTO": 3,
+	}
+)
+
+func (x Format) Enum() *Format {
+	p := new(Format)
+	*p = x
+	return p
+}
+
+func (x Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_frontend_v1_service_proto_enumTypes[0].Descriptor()
+}
+
+func (Format) Type() protoreflect.EnumType {
+	return &file_frontend_v1_service_proto_enumTypes[0]
+}
+
+func (x Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 type PutRequest struct {
 	state            protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Key   int64                  `protobuf:"varint,1,opt,name=key"`
@@ -136,10 +189,12 @@ func (b0 PutResponse_builder) Build() *PutResponse {
 }
 
 type GetRequest struct {
-	state          protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Key int64                  `protobuf:"varint,1,opt,name=key"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Key      int64                  `protobuf:"varint,1,opt,name=key"`
+	xxx_hidden_Revision int64                  `protobuf:"varint,2,opt,name=revision"`
+	xxx_hidden_ReadTime int64                  `protobuf:"varint,3,opt,name=read_time,json=readTime"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *GetRequest) Reset() {
@@ -174,14 +229,42 @@ func (x *GetRequest) GetKey() int64 {
 	return 0
 }
 
+func (x *GetRequest) GetRevision() int64 {
+	if x != nil {
+		return x.xxx_hidden_Revision
+	}
+	return 0
+}
+
+func (x *GetRequest) GetReadTime() int64 {
+	if x != nil {
+		return x.xxx_hidden_ReadTime
+	}
+	return 0
+}
+
 func (x *GetRequest) SetKey(v int64) {
 	x.xxx_hidden_Key = v
 }
 
+func (x *GetRequest) SetRevision(v int64) {
+	x.xxx_hidden_Revision = v
+}
+
+func (x *GetRequest) SetReadTime(v int64) {
+	x.xxx_hidden_ReadTime = v
+}
+
 type GetRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	Key int64
+	// revision, if not zero, reads the value the key had after the change
+	// with this revision was committed.
+	Revision int64
+	// read_time, if not zero, reads the value the key had at this time,
+	// in Unix nanoseconds.
+	ReadTime int64
 }
 
 func (b0 GetRequest_builder) Build() *GetRequest {
@@ -189,6 +272,8 @@ func (b0 GetRequest_builder) Build() *GetRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	x.xxx_hidden_Key = b.Key
+	x.xxx_hidden_Revision = b.Revision
+	x.xxx_hidden_ReadTime = b.ReadTime
 	return m0
 }
 
@@ -249,42 +334,1626 @@ func (b0 GetResponse_builder) Build() *GetResponse {
 	return m0
 }
 
-var File_frontend_v1_service_proto protoreflect.FileDescriptor
+type DeleteRequest struct {
+	state          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Key int64                  `protobuf:"varint,1,opt,name=key"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
 
-const file_frontend_v1_service_proto_rawDesc = "" +
-	"\n" +
-	"\x19frontend/v1/service.proto\x12\vfrontend.v1\"B\n" +
-	"\n" +
-	"PutRequest\x12\x17\n" +
-	"\x03key\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\x12\x1b\n" +
-	"\x05value\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\x05value\"\r\n" +
-	"\vPutResponse\"%\n" +
-	"\n" +
-	"GetRequest\x12\x17\n" +
-	"\x03key\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\"*\n" +
-	"\vGetResponse\x12\x1b\n" +
-	"\x05value\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x05value2\x85\x01\n" +
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_frontend_v1_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteRequest) GetKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_Key
+	}
+	return 0
+}
+
+func (x *DeleteRequest) SetKey(v int64) {
+	x.xxx_hidden_Key = v
+}
+
+type DeleteRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Key int64
+}
+
+func (b0 DeleteRequest_builder) Build() *DeleteRequest {
+	m0 := &DeleteRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Key = b.Key
+	return m0
+}
+
+type DeleteResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Found bool                   `protobuf:"varint,1,opt,name=found"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_frontend_v1_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteResponse) GetFound() bool {
+	if x != nil {
+		return x.xxx_hidden_Found
+	}
+	return false
+}
+
+func (x *DeleteResponse) SetFound(v bool) {
+	x.xxx_hidden_Found = v
+}
+
+type DeleteResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// found reports whether the key existed.
+	Found bool
+}
+
+func (b0 DeleteResponse_builder) Build() *DeleteResponse {
+	m0 := &DeleteResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Found = b.Found
+	return m0
+}
+
+type ScanRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_StartKey    int64                  `protobuf:"varint,1,opt,name=start_key,json=startKey"`
+	xxx_hidden_EndKey      int64                  `protobuf:"varint,2,opt,name=end_key,json=endKey"`
+	xxx_hidden_Limit       int64                  `protobuf:"varint,3,opt,name=limit"`
+	xxx_hidden_Revision    int64                  `protobuf:"varint,4,opt,name=revision"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ScanRequest) Reset() {
+	*x = ScanRequest{}
+	mi := &file_frontend_v1_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanRequest) ProtoMessage() {}
+
+func (x *ScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ScanRequest) GetStartKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_StartKey
+	}
+	return 0
+}
+
+func (x *ScanRequest) GetEndKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_EndKey
+	}
+	return 0
+}
+
+func (x *ScanRequest) GetLimit() int64 {
+	if x != nil {
+		return x.xxx_hidden_Limit
+	}
+	return 0
+}
+
+func (x *ScanRequest) GetRevision() int64 {
+	if x != nil {
+		return x.xxx_hidden_Revision
+	}
+	return 0
+}
+
+func (x *ScanRequest) SetStartKey(v int64) {
+	x.xxx_hidden_StartKey = v
+}
+
+func (x *ScanRequest) SetEndKey(v int64) {
+	x.xxx_hidden_EndKey = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *ScanRequest) SetLimit(v int64) {
+	x.xxx_hidden_Limit = v
+}
+
+func (x *ScanRequest) SetRevision(v int64) {
+	x.xxx_hidden_Revision = v
+}
+
+func (x *ScanRequest) HasEndKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ScanRequest) ClearEndKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_EndKey = 0
+}
+
+type ScanRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// start_key is the first key returned.
+	StartKey int64
+	// end_key, if set, is the key the scan stops before.
+	EndKey *int64
+	// limit caps the number of entries returned; zero returns all.
+	Limit int64
+	// revision, if not zero, scans the entries as of this revision, as
+	// returned by an earlier read. Otherwise the scan reads the latest
+	// revision.
+	Revision int64
+}
+
+func (b0 ScanRequest_builder) Build() *ScanRequest {
+	m0 := &ScanRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_StartKey = b.StartKey
+	if b.EndKey != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_EndKey = *b.EndKey
+	}
+	x.xxx_hidden_Limit = b.Limit
+	x.xxx_hidden_Revision = b.Revision
+	return m0
+}
+
+type ScanResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Key      int64                  `protobuf:"varint,1,opt,name=key"`
+	xxx_hidden_Value    string                 `protobuf:"bytes,2,opt,name=value"`
+	xxx_hidden_Revision int64                  `protobuf:"varint,3,opt,name=revision"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ScanResponse) Reset() {
+	*x = ScanResponse{}
+	mi := &file_frontend_v1_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanResponse) ProtoMessage() {}
+
+func (x *ScanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ScanResponse) GetKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_Key
+	}
+	return 0
+}
+
+func (x *ScanResponse) GetValue() string {
+	if x != nil {
+		return x.xxx_hidden_Value
+	}
+	return ""
+}
+
+func (x *ScanResponse) GetRevision() int64 {
+	if x != nil {
+		return x.xxx_hidden_Revision
+	}
+	return 0
+}
+
+func (x *ScanResponse) SetKey(v int64) {
+	x.xxx_hidden_Key = v
+}
+
+func (x *ScanResponse) SetValue(v string) {
+	x.xxx_hidden_Value = v
+}
+
+func (x *ScanResponse) SetRevision(v int64) {
+	x.xxx_hidden_Revision = v
+}
+
+type ScanResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Key   int64
+	Value string
+	// revision is the revision the scan reads, the same in every
+	// response.
+	Revision int64
+}
+
+func (b0 ScanResponse_builder) Build() *ScanResponse {
+	m0 := &ScanResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Key = b.Key
+	x.xxx_hidden_Value = b.Value
+	x.xxx_hidden_Revision = b.Revision
+	return m0
+}
+
+type BatchPutRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Entries *[]*PutRequest         `protobuf:"bytes,1,rep,name=entries"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BatchPutRequest) Reset() {
+	*x = BatchPutRequest{}
+	mi := &file_frontend_v1_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPutRequest) ProtoMessage() {}
+
+func (x *BatchPutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchPutRequest) GetEntries() []*PutRequest {
+	if x != nil {
+		if x.xxx_hidden_Entries != nil {
+			return *x.xxx_hidden_Entries
+		}
+	}
+	return nil
+}
+
+func (x *BatchPutRequest) SetEntries(v []*PutRequest) {
+	x.xxx_hidden_Entries = &v
+}
+
+type BatchPutRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// entries are applied in order, so the last entry for a key wins.
+	Entries []*PutRequest
+}
+
+func (b0 BatchPutRequest_builder) Build() *BatchPutRequest {
+	m0 := &BatchPutRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Entries = &b.Entries
+	return m0
+}
+
+type BatchPutResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchPutResponse) Reset() {
+	*x = BatchPutResponse{}
+	mi := &file_frontend_v1_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPutResponse) ProtoMessage() {}
+
+func (x *BatchPutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type BatchPutResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 BatchPutResponse_builder) Build() *BatchPutResponse {
+	m0 := &BatchPutResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type BatchGetRequest struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Keys     []int64                `protobuf:"varint,1,rep,packed,name=keys"`
+	xxx_hidden_Revision int64                  `protobuf:"varint,2,opt,name=revision"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BatchGetRequest) Reset() {
+	*x = BatchGetRequest{}
+	mi := &file_frontend_v1_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetRequest) ProtoMessage() {}
+
+func (x *BatchGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchGetRequest) GetKeys() []int64 {
+	if x != nil {
+		return x.xxx_hidden_Keys
+	}
+	return nil
+}
+
+func (x *BatchGetRequest) GetRevision() int64 {
+	if x != nil {
+		return x.xxx_hidden_Revision
+	}
+	return 0
+}
+
+func (x *BatchGetRequest) SetKeys(v []int64) {
+	x.xxx_hidden_Keys = v
+}
+
+func (x *BatchGetRequest) SetRevision(v int64) {
+	x.xxx_hidden_Revision = v
+}
+
+type BatchGetRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Keys []int64
+	// revision, if not zero, reads the keys as of this revision, as
+	// returned by an earlier read. Otherwise the latest revision is read.
+	Revision int64
+}
+
+func (b0 BatchGetRequest_builder) Build() *BatchGetRequest {
+	m0 := &BatchGetRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Keys = b.Keys
+	x.xxx_hidden_Revision = b.Revision
+	return m0
+}
+
+type BatchGetResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Results  *[]*GetResult          `protobuf:"bytes,1,rep,name=results"`
+	xxx_hidden_Revision int64                  `protobuf:"varint,2,opt,name=revision"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BatchGetResponse) Reset() {
+	*x = BatchGetResponse{}
+	mi := &file_frontend_v1_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetResponse) ProtoMessage() {}
+
+func (x *BatchGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchGetResponse) GetResults() []*GetResult {
+	if x != nil {
+		if x.xxx_hidden_Results != nil {
+			return *x.xxx_hidden_Results
+		}
+	}
+	return nil
+}
+
+func (x *BatchGetResponse) GetRevision() int64 {
+	if x != nil {
+		return x.xxx_hidden_Revision
+	}
+	return 0
+}
+
+func (x *BatchGetResponse) SetResults(v []*GetResult) {
+	x.xxx_hidden_Results = &v
+}
+
+func (x *BatchGetResponse) SetRevision(v int64) {
+	x.xxx_hidden_Revision = v
+}
+
+type BatchGetResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// results holds one result per requested key, in request order.
+	Results []*GetResult
+	// revision is the revision the results were read at. Passing it to
+	// later reads sees the same state.
+	Revision int64
+}
+
+func (b0 BatchGetResponse_builder) Build() *BatchGetResponse {
+	m0 := &BatchGetResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Results = &b.Results
+	x.xxx_hidden_Revision = b.Revision
+	return m0
+}
+
+type GetResult struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Key   int64                  `protobuf:"varint,1,opt,name=key"`
+	xxx_hidden_Value string                 `protobuf:"bytes,2,opt,name=value"`
+	xxx_hidden_Found bool                   `protobuf:"varint,3,opt,name=found"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetResult) Reset() {
+	*x = GetResult{}
+	mi := &file_frontend_v1_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResult) ProtoMessage() {}
+
+func (x *GetResult) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetResult) GetKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_Key
+	}
+	return 0
+}
+
+func (x *GetResult) GetValue() string {
+	if x != nil {
+		return x.xxx_hidden_Value
+	}
+	return ""
+}
+
+func (x *GetResult) GetFound() bool {
+	if x != nil {
+		return x.xxx_hidden_Found
+	}
+	return false
+}
+
+func (x *GetResult) SetKey(v int64) {
+	x.xxx_hidden_Key = v
+}
+
+func (x *GetResult) SetValue(v string) {
+	x.xxx_hidden_Value = v
+}
+
+func (x *GetResult) SetFound(v bool) {
+	x.xxx_hidden_Found = v
+}
+
+type GetResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Key   int64
+	Value string
+	Found bool
+}
+
+func (b0 GetResult_builder) Build() *GetResult {
+	m0 := &GetResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Key = b.Key
+	x.xxx_hidden_Value = b.Value
+	x.xxx_hidden_Found = b.Found
+	return m0
+}
+
+type WatchRequest struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Keys []int64                `protobuf:"varint,1,rep,packed,name=keys"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_frontend_v1_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WatchRequest) GetKeys() []int64 {
+	if x != nil {
+		return x.xxx_hidden_Keys
+	}
+	return nil
+}
+
+func (x *WatchRequest) SetKeys(v []int64) {
+	x.xxx_hidden_Keys = v
+}
+
+type WatchRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// keys limits the stream to changes of these keys; empty watches all.
+	Keys []int64
+}
+
+func (b0 WatchRequest_builder) Build() *WatchRequest {
+	m0 := &WatchRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Keys = b.Keys
+	return m0
+}
+
+type WatchResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Key     int64                  `protobuf:"varint,1,opt,name=key"`
+	xxx_hidden_Value   string                 `protobuf:"bytes,2,opt,name=value"`
+	xxx_hidden_Deleted bool                   `protobuf:"varint,3,opt,name=deleted"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *WatchResponse) Reset() {
+	*x = WatchResponse{}
+	mi := &file_frontend_v1_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchResponse) ProtoMessage() {}
+
+func (x *WatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WatchResponse) GetKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_Key
+	}
+	return 0
+}
+
+func (x *WatchResponse) GetValue() string {
+	if x != nil {
+		return x.xxx_hidden_Value
+	}
+	return ""
+}
+
+func (x *WatchResponse) GetDeleted() bool {
+	if x != nil {
+		return x.xxx_hidden_Deleted
+	}
+	return false
+}
+
+func (x *WatchResponse) SetKey(v int64) {
+	x.xxx_hidden_Key = v
+}
+
+func (x *WatchResponse) SetValue(v string) {
+	x.xxx_hidden_Value = v
+}
+
+func (x *WatchResponse) SetDeleted(v bool) {
+	x.xxx_hidden_Deleted = v
+}
+
+type WatchResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Key     int64
+	Value   string
+	Deleted bool
+}
+
+func (b0 WatchResponse_builder) Build() *WatchResponse {
+	m0 := &WatchResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Key = b.Key
+	x.xxx_hidden_Value = b.Value
+	x.xxx_hidden_Deleted = b.Deleted
+	return m0
+}
+
+type HistoryRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Key            int64                  `protobuf:"varint,1,opt,name=key"`
+	xxx_hidden_BeforeRevision int64                  `protobuf:"varint,2,opt,name=before_revision,json=beforeRevision"`
+	xxx_hidden_Limit          int64                  `protobuf:"varint,3,opt,name=limit"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	mi := &file_frontend_v1_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *HistoryRequest) GetKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_Key
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetBeforeRevision() int64 {
+	if x != nil {
+		return x.xxx_hidden_BeforeRevision
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetLimit() int64 {
+	if x != nil {
+		return x.xxx_hidden_Limit
+	}
+	return 0
+}
+
+func (x *HistoryRequest) SetKey(v int64) {
+	x.xxx_hidden_Key = v
+}
+
+func (x *HistoryRequest) SetBeforeRevision(v int64) {
+	x.xxx_hidden_BeforeRevision = v
+}
+
+func (x *HistoryRequest) SetLimit(v int64) {
+	x.xxx_hidden_Limit = v
+}
+
+type HistoryRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Key int64
+	// before_revision, if not zero, lists versions older than this
+	// revision, to page through the history.
+	BeforeRevision int64
+	// limit caps the number of versions returned; zero returns up to 100.
+	Limit int64
+}
+
+func (b0 HistoryRequest_builder) Build() *HistoryRequest {
+	m0 := &HistoryRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Key = b.Key
+	x.xxx_hidden_BeforeRevision = b.BeforeRevision
+	x.xxx_hidden_Limit = b.Limit
+	return m0
+}
+
+type HistoryResponse struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Versions  *[]*Version            `protobuf:"bytes,1,rep,name=versions"`
+	xxx_hidden_Compacted bool                   `protobuf:"varint,2,opt,name=compacted"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *HistoryResponse) Reset() {
+	*x = HistoryResponse{}
+	mi := &file_frontend_v1_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryResponse) ProtoMessage() {}
+
+func (x *HistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *HistoryResponse) GetVersions() []*Version {
+	if x != nil {
+		if x.xxx_hidden_Versions != nil {
+			return *x.xxx_hidden_Versions
+		}
+	}
+	return nil
+}
+
+func (x *HistoryResponse) GetCompacted() bool {
+	if x != nil {
+		return x.xxx_hidden_Compacted
+	}
+	return false
+}
+
+func (x *HistoryResponse) SetVersions(v []*Version) {
+	x.xxx_hidden_Versions = &v
+}
+
+func (x *HistoryResponse) SetCompacted(v bool) {
+	x.xxx_hidden_Compacted = v
+}
+
+type HistoryResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// versions are newest first.
+	Versions []*Version
+	// compacted reports that older versions were trimmed by compaction.
+	Compacted bool
+}
+
+func (b0 HistoryResponse_builder) Build() *HistoryResponse {
+	m0 := &HistoryResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Versions = &b.Versions
+	x.xxx_hidden_Compacted = b.Compacted
+	return m0
+}
+
+// Version is a value a key had.
+type Version struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Revision    int64                  `protobuf:"varint,1,opt,name=revision"`
+	xxx_hidden_Value       string                 `protobuf:"bytes,2,opt,name=value"`
+	xxx_hidden_Deleted     bool                   `protobuf:"varint,3,opt,name=deleted"`
+	xxx_hidden_CommittedAt int64                  `protobuf:"varint,4,opt,name=committed_at,json=committedAt"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Version) Reset() {
+	*x = Version{}
+	mi := &file_frontend_v1_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Version) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Version) ProtoMessage() {}
+
+func (x *Version) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Version) GetRevision() int64 {
+	if x != nil {
+		return x.xxx_hidden_Revision
+	}
+	return 0
+}
+
+func (x *Version) GetValue() string {
+	if x != nil {
+		return x.xxx_hidden_Value
+	}
+	return ""
+}
+
+func (x *Version) GetDeleted() bool {
+	if x != nil {
+		return x.xxx_hidden_Deleted
+	}
+	return false
+}
+
+func (x *Version) GetCommittedAt() int64 {
+	if x != nil {
+		return x.xxx_hidden_CommittedAt
+	}
+	return 0
+}
+
+func (x *Version) SetRevision(v int64) {
+	x.xxx_hidden_Revision = v
+}
+
+func (x *Version) SetValue(v string) {
+	x.xxx_hidden_Value = v
+}
+
+func (x *Version) SetDeleted(v bool) {
+	x.xxx_hidden_Deleted = v
+}
+
+func (x *Version) SetCommittedAt(v int64) {
+	x.xxx_hidden_CommittedAt = v
+}
+
+type Version_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// revision numbers the change that wrote the version.
+	Revision int64
+	Value    string
+	Deleted  bool
+	// committed_at is the commit time in Unix nanoseconds.
+	CommittedAt int64
+}
+
+func (b0 Version_builder) Build() *Version {
+	m0 := &Version{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Revision = b.Revision
+	x.xxx_hidden_Value = b.Value
+	x.xxx_hidden_Deleted = b.Deleted
+	x.xxx_hidden_CommittedAt = b.CommittedAt
+	return m0
+}
+
+type ImportRequest struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Format    Format                 `protobuf:"varint,1,opt,name=format,enum=frontend.v1.Format"`
+	xxx_hidden_ChunkSize int64                  `protobuf:"varint,2,opt,name=chunk_size,json=chunkSize"`
+	xxx_hidden_DryRun    bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun"`
+	xxx_hidden_Data      []byte                 `protobuf:"bytes,4,opt,name=data"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ImportRequest) Reset() {
+	*x = ImportRequest{}
+	mi := &file_frontend_v1_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRequest) ProtoMessage() {}
+
+func (x *ImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ImportRequest) GetFormat() Format {
+	if x != nil {
+		return x.xxx_hidden_Format
+	}
+	return Format_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportRequest) GetChunkSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_ChunkSize
+	}
+	return 0
+}
+
+func (x *ImportRequest) GetDryRun() bool {
+	if x != nil {
+		return x.xxx_hidden_DryRun
+	}
+	return false
+}
+
+func (x *ImportRequest) GetData() []byte {
+	if x != nil {
+		return x.xxx_hidden_Data
+	}
+	return nil
+}
+
+func (x *ImportRequest) SetFormat(v Format) {
+	x.xxx_hidden_Format = v
+}
+
+func (x *ImportRequest) SetChunkSize(v int64) {
+	x.xxx_hidden_ChunkSize = v
+}
+
+func (x *ImportRequest) SetDryRun(v bool) {
+	x.xxx_hidden_DryRun = v
+}
+
+func (x *ImportRequest) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Data = v
+}
+
+type ImportRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// format, chunk_size and dry_run are read from the first message.
+	Format Format
+	// chunk_size is the number of entries stored per transaction; zero
+	// uses the server's default.
+	ChunkSize int64
+	// dry_run validates the data without storing it.
+	DryRun bool
+	// data is the next part of the encoded entries. Parts need not align
+	// with entries.
+	Data []byte
+}
+
+func (b0 ImportRequest_builder) Build() *ImportRequest {
+	m0 := &ImportRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Format = b.Format
+	x.xxx_hidden_ChunkSize = b.ChunkSize
+	x.xxx_hidden_DryRun = b.DryRun
+	x.xxx_hidden_Data = b.Data
+	return m0
+}
+
+type ImportResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Entries int64                  `protobuf:"varint,1,opt,name=entries"`
+	xxx_hidden_Chunks  int64                  `protobuf:"varint,2,opt,name=chunks"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ImportResponse) Reset() {
+	*x = ImportResponse{}
+	mi := &file_frontend_v1_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportResponse) ProtoMessage() {}
+
+func (x *ImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ImportResponse) GetEntries() int64 {
+	if x != nil {
+		return x.xxx_hidden_Entries
+	}
+	return 0
+}
+
+func (x *ImportResponse) GetChunks() int64 {
+	if x != nil {
+		return x.xxx_hidden_Chunks
+	}
+	return 0
+}
+
+func (x *ImportResponse) SetEntries(v int64) {
+	x.xxx_hidden_Entries = v
+}
+
+func (x *ImportResponse) SetChunks(v int64) {
+	x.xxx_hidden_Chunks = v
+}
+
+type ImportResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// entries is the number of entries stored, or validated on a dry run.
+	Entries int64
+	// chunks is the number of transactions the entries were stored in.
+	Chunks int64
+}
+
+func (b0 ImportResponse_builder) Build() *ImportResponse {
+	m0 := &ImportResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Entries = b.Entries
+	x.xxx_hidden_Chunks = b.Chunks
+	return m0
+}
+
+type ExportRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Format      Format                 `protobuf:"varint,1,opt,name=format,enum=frontend.v1.Format"`
+	xxx_hidden_StartKey    int64                  `protobuf:"varint,2,opt,name=start_key,json=startKey"`
+	xxx_hidden_EndKey      int64                  `protobuf:"varint,3,opt,name=end_key,json=endKey"`
+	xxx_hidden_ChunkSize   int64                  `protobuf:"varint,4,opt,name=chunk_size,json=chunkSize"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	mi := &file_frontend_v1_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ExportRequest) GetFormat() Format {
+	if x != nil {
+		return x.xxx_hidden_Format
+	}
+	return Format_FORMAT_UNSPECIFIED
+}
+
+func (x *ExportRequest) GetStartKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_StartKey
+	}
+	return 0
+}
+
+func (x *ExportRequest) GetEndKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_EndKey
+	}
+	return 0
+}
+
+func (x *ExportRequest) GetChunkSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_ChunkSize
+	}
+	return 0
+}
+
+func (x *ExportRequest) SetFormat(v Format) {
+	x.xxx_hidden_Format = v
+}
+
+func (x *ExportRequest) SetStartKey(v int64) {
+	x.xxx_hidden_StartKey = v
+}
+
+func (x *ExportRequest) SetEndKey(v int64) {
+	x.xxx_hidden_EndKey = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *ExportRequest) SetChunkSize(v int64) {
+	x.xxx_hidden_ChunkSize = v
+}
+
+func (x *ExportRequest) HasEndKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ExportRequest) ClearEndKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_EndKey = 0
+}
+
+type ExportRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Format Format
+	// start_key is the first key exported. To resume an interrupted
+	// export, set it to the last_key received plus one.
+	StartKey int64
+	// end_key, if set, is the key the export stops before.
+	EndKey *int64
+	// chunk_size is the number of entries per response; zero uses the
+	// server's default.
+	ChunkSize int64
+}
+
+func (b0 ExportRequest_builder) Build() *ExportRequest {
+	m0 := &ExportRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Format = b.Format
+	x.xxx_hidden_StartKey = b.StartKey
+	if b.EndKey != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_EndKey = *b.EndKey
+	}
+	x.xxx_hidden_ChunkSize = b.ChunkSize
+	return m0
+}
+
+type ExportResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Data    []byte                 `protobuf:"bytes,1,opt,name=data"`
+	xxx_hidden_LastKey int64                  `protobuf:"varint,2,opt,name=last_key,json=lastKey"`
+	xxx_hidden_Entries int64                  `protobuf:"varint,3,opt,name=entries"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ExportResponse) Reset() {
+	*x = ExportResponse{}
+	mi := &file_frontend_v1_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportResponse) ProtoMessage() {}
+
+func (x *ExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_frontend_v1_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ExportResponse) GetData() []byte {
+	if x != nil {
+		return x.xxx_hidden_Data
+	}
+	return nil
+}
+
+func (x *ExportResponse) GetLastKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_LastKey
+	}
+	return 0
+}
+
+func (x *ExportResponse) GetEntries() int64 {
+	if x != nil {
+		return x.xxx_hidden_Entries
+	}
+	return 0
+}
+
+func (x *ExportResponse) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Data = v
+}
+
+func (x *ExportResponse) SetLastKey(v int64) {
+	x.xxx_hidden_LastKey = v
+}
+
+func (x *ExportResponse) SetEntries(v int64) {
+	x.xxx_hidden_Entries = v
+}
+
+type ExportResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// data holds the encoded entries of this chunk.
+	Data []byte
+	// last_key is the key of the last entry in data.
+	LastKey int64
+	// entries is the number of entries exported so far by this call.
+	Entries int64
+}
+
+func (b0 ExportResponse_builder) Build() *ExportResponse {
+	m0 := &ExportResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Data = b.Data
+	x.xxx_hidden_LastKey = b.LastKey
+	x.xxx_hidden_Entries = b.Entries
+	return m0
+}
+
+var File_frontend_v1_service_proto protoreflect.FileDescriptor
+
+const file_frontend_v1_service_proto_rawDesc = "" +
+	"\n" +
+	"\x19frontend/v1/service.proto\x12\vfrontend.v1\"B\n" +
+	"\n" +
+	"PutRequest\x12\x17\n" +
+	"\x03key\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\x12\x1b\n" +
+	"\x05value\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\x05value\"\r\n" +
+	"\vPutResponse\"l\n" +
+	"\n" +
+	"GetRequest\x12\x17\n" +
+	"\x03key\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\x12!\n" +
+	"\brevision\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\brevision\x12\"\n" +
+	"\tread_time\x18\x03 \x01(\x03B\x05\xaa\x01\x02\b\x02R\breadTime\"*\n" +
+	"\vGetResponse\x12\x1b\n" +
+	"\x05value\x18\x01 \x01(\tB\x05\xaa\x01\x02\b\x02R\x05value\"(\n" +
+	"\rDeleteRequest\x12\x17\n" +
+	"\x03key\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\"-\n" +
+	"\x0eDeleteResponse\x12\x1b\n" +
+	"\x05found\x18\x01 \x01(\bB\x05\xaa\x01\x02\b\x02R\x05found\"\x8a\x01\n" +
+	"\vScanRequest\x12\"\n" +
+	"\tstart_key\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\bstartKey\x12\x17\n" +
+	"\aend_key\x18\x02 \x01(\x03R\x06endKey\x12\x1b\n" +
+	"\x05limit\x18\x03 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x05limit\x12!\n" +
+	"\brevision\x18\x04 \x01(\x03B\x05\xaa\x01\x02\b\x02R\brevision\"g\n" +
+	"\fScanResponse\x12\x17\n" +
+	"\x03key\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\x12\x1b\n" +
+	"\x05value\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\x05value\x12!\n" +
+	"\brevision\x18\x03 \x01(\x03B\x05\xaa\x01\x02\b\x02R\brevision\"D\n" +
+	"\x0fBatchPutRequest\x121\n" +
+	"\aentries\x18\x01 \x03(\v2\x17.frontend.v1.PutRequestR\aentries\"\x12\n" +
+	"\x10BatchPutResponse\"H\n" +
+	"\x0fBatchGetRequest\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\x03R\x04keys\x12!\n" +
+	"\brevision\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\brevision\"g\n" +
+	"\x10BatchGetResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.frontend.v1.GetResultR\aresults\x12!\n" +
+	"\brevision\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\brevision\"^\n" +
+	"\tGetResult\x12\x17\n" +
+	"\x03key\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\x12\x1b\n" +
+	"\x05value\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\x05value\x12\x1b\n" +
+	"\x05found\x18\x03 \x01(\bB\x05\xaa\x01\x02\b\x02R\x05found\"\"\n" +
+	"\fWatchRequest\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\x03R\x04keys\"f\n" +
+	"\rWatchResponse\x12\x17\n" +
+	"\x03key\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\x12\x1b\n" +
+	"\x05value\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\x05value\x12\x1f\n" +
+	"\adeleted\x18\x03 \x01(\bB\x05\xaa\x01\x02\b\x02R\adeleted\"v\n" +
+	"\x0eHistoryRequest\x12\x17\n" +
+	"\x03key\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\x12.\n" +
+	"\x0fbefore_revision\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x0ebeforeRevision\x12\x1b\n" +
+	"\x05limit\x18\x03 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x05limit\"h\n" +
+	"\x0fHistoryResponse\x120\n" +
+	"\bversions\x18\x01 \x03(\v2\x14.frontend.v1.VersionR\bversions\x12#\n" +
+	"\tcompacted\x18\x02 \x01(\bB\x05\xaa\x01\x02\b\x02R\tcompacted\"\x94\x01\n" +
+	"\aVersion\x12!\n" +
+	"\brevision\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\brevision\x12\x1b\n" +
+	"\x05value\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\x05value\x12\x1f\n" +
+	"\adeleted\x18\x03 \x01(\bB\x05\xaa\x01\x02\b\x02R\adeleted\x12(\n" +
+	"\fcommitted_at\x18\x04 \x01(\x03B\x05\xaa\x01\x02\b\x02R\vcommittedAt\"\xa4\x01\n" +
+	"\rImportRequest\x122\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x13.frontend.v1.FormatB\x05\xaa\x01\x02\b\x02R\x06format\x12$\n" +
+	"\n" +
+	"chunk_size\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\tchunkSize\x12\x1e\n" +
+	"\adry_run\x18\x03 \x01(\bB\x05\xaa\x01\x02\b\x02R\x06dryRun\x12\x19\n" +
+	"\x04data\x18\x04 \x01(\fB\x05\xaa\x01\x02\b\x02R\x04data\"P\n" +
+	"\x0eImportResponse\x12\x1f\n" +
+	"\aentries\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\aentries\x12\x1d\n" +
+	"\x06chunks\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x06chunks\"\xa6\x01\n" +
+	"\rExportRequest\x122\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x13.frontend.v1.FormatB\x05\xaa\x01\x02\b\x02R\x06format\x12\"\n" +
+	"\tstart_key\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\bstartKey\x12\x17\n" +
+	"\aend_key\x18\x03 \x01(\x03R\x06endKey\x12$\n" +
+	"\n" +
+	"chunk_size\x18\x04 \x01(\x03B\x05\xaa\x01\x02\b\x02R\tchunkSize\"n\n" +
+	"\x0eExportResponse\x12\x19\n" +
+	"\x04data\x18\x01 \x01(\fB\x05\xaa\x01\x02\b\x02R\x04data\x12 \n" +
+	"\blast_key\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\alastKey\x12\x1f\n" +
+	"\aentries\x18\x03 \x01(\x03B\x05\xaa\x01\x02\b\x02R\aentries*^\n" +
+	"\x06Format\x12\x16\n" +
+	"\x12FORMAT_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fFORMAT_JSONL\x10\x01\x12\x0e\n" +
+	"\n" +
+	"FORMAT_CSV\x10\x02\x12\x1a\n" +
+	"\x16FORMAT_DELIMITED_PROTO\x10\x032\xab\x05\n" +
 	"\x0fFrontendService\x128\n" +
 	"\x03Put\x12\x17.frontend.v1.PutRequest\x1a\x18.frontend.v1.PutResponse\x128\n" +
-	"\x03Get\x12\x17.frontend.v1.GetRequest\x1a\x18.frontend.v1.GetResponseB,Z*github.com/dynoinc/gh-go/proto/frontend/v1b\beditionsp\xe8\a"
+	"\x03Get\x12\x17.frontend.v1.GetRequest\x1a\x18.frontend.v1.GetResponse\x12A\n" +
+	"\x06Delete\x12\x1a.frontend.v1.DeleteRequest\x1a\x1b.frontend.v1.DeleteResponse\x12=\n" +
+	"\x04Scan\x12\x18.frontend.v1.ScanRequest\x1a\x19.frontend.v1.ScanResponse0\x01\x12G\n" +
+	"\bBatchPut\x12\x1c.frontend.v1.BatchPutRequest\x1a\x1d.frontend.v1.BatchPutResponse\x12G\n" +
+	"\bBatchGet\x12\x1c.frontend.v1.BatchGetRequest\x1a\x1d.frontend.v1.BatchGetResponse\x12@\n" +
+	"\x05Watch\x12\x19.frontend.v1.WatchRequest\x1a\x1a.frontend.v1.WatchResponse0\x01\x12C\n" +
+	"\x06Import\x12\x1a.frontend.v1.ImportRequest\x1a\x1b.frontend.v1.ImportResponse(\x01\x12C\n" +
+	"\x06Export\x12\x1a.frontend.v1.ExportRequest\x1a\x1b.frontend.v1.ExportResponse0\x01\x12D\n" +
+	"\aHistory\x12\x1b.frontend.v1.HistoryRequest\x1a\x1c.frontend.v1.HistoryResponseB,Z*github.com/dynoinc/gh-go/proto/frontend/v1b\beditionsp\xe8\a"
 
-var file_frontend_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_frontend_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_frontend_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_frontend_v1_service_proto_goTypes = []any{
-	(*PutRequest)(nil),  // 0: frontend.v1.PutRequest
-	(*PutResponse)(nil), // 1: frontend.v1.PutResponse
-	(*GetRequest)(nil),  // 2: frontend.v1.GetRequest
-	(*GetResponse)(nil), // 3: frontend.v1.GetResponse
+	(Format)(0),              // 0: frontend.v1.Format
+	(*PutRequest)(nil),       // 1: frontend.v1.PutRequest
+	(*PutResponse)(nil),      // 2: frontend.v1.PutResponse
+	(*GetRequest)(nil),       // 3: frontend.v1.GetRequest
+	(*GetResponse)(nil),      // 4: frontend.v1.GetResponse
+	(*DeleteRequest)(nil),    // 5: frontend.v1.DeleteRequest
+	(*DeleteResponse)(nil),   // 6: frontend.v1.DeleteResponse
+	(*ScanRequest)(nil),      // 7: frontend.v1.ScanRequest
+	(*ScanResponse)(nil),     // 8: frontend.v1.ScanResponse
+	(*BatchPutRequest)(nil),  // 9: frontend.v1.BatchPutRequest
+	(*BatchPutResponse)(nil), // 10: frontend.v1.BatchPutResponse
+	(*BatchGetRequest)(nil),  // 11: frontend.v1.BatchGetRequest
+	(*BatchGetResponse)(nil), // 12: frontend.v1.BatchGetResponse
+	(*GetResult)(nil),        // 13: frontend.v1.GetResult
+	(*WatchRequest)(nil),     // 14: frontend.v1.WatchRequest
+	(*WatchResponse)(nil),    // 15: frontend.v1.WatchResponse
+	(*HistoryRequest)(nil),   // 16: frontend.v1.HistoryRequest
+	(*HistoryResponse)(nil),  // 17: frontend.v1.HistoryResponse
+	(*Version)(nil),          // 18: frontend.v1.Version
+	(*ImportRequest)(nil),    // 19: frontend.v1.ImportRequest
+	(*ImportResponse)(nil),   // 20: frontend.v1.ImportResponse
+	(*ExportRequest)(nil),    // 21: frontend.v1.ExportRequest
+	(*ExportResponse)(nil),   // 22: frontend.v1.ExportResponse
 }
 var file_frontend_v1_service_proto_depIdxs = []int32{
-	0, // 0: frontend.v1.FrontendService.Put:input_type -> frontend.v1.PutRequest
-	2, // 1: frontend.v1.FrontendService.Get:input_type -> frontend.v1.GetRequest
-	1, // 2: frontend.v1.FrontendService.Put:output_type -> frontend.v1.PutResponse
-	3, // 3: frontend.v1.FrontendService.Get:output_type -> frontend.v1.GetResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1,  // 0: frontend.v1.BatchPutRequest.entries:type_name -> frontend.v1.PutRequest
+	13, // 1: frontend.v1.BatchGetResponse.results:type_name -> frontend.v1.GetResult
+	18, // 2: frontend.v1.HistoryResponse.versions:type_name -> frontend.v1.Version
+	0,  // 3: frontend.v1.ImportRequest.format:type_name -> frontend.v1.Format
+	0,  // 4: frontend.v1.ExportRequest.format:type_name -> frontend.v1.Format
+	1,  // 5: frontend.v1.FrontendService.Put:input_type -> frontend.v1.PutRequest
+	3,  // 6: frontend.v1.FrontendService.Get:input_type -> frontend.v1.GetRequest
+	5,  // 7: frontend.v1.FrontendService.Delete:input_type -> frontend.v1.DeleteRequest
+	7,  // 8: frontend.v1.FrontendService.Scan:input_type -> frontend.v1.ScanRequest
+	9,  // 9: frontend.v1.FrontendService.BatchPut:input_type -> frontend.v1.BatchPutRequest
+	11, // 10: frontend.v1.FrontendService.BatchGet:input_type -> frontend.v1.BatchGetRequest
+	14, // 11: frontend.v1.FrontendService.Watch:input_type -> frontend.v1.WatchRequest
+	19, // 12: frontend.v1.FrontendService.Import:input_type -> frontend.v1.ImportRequest
+	21, // 13: frontend.v1.FrontendService.Export:input_type -> frontend.v1.ExportRequest
+	16, // 14: frontend.v1.FrontendService.History:input_type -> frontend.v1.HistoryRequest
+	2,  // 15: frontend.v1.FrontendService.Put:output_type -> frontend.v1.PutResponse
+	4,  // 16: frontend.v1.FrontendService.Get:output_type -> frontend.v1.GetResponse
+	6,  // 17: frontend.v1.FrontendService.Delete:output_type -> frontend.v1.DeleteResponse
+	8,  // 18: frontend.v1.FrontendService.Scan:output_type -> frontend.v1.ScanResponse
+	10, // 19: frontend.v1.FrontendService.BatchPut:output_type -> frontend.v1.BatchPutResponse
+	12, // 20: frontend.v1.FrontendService.BatchGet:output_type -> frontend.v1.BatchGetResponse
+	15, // 21: frontend.v1.FrontendService.Watch:output_type -> frontend.v1.WatchResponse
+	20, // 22: frontend.v1.FrontendService.Import:output_type -> frontend.v1.ImportResponse
+	22, // 23: frontend.v1.FrontendService.Export:output_type -> frontend.v1.ExportResponse
+	17, // 24: frontend.v1.FrontendService.History:output_type -> frontend.v1.HistoryResponse
+	15, // [15:25] is the sub-list for method output_type
+	5,  // [5:15] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_frontend_v1_service_proto_init() }
@@ -297,13 +1966,14 @@ func file_frontend_v1_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_frontend_v1_service_proto_rawDesc), len(file_frontend_v1_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_frontend_v1_service_proto_goTypes,
 		DependencyIndexes: file_frontend_v1_service_proto_depIdxs,
+		EnumInfos:         file_frontend_v1_service_proto_enumTypes,
 		MessageInfos:      file_frontend_v1_service_proto_msgTypes,
 	}.Build()
 	File_frontend_v1_service_proto = out.File
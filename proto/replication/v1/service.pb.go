@@ -0,0 +1,477 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: replication/v1/service.proto
+
+package v1
+
+import (
+	reflect "reflect"
+	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Change is a committed write of the primary's change log.
+type Change struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Seq         int64                  `protobuf:"varint,1,opt,name=seq"`
+	xxx_hidden_Key         int64                  `protobuf:"varint,2,opt,name=key"`
+	xxx_hidden_Value       string                 `protobuf:"bytes,3,opt,name=value"`
+	xxx_hidden_Deleted     bool                   `protobuf:"varint,4,opt,name=deleted"`
+	xxx_hidden_CommittedAt int64                  `protobuf:"varint,5,opt,name=committed_at,json=committedAt"`
+	xxx_hidden_Namespace   string                 `protobuf:"bytes,6,opt,name=namespace"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Change) Reset() {
+	*x = Change{}
+	mi := &file_replication_v1_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Change) ProtoMessage() {}
+
+func (x *Change) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_v1_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Change) GetSeq() int64 {
+	if x != nil {
+		return x.xxx_hidden_Seq
+	}
+	return 0
+}
+
+func (x *Change) GetKey() int64 {
+	if x != nil {
+		return x.xxx_hidden_Key
+	}
+	return 0
+}
+
+func (x *Change) GetValue() string {
+	if x != nil {
+		return x.xxx_hidden_Value
+	}
+	return ""
+}
+
+func (x *Change) GetDeleted() bool {
+	if x != nil {
+		return x.xxx_hidden_Deleted
+	}
+	return false
+}
+
+func (x *Change) GetCommittedAt() int64 {
+	if x != nil {
+		return x.xxx_hidden_CommittedAt
+	}
+	return 0
+}
+
+func (x *Change) GetNamespace() string {
+	if x != nil {
+		return x.xxx_hidden_Namespace
+	}
+	return ""
+}
+
+func (x *Change) SetSeq(v int64) {
+	x.xxx_hidden_Seq = v
+}
+
+func (x *Change) SetKey(v int64) {
+	x.xxx_hidden_Key = v
+}
+
+func (x *Change) SetValue(v string) {
+	x.xxx_hidden_Value = v
+}
+
+func (x *Change) SetDeleted(v bool) {
+	x.xxx_hidden_Deleted = v
+}
+
+func (x *Change) SetCommittedAt(v int64) {
+	x.xxx_hidden_CommittedAt = v
+}
+
+func (x *Change) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = v
+}
+
+type Change_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Seq     int64
+	Key     int64
+	Value   string
+	Deleted bool
+	// Unix nanoseconds.
+	CommittedAt int64
+	// Empty for the default namespace.
+	Namespace string
+}
+
+func (b0 Change_builder) Build() *Change {
+	m0 := &Change{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Seq = b.Seq
+	x.xxx_hidden_Key = b.Key
+	x.xxx_hidden_Value = b.Value
+	x.xxx_hidden_Deleted = b.Deleted
+	x.xxx_hidden_CommittedAt = b.CommittedAt
+	x.xxx_hidden_Namespace = b.Namespace
+	return m0
+}
+
+type StreamChangesRequest struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_AfterSeq int64                  `protobuf:"varint,1,opt,name=after_seq,json=afterSeq"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *StreamChangesRequest) Reset() {
+	*x = StreamChangesRequest{}
+	mi := &file_replication_v1_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamChangesRequest) ProtoMessage() {}
+
+func (x *StreamChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_v1_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *StreamChangesRequest) GetAfterSeq() int64 {
+	if x != nil {
+		return x.xxx_hidden_AfterSeq
+	}
+	return 0
+}
+
+func (x *StreamChangesRequest) SetAfterSeq(v int64) {
+	x.xxx_hidden_AfterSeq = v
+}
+
+type StreamChangesRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Stream changes following this sequence number.
+	AfterSeq int64
+}
+
+func (b0 StreamChangesRequest_builder) Build() *StreamChangesRequest {
+	m0 := &StreamChangesRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_AfterSeq = b.AfterSeq
+	return m0
+}
+
+type StreamChangesResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Changes *[]*Change             `protobuf:"bytes,1,rep,name=changes"`
+	xxx_hidden_LastSeq int64                  `protobuf:"varint,2,opt,name=last_seq,json=lastSeq"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *StreamChangesResponse) Reset() {
+	*x = StreamChangesResponse{}
+	mi := &file_replication_v1_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamChangesResponse) ProtoMessage() {}
+
+func (x *StreamChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_v1_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *StreamChangesResponse) GetChanges() []*Change {
+	if x != nil {
+		if x.xxx_hidden_Changes != nil {
+			return *x.xxx_hidden_Changes
+		}
+	}
+	return nil
+}
+
+func (x *StreamChangesResponse) GetLastSeq() int64 {
+	if x != nil {
+		return x.xxx_hidden_LastSeq
+	}
+	return 0
+}
+
+func (x *StreamChangesResponse) SetChanges(v []*Change) {
+	x.xxx_hidden_Changes = &v
+}
+
+func (x *StreamChangesResponse) SetLastSeq(v int64) {
+	x.xxx_hidden_LastSeq = v
+}
+
+type StreamChangesResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Changes in log order. Empty in heartbeats sent while idle.
+	Changes []*Change
+	// The primary's latest sequence number when the message was sent.
+	LastSeq int64
+}
+
+func (b0 StreamChangesResponse_builder) Build() *StreamChangesResponse {
+	m0 := &StreamChangesResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Changes = &b.Changes
+	x.xxx_hidden_LastSeq = b.LastSeq
+	return m0
+}
+
+type CopyDatabaseRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CopyDatabaseRequest) Reset() {
+	*x = CopyDatabaseRequest{}
+	mi := &file_replication_v1_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopyDatabaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyDatabaseRequest) ProtoMessage() {}
+
+func (x *CopyDatabaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_v1_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type CopyDatabaseRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 CopyDatabaseRequest_builder) Build() *CopyDatabaseRequest {
+	m0 := &CopyDatabaseRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type CopyDatabaseResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Metadata []byte                 `protobuf:"bytes,1,opt,name=metadata"`
+	xxx_hidden_Data     []byte                 `protobuf:"bytes,2,opt,name=data"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CopyDatabaseResponse) Reset() {
+	*x = CopyDatabaseResponse{}
+	mi := &file_replication_v1_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopyDatabaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyDatabaseResponse) ProtoMessage() {}
+
+func (x *CopyDatabaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_v1_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CopyDatabaseResponse) GetMetadata() []byte {
+	if x != nil {
+		return x.xxx_hidden_Metadata
+	}
+	return nil
+}
+
+func (x *CopyDatabaseResponse) GetData() []byte {
+	if x != nil {
+		return x.xxx_hidden_Data
+	}
+	return nil
+}
+
+func (x *CopyDatabaseResponse) SetMetadata(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Metadata = v
+}
+
+func (x *CopyDatabaseResponse) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Data = v
+}
+
+type CopyDatabaseResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The backup's JSON metadata, in the first message only.
+	Metadata []byte
+	// The next chunk of the backup.
+	Data []byte
+}
+
+func (b0 CopyDatabaseResponse_builder) Build() *CopyDatabaseResponse {
+	m0 := &CopyDatabaseResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Metadata = b.Metadata
+	x.xxx_hidden_Data = b.Data
+	return m0
+}
+
+var File_replication_v1_service_proto protoreflect.FileDescriptor
+
+const file_replication_v1_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1creplication/v1/service.proto\x12\x0ereplication.v1\"\xc7\x01\n" +
+	"\x06Change\x12\x17\n" +
+	"\x03seq\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03seq\x12\x17\n" +
+	"\x03key\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\x03key\x12\x1b\n" +
+	"\x05value\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x02R\x05value\x12\x1f\n" +
+	"\adeleted\x18\x04 \x01(\bB\x05\xaa\x01\x02\b\x02R\adeleted\x12(\n" +
+	"\fcommitted_at\x18\x05 \x01(\x03B\x05\xaa\x01\x02\b\x02R\vcommittedAt\x12#\n" +
+	"\tnamespace\x18\x06 \x01(\tB\x05\xaa\x01\x02\b\x02R\tnamespace\":\n" +
+	"\x14StreamChangesRequest\x12\"\n" +
+	"\tafter_seq\x18\x01 \x01(\x03B\x05\xaa\x01\x02\b\x02R\bafterSeq\"k\n" +
+	"\x15StreamChangesResponse\x120\n" +
+	"\achanges\x18\x01 \x03(\v2\x16.replication.v1.ChangeR\achanges\x12 \n" +
+	"\blast_seq\x18\x02 \x01(\x03B\x05\xaa\x01\x02\b\x02R\alastSeq\"\x15\n" +
+	"\x13CopyDatabaseRequest\"T\n" +
+	"\x14CopyDatabaseResponse\x12!\n" +
+	"\bmetadata\x18\x01 \x01(\fB\x05\xaa\x01\x02\b\x02R\bmetadata\x12\x19\n" +
+	"\x04data\x18\x02 \x01(\fB\x05\xaa\x01\x02\b\x02R\x04data2\xd1\x01\n" +
+	"\x12ReplicationService\x12^\n" +
+	"\rStreamChanges\x12$.replication.v1.StreamChangesRequest\x1a%.replication.v1.StreamChangesResponse0\x01\x12[\n" +
+	"\fCopyDatabase\x12#.replication.v1.CopyDatabaseRequest\x1a$.replication.v1.CopyDatabaseResponse0\x01B/Z-github.com/dynoinc/gh-go/proto/replication/v1b\beditionsp\xe8\a"
+
+var file_replication_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_replication_v1_service_proto_goTypes = []any{
+	(*Change)(nil),                // 0: replication.v1.Change
+	(*StreamChangesRequest)(nil),  // 1: replication.v1.StreamChangesRequest
+	(*StreamChangesResponse)(nil), // 2: replication.v1.StreamChangesResponse
+	(*CopyDatabaseRequest)(nil),   // 3: replication.v1.CopyDatabaseRequest
+	(*CopyDatabaseResponse)(nil),  // 4: replication.v1.CopyDatabaseResponse
+}
+var file_replication_v1_service_proto_depIdxs = []int32{
+	0, // 0: replication.v1.StreamChangesResponse.changes:type_name -> replication.v1.Change
+	1, // 1: replication.v1.ReplicationService.StreamChanges:input_type -> replication.v1.StreamChangesRequest
+	3, // 2: replication.v1.ReplicationService.CopyDatabase:input_type -> replication.v1.CopyDatabaseRequest
+	2, // 3: replication.v1.ReplicationService.StreamChanges:output_type -> replication.v1.StreamChangesResponse
+	4, // 4: replication.v1.ReplicationService.CopyDatabase:output_type -> replication.v1.CopyDatabaseResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_replication_v1_service_proto_init() }
+func file_replication_v1_service_proto_init() {
+	if File_replication_v1_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_replication_v1_service_proto_rawDesc), len(file_replication_v1_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_replication_v1_service_proto_goTypes,
+		DependencyIndexes: file_replication_v1_service_proto_depIdxs,
+		MessageInfos:      file_replication_v1_service_proto_msgTypes,
+	}.Build()
+	File_replication_v1_service_proto = out.File
+	file_replication_v1_service_proto_goTypes = nil
+	file_replication_v1_service_proto_depIdxs = nil
+}
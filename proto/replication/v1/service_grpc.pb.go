@@ -0,0 +1,178 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: replication/v1/service.proto
+
+package v1
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReplicationService_StreamChanges_FullMethodName = "/replication.v1.ReplicationService/StreamChanges"
+	ReplicationService_CopyDatabase_FullMethodName  = "/replication.v1.ReplicationService/CopyDatabase"
+)
+
+// ReplicationServiceClient is the client API for ReplicationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ReplicationService ships the primary's change log to followers.
+type ReplicationServiceClient interface {
+	// StreamChanges fails with OUT_OF_RANGE if changes the follower needs
+	// were trimmed from the change log.
+	StreamChanges(ctx context.Context, in *StreamChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamChangesResponse], error)
+	// CopyDatabase streams a backup of the primary's database, from which
+	// followers start over when changes they need were trimmed.
+	CopyDatabase(ctx context.Context, in *CopyDatabaseRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CopyDatabaseResponse], error)
+}
+
+type replicationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReplicationServiceClient(cc grpc.ClientConnInterface) ReplicationServiceClient {
+	return &replicationServiceClient{cc}
+}
+
+func (c *replicationServiceClient) StreamChanges(ctx context.Context, in *StreamChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamChangesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ReplicationService_ServiceDesc.Streams[0], ReplicationService_StreamChanges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamChangesRequest, StreamChangesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReplicationService_StreamChangesClient = grpc.ServerStreamingClient[StreamChangesResponse]
+
+func (c *replicationServiceClient) CopyDatabase(ctx context.Context, in *CopyDatabaseRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CopyDatabaseResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ReplicationService_ServiceDesc.Streams[1], ReplicationService_CopyDatabase_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CopyDatabaseRequest, CopyDatabaseResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReplicationService_CopyDatabaseClient = grpc.ServerStreamingClient[CopyDatabaseResponse]
+
+// ReplicationServiceServer is the server API for ReplicationService service.
+// All implementations must embed UnimplementedReplicationServiceServer
+// for forward compatibility.
+//
+// ReplicationService ships the primary's change log to followers.
+type ReplicationServiceServer interface {
+	// StreamChanges fails with OUT_OF_RANGE if changes the follower needs
+	// were trimmed from the change log.
+	StreamChanges(*StreamChangesRequest, grpc.ServerStreamingServer[StreamChangesResponse]) error
+	// CopyDatabase streams a backup of the primary's database, from which
+	// followers start over when changes they need were trimmed.
+	CopyDatabase(*CopyDatabaseRequest, grpc.ServerStreamingServer[CopyDatabaseResponse]) error
+	mustEmbedUnimplementedReplicationServiceServer()
+}
+
+// UnimplementedReplicationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReplicationServiceServer struct{}
+
+func (UnimplementedReplicationServiceServer) StreamChanges(*StreamChangesRequest, grpc.ServerStreamingServer[StreamChangesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamChanges not implemented")
+}
+func (UnimplementedReplicationServiceServer) CopyDatabase(*CopyDatabaseRequest, grpc.ServerStreamingServer[CopyDatabaseResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CopyDatabase not implemented")
+}
+func (UnimplementedReplicationServiceServer) mustEmbedUnimplementedReplicationServiceServer() {}
+func (UnimplementedReplicationServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeReplicationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReplicationServiceServer will
+// result in compilation errors.
+type UnsafeReplicationServiceServer interface {
+	mustEmbedUnimplementedReplicationServiceServer()
+}
+
+func RegisterReplicationServiceServer(s grpc.ServiceRegistrar, srv ReplicationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedReplicationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReplicationService_ServiceDesc, srv)
+}
+
+func _ReplicationService_StreamChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReplicationServiceServer).StreamChanges(m, &grpc.GenericServerStream[StreamChangesRequest, StreamChangesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReplicationService_StreamChangesServer = grpc.ServerStreamingServer[StreamChangesResponse]
+
+func _ReplicationService_CopyDatabase_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CopyDatabaseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReplicationServiceServer).CopyDatabase(m, &grpc.GenericServerStream[CopyDatabaseRequest, CopyDatabaseResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReplicationService_CopyDatabaseServer = grpc.ServerStreamingServer[CopyDatabaseResponse]
+
+// ReplicationService_ServiceDesc is the grpc.ServiceDesc for ReplicationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReplicationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "replication.v1.ReplicationService",
+	HandlerType: (*ReplicationServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamChanges",
+			Handler:       _ReplicationService_StreamChanges_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CopyDatabase",
+			Handler:       _ReplicationService_CopyDatabase_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "replication/v1/service.proto",
+}